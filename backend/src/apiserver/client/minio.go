@@ -15,8 +15,11 @@
 package client
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff"
@@ -26,11 +29,39 @@ import (
 	"github.com/pkg/errors"
 )
 
+// SignatureVersion is the AWS signature version requests to the object store are signed with.
+type SignatureVersion string
+
+const (
+	SignatureV4 SignatureVersion = "v4"
+	// SignatureV2 is only meant for legacy object stores that reject V4 signatures.
+	SignatureV2 SignatureVersion = "v2"
+)
+
+// ParseSignatureVersion parses a configured signature version. An empty value selects V4.
+func ParseSignatureVersion(value string) (SignatureVersion, error) {
+	switch SignatureVersion(strings.ToLower(strings.TrimSpace(value))) {
+	case "", SignatureV4:
+		return SignatureV4, nil
+	case SignatureV2:
+		return SignatureV2, nil
+	default:
+		return "", errors.Errorf("unsupported signature version %q, use %q or %q", value, SignatureV4, SignatureV2)
+	}
+}
+
+func (v SignatureVersion) signerType() credentials.SignatureType {
+	if v == SignatureV2 {
+		return credentials.SignatureV2
+	}
+	return credentials.SignatureV4
+}
+
 // createCredentialProvidersChain creates a chained providers credential for a minio client.
-func createCredentialProvidersChain(endpoint, accessKey, secretKey string) *credentials.Credentials {
+func createCredentialProvidersChain(endpoint, accessKey, secretKey string, signatureVersion SignatureVersion) *credentials.Credentials {
 	// first try with static api key
 	if accessKey != "" && secretKey != "" {
-		return credentials.NewStaticV4(accessKey, secretKey, "")
+		return credentials.NewStatic(accessKey, secretKey, "", signatureVersion.signerType())
 	}
 	// otherwise use a chained provider: minioEnv -> awsEnv -> IAM
 	providers := []credentials.Provider{
@@ -42,18 +73,47 @@ func createCredentialProvidersChain(endpoint, accessKey, secretKey string) *cred
 			},
 		},
 	}
-	return credentials.New(&credentials.Chain{Providers: providers})
+	var provider credentials.Provider = &credentials.Chain{Providers: providers}
+	if signatureVersion == SignatureV2 {
+		provider = &signerTypeProvider{Provider: provider, signerType: credentials.SignatureV2}
+	}
+	return credentials.New(provider)
 }
 
+// signerTypeProvider overrides the signature type of the credentials retrieved by a provider.
+type signerTypeProvider struct {
+	credentials.Provider
+	signerType credentials.SignatureType
+}
+
+func (p *signerTypeProvider) Retrieve() (credentials.Value, error) {
+	return p.RetrieveWithCredContext(nil)
+}
+
+func (p *signerTypeProvider) RetrieveWithCredContext(cc *credentials.CredContext) (credentials.Value, error) {
+	value, err := p.Provider.RetrieveWithCredContext(cc)
+	if err != nil {
+		return value, err
+	}
+	value.SignerType = p.signerType
+	return value, nil
+}
+
+// CreateMinioClient creates a minio client. A nil transport makes the client use its default transport.
+// maxRetries is the number of attempts of each request made by the client itself, zero
+// keeping its default of 10.
 func CreateMinioClient(minioServiceHost string, minioServicePort string,
-	accessKey string, secretKey string, secure bool, region string,
+	accessKey string, secretKey string, secure bool, region string, signatureVersion SignatureVersion,
+	transport http.RoundTripper, maxRetries int,
 ) (*minio.Client, error) {
 	endpoint := joinHostPort(minioServiceHost, minioServicePort)
-	cred := createCredentialProvidersChain(endpoint, accessKey, secretKey)
+	cred := createCredentialProvidersChain(endpoint, accessKey, secretKey, signatureVersion)
 	minioClient, err := minio.New(endpoint, &minio.Options{
-		Creds:  cred,
-		Secure: secure,
-		Region: region,
+		Creds:      cred,
+		Secure:     secure,
+		Region:     region,
+		Transport:  transport,
+		MaxRetries: maxRetries,
 	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "Error while creating object store client: %+v", err)
@@ -62,13 +122,14 @@ func CreateMinioClient(minioServiceHost string, minioServicePort string,
 }
 
 func CreateMinioClientOrFatal(minioServiceHost string, minioServicePort string,
-	accessKey string, secretKey string, secure bool, region string, initConnectionTimeout time.Duration,
+	accessKey string, secretKey string, secure bool, region string, signatureVersion SignatureVersion,
+	transport http.RoundTripper, maxRetries int, initConnectionTimeout time.Duration,
 ) *minio.Client {
 	var minioClient *minio.Client
 	var err error
 	operation := func() error {
 		minioClient, err = CreateMinioClient(minioServiceHost, minioServicePort,
-			accessKey, secretKey, secure, region)
+			accessKey, secretKey, secure, region, signatureVersion, transport, maxRetries)
 		if err != nil {
 			return err
 		}
@@ -83,6 +144,68 @@ func CreateMinioClientOrFatal(minioServiceHost string, minioServicePort string,
 	return minioClient
 }
 
+// TransportConfig sizes the connection pool of the object store transport. Zero fields keep
+// the defaults of the minio client.
+type TransportConfig struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	// KeepAlive is the interval between keep-alive probes on open connections.
+	KeepAlive time.Duration
+}
+
+// dialTimeout matches the dial timeout of the minio client default transport.
+const dialTimeout = 30 * time.Second
+
+// CreateMinioTransport creates the default minio client transport, tuned by config.
+func CreateMinioTransport(secure bool, config TransportConfig) (*http.Transport, error) {
+	transport, err := minio.DefaultTransport(secure)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error while creating object store transport: %+v", err)
+	}
+	if config.MaxIdleConns > 0 {
+		transport.MaxIdleConns = config.MaxIdleConns
+	}
+	if config.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	}
+	if config.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = config.IdleConnTimeout
+	}
+	if config.KeepAlive > 0 {
+		transport.DialContext = (&net.Dialer{
+			Timeout:   dialTimeout,
+			KeepAlive: config.KeepAlive,
+		}).DialContext
+	}
+	return transport, nil
+}
+
+// BucketLocator looks up the region a bucket is located in. It is satisfied by *minio.Client.
+type BucketLocator interface {
+	GetBucketLocation(ctx context.Context, bucketName string) (string, error)
+}
+
+// DetectBucketRegion asks the object store for the region of the bucket, falling back to
+// the configured region when the lookup fails or reports no region.
+func DetectBucketRegion(ctx context.Context, locator BucketLocator, bucketName string, configuredRegion string) string {
+	region, err := locator.GetBucketLocation(ctx, bucketName)
+	if err != nil {
+		glog.Warningf("Failed to detect the region of bucket %s, using configured region %q. Error: %v",
+			bucketName, configuredRegion, err)
+		return configuredRegion
+	}
+	if region == "" {
+		return configuredRegion
+	}
+	if region != configuredRegion {
+		glog.Infof("Detected region %q for bucket %s, overriding configured region %q", region, bucketName, configuredRegion)
+	} else {
+		glog.Infof("Detected region %q for bucket %s", region, bucketName)
+	}
+	return region
+}
+
 // joinHostPort combines host and port into a network address of the form "host:port".
 //
 // An empty port value results in "host" instead of "host:" (which net.JoinHostPort would return).
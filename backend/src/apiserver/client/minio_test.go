@@ -0,0 +1,145 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	minio "github.com/minio/minio-go/v7"
+	credentials "github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeBucketLocator struct {
+	region string
+	err    error
+}
+
+func (l *fakeBucketLocator) GetBucketLocation(ctx context.Context, bucketName string) (string, error) {
+	return l.region, l.err
+}
+
+func TestDetectBucketRegion(t *testing.T) {
+	tests := []struct {
+		name    string
+		locator *fakeBucketLocator
+		want    string
+	}{
+		{
+			name:    "detected region is used",
+			locator: &fakeBucketLocator{region: "eu-west-1"},
+			want:    "eu-west-1",
+		},
+		{
+			name:    "detection error falls back to configured region",
+			locator: &fakeBucketLocator{err: errors.New("access denied")},
+			want:    "us-east-1",
+		},
+		{
+			name:    "empty detected region falls back to configured region",
+			locator: &fakeBucketLocator{},
+			want:    "us-east-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectBucketRegion(context.TODO(), tt.locator, "mlpipeline", "us-east-1"))
+		})
+	}
+}
+
+func TestParseSignatureVersion(t *testing.T) {
+	for value, expected := range map[string]SignatureVersion{"": SignatureV4, "v4": SignatureV4, "V2": SignatureV2, " v2 ": SignatureV2} {
+		version, err := ParseSignatureVersion(value)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, version, value)
+	}
+	_, err := ParseSignatureVersion("v3")
+	assert.NotNil(t, err)
+}
+
+func TestCreateMinioClient_SignatureVersion(t *testing.T) {
+	for version, expected := range map[SignatureVersion]credentials.SignatureType{
+		SignatureV4: credentials.SignatureV4,
+		SignatureV2: credentials.SignatureV2,
+	} {
+		minioClient, err := CreateMinioClient("localhost", "9000", "access", "secret", false, "", version, nil, 0)
+		assert.Nil(t, err)
+		creds, err := minioClient.GetCreds()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, creds.SignerType, version)
+	}
+}
+
+func TestCreateCredentialProvidersChain_SignatureV2(t *testing.T) {
+	t.Setenv("MINIO_ACCESS_KEY", "access")
+	t.Setenv("MINIO_SECRET_KEY", "secret")
+	creds, err := createCredentialProvidersChain("localhost:9000", "", "", SignatureV2).GetWithContext(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "access", creds.AccessKeyID)
+	assert.True(t, creds.SignerType.IsV2())
+}
+
+func TestCreateMinioTransport(t *testing.T) {
+	transport, err := CreateMinioTransport(false, TransportConfig{
+		MaxIdleConns:        512,
+		MaxIdleConnsPerHost: 64,
+		IdleConnTimeout:     5 * time.Minute,
+		KeepAlive:           15 * time.Second,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 512, transport.MaxIdleConns)
+	assert.Equal(t, 64, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 5*time.Minute, transport.IdleConnTimeout)
+	assert.NotNil(t, transport.DialContext)
+}
+
+func TestCreateMinioTransport_Defaults(t *testing.T) {
+	transport, err := CreateMinioTransport(true, TransportConfig{})
+	assert.Nil(t, err)
+	defaultTransport, err := minio.DefaultTransport(true)
+	assert.Nil(t, err)
+	assert.Equal(t, defaultTransport.MaxIdleConns, transport.MaxIdleConns)
+	assert.Equal(t, defaultTransport.MaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, defaultTransport.IdleConnTimeout, transport.IdleConnTimeout)
+	assert.True(t, transport.DisableCompression)
+	assert.NotNil(t, transport.TLSClientConfig)
+}
+
+func TestCreateMinioClient_MaxRetries(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	host, port, err := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	assert.Nil(t, err)
+
+	minioClient, err := CreateMinioClient(host, port, "access", "secret", false, "us-east-1", SignatureV4, nil, 1)
+	assert.Nil(t, err)
+	_, err = minioClient.StatObject(context.Background(), "bucket", "object", minio.StatObjectOptions{})
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"sync"
@@ -37,6 +38,8 @@ import (
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	k8sapi "github.com/kubeflow/pipelines/backend/src/crd/kubernetes/v2beta1"
 	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"go.opentelemetry.io/otel"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -286,6 +289,9 @@ func (c *ClientManager) init(options *Options) error {
 	c.defaultExperimentStore = storage.NewDefaultExperimentStore(db)
 	glog.Info("Initializing Object store client...")
 	c.objectStore = initMinioClient(options.Context, common.GetDurationConfig(initConnectionTimeout))
+	if common.GetBoolConfigWithDefault("ObjectStoreConfig.RecordMetricsToDB", false) {
+		c.objectStore = storage.NewMetricsRecordingObjectStore(c.objectStore, storage.NewObjectStoreMetricStore(db))
+	}
 	glog.Info("Object store client initialized successfully")
 	// Use default value of client QPS (5) & burst (10) defined in
 	// k8s.io/client-go/rest/config.go#RESTClientFor
@@ -318,6 +324,11 @@ func (c *ClientManager) init(options *Options) error {
 
 func (c *ClientManager) Close() {
 	c.db.Close()
+	if c.objectStore != nil {
+		if err := c.objectStore.Close(); err != nil {
+			glog.Errorf("Failed to close the object store: %v", err)
+		}
+	}
 }
 
 // addDisplayNameColumn adds a DisplayName column to the given table with a default value of Name.
@@ -401,6 +412,7 @@ func InitDBClient(initConnectionTimeout time.Duration) *storage.DB {
 		&model.RunMetric{},
 		&model.Task{},
 		&model.ResourceReference{},
+		&model.ObjectStoreMetric{},
 	)
 
 	if ignoreAlreadyExistError(driverName, response.Error) != nil {
@@ -638,12 +650,152 @@ func initMinioClient(ctx context.Context, initConnectionTimeout time.Duration) s
 	bucketName := common.GetStringConfigWithDefault("ObjectStoreConfig.BucketName", os.Getenv(pipelineBucketName))
 	pipelinePath := common.GetStringConfigWithDefault("ObjectStoreConfig.PipelinePath", os.Getenv(pipelinePath))
 	disableMultipart := common.GetBoolConfigWithDefault("ObjectStoreConfig.Multipart.Disable", true)
+	signatureVersion, err := client.ParseSignatureVersion(
+		common.GetStringConfigWithDefault("ObjectStoreConfig.SignatureVersion", string(client.SignatureV4)))
+	if err != nil {
+		glog.Fatalf("Failed to read the object store signature version. Error: %v", err)
+	}
 
-	minioClient := client.CreateMinioClientOrFatal(minioServiceHost, minioServicePort, accessKey,
-		secretKey, minioServiceSecure, minioServiceRegion, initConnectionTimeout)
-	createMinioBucket(ctx, minioClient, bucketName, minioServiceRegion)
+	transport, err := client.CreateMinioTransport(minioServiceSecure, client.TransportConfig{
+		MaxIdleConns:        common.GetIntConfigWithDefault("ObjectStoreConfig.Transport.MaxIdleConns", 0),
+		MaxIdleConnsPerHost: common.GetIntConfigWithDefault("ObjectStoreConfig.Transport.MaxIdleConnsPerHost", 0),
+		IdleConnTimeout:     common.GetDurationConfigWithDefault("ObjectStoreConfig.Transport.IdleConnTimeout", 0),
+		KeepAlive:           common.GetDurationConfigWithDefault("ObjectStoreConfig.Transport.KeepAlive", 0),
+	})
+	if err != nil {
+		glog.Fatalf("Failed to create object store transport. Error: %v", err)
+	}
+	var clientTransport http.RoundTripper = transport
+	if common.GetBoolConfigWithDefault("ObjectStoreConfig.Tracing", false) {
+		clientTransport = storage.NewTracePropagatingTransport(transport)
+	}
+	retryAfterTransport := storage.NewRetryAfterTransport(clientTransport)
+	retryPolicy := storage.RetryPolicy{
+		MaxAttempts:   common.GetIntConfigWithDefault("ObjectStoreConfig.Retry.MaxAttempts", 1),
+		Backoff:       common.GetDurationConfigWithDefault("ObjectStoreConfig.Retry.Backoff", 100*time.Millisecond),
+		MaxRetryAfter: common.GetDurationConfigWithDefault("ObjectStoreConfig.Retry.MaxRetryAfter", 30*time.Second),
+	}
+	// The minio client retries on its own, ignoring Retry-After, before the store sees the
+	// error; when the header is honoured the store alone retries.
+	minioMaxRetries := 0
+	if retryPolicy.MaxRetryAfter > 0 {
+		minioMaxRetries = 1
+	}
+	minioClient := client.CreateMinioClientOrFatal(minioServiceHost, minioServicePort, accessKey, secretKey,
+		minioServiceSecure, minioServiceRegion, signatureVersion, retryAfterTransport, minioMaxRetries, initConnectionTimeout)
+	if common.GetBoolConfigWithDefault("ObjectStoreConfig.AutoDetectRegion", false) {
+		detectedRegion := client.DetectBucketRegion(ctx, minioClient, bucketName, minioServiceRegion)
+		if detectedRegion != minioServiceRegion {
+			minioServiceRegion = detectedRegion
+			minioClient = client.CreateMinioClientOrFatal(minioServiceHost, minioServicePort, accessKey, secretKey,
+				minioServiceSecure, minioServiceRegion, signatureVersion, retryAfterTransport, minioMaxRetries, initConnectionTimeout)
+		}
+	}
+	opts := []storage.MinioObjectStoreOption{
+		storage.WithDisableMultipart(disableMultipart),
+		storage.WithPartSize(uint64(common.GetIntConfigWithDefault("ObjectStoreConfig.Multipart.PartSize", 0))),
+		storage.WithRetry(retryPolicy),
+		storage.WithSoftDelete(common.GetBoolConfigWithDefault("ObjectStoreConfig.SoftDelete", false)),
+		storage.WithYamlValidation(common.GetBoolConfigWithDefault("ObjectStoreConfig.ValidateYaml", false)),
+		storage.WithUploadVerification(common.GetBoolConfigWithDefault("ObjectStoreConfig.VerifyUploads", false)),
+		storage.WithBatchManifest(common.GetBoolConfigWithDefault("ObjectStoreConfig.BatchManifest", false)),
+		storage.WithMaxKeyLength(common.GetIntConfigWithDefault("ObjectStoreConfig.MaxKeyLength", 0)),
+		storage.WithReadAfterWriteTimeout(common.GetDurationConfigWithDefault("ObjectStoreConfig.ReadAfterWriteTimeout", 0)),
+		storage.WithOperationTimeout(common.GetDurationConfigWithDefault("ObjectStoreConfig.OperationTimeout", 0)),
+		storage.WithKeyNormalization(common.GetBoolConfigWithDefault("ObjectStoreConfig.NormalizeKeys", true)),
+		storage.WithMinYamlFileSize(
+			common.GetIntConfigWithDefault("ObjectStoreConfig.MinYamlFileSize", 0),
+			common.GetBoolConfigWithDefault("ObjectStoreConfig.RejectSmallYamlFiles", false)),
+		storage.WithHealthPolicy(storage.HealthPolicy{
+			Window:        common.GetDurationConfigWithDefault("ObjectStoreConfig.Health.Window", 0),
+			DegradedBelow: common.GetFloat64ConfigWithDefault("ObjectStoreConfig.Health.DegradedBelow", 0),
+			DownBelow:     common.GetFloat64ConfigWithDefault("ObjectStoreConfig.Health.DownBelow", 0),
+		}),
+		storage.WithKeySharding(common.GetBoolConfigWithDefault("ObjectStoreConfig.KeySharding", false)),
+		storage.WithUserAgent(common.GetStringConfigWithDefault("ObjectStoreConfig.UserAgent",
+			storage.DefaultUserAgent(common.GetStringConfigWithDefault("TAG_NAME", "unknown")))),
+		storage.WithMaxInFlightBytes(int64(common.GetIntConfigWithDefault("ObjectStoreConfig.MaxInFlightBytes", 0))),
+		storage.WithSizeDeadline(
+			common.GetDurationConfigWithDefault("ObjectStoreConfig.SizeDeadline.Base", 0),
+			common.GetDurationConfigWithDefault("ObjectStoreConfig.SizeDeadline.PerMegabyte", 0)),
+		storage.WithGzipVariants(common.GetBoolConfigWithDefault("ObjectStoreConfig.GzipVariants", false)),
+		storage.WithAccessTracking(common.GetDurationConfigWithDefault("ObjectStoreConfig.AccessTrackingInterval", 0)),
+	}
+	if common.GetBoolConfigWithDefault("ObjectStoreConfig.DecodeLegacyEncodings", false) {
+		opts = append(opts, storage.WithContentDecoders(storage.Base64ContentDecoder, storage.GzipContentDecoder))
+	}
+	if common.GetBoolConfigWithDefault("ObjectStoreConfig.NamespaceKeys", false) {
+		opts = append(opts, storage.WithKeyNamespacer(storage.DefaultKeyNamespacer))
+	}
+	keyPrefix := common.GetStringConfigWithDefault("ObjectStoreConfig.KeyTransform.Prefix", "")
+	keySuffix := common.GetStringConfigWithDefault("ObjectStoreConfig.KeyTransform.Suffix", "")
+	if keyPrefix != "" || keySuffix != "" {
+		opts = append(opts, storage.WithKeyTransform(storage.NewAffixKeyTransform(keyPrefix, keySuffix)))
+	}
+	if kmsKeyID := common.GetStringConfigWithDefault("ObjectStoreConfig.Encryption.KmsKeyID", ""); kmsKeyID != "" {
+		encryption, err := encrypt.NewSSEKMS(kmsKeyID, nil)
+		if err != nil {
+			glog.Fatalf("Failed to read the object store encryption key. Error: %v", err)
+		}
+		opts = append(opts, storage.WithEncryption(encryption))
+	} else if common.GetBoolConfigWithDefault("ObjectStoreConfig.Encryption.Enabled", false) {
+		opts = append(opts, storage.WithEncryption(encrypt.NewSSE()))
+	}
+	var objectStore *storage.MinioObjectStore
+	if storage.IsAccessPoint(bucketName) {
+		// Access points are provisioned together with their bucket, outside of KFP.
+		objectStore, err = storage.NewAccessPointObjectStore(&storage.MinioClient{Client: minioClient, Transport: transport},
+			bucketName, pipelinePath, opts...)
+		if err != nil {
+			glog.Fatalf("Failed to create object store. Error: %v", err)
+		}
+	} else {
+		createMinioBucket(ctx, minioClient, bucketName, minioServiceRegion)
+		objectStore = storage.NewMinioObjectStoreWithOptions(&storage.MinioClient{Client: minioClient, Transport: transport},
+			bucketName, pipelinePath, opts...)
+	}
+	prefixRewrites, err := storage.ParsePrefixRewrites(common.GetStringConfigWithDefault("ObjectStoreConfig.PrefixRewrites", ""))
+	if err != nil {
+		glog.Fatalf("Failed to read the object store prefix rewrites. Error: %v", err)
+	}
+	objectStore.SetPrefixRewrites(prefixRewrites)
+	objectStore.SetEnvironmentPrefix(common.GetStringConfigWithDefault("ObjectStoreConfig.EnvironmentPrefix", ""),
+		common.GetBoolConfigWithDefault("ObjectStoreConfig.RestrictReadsToEnvironment", false))
+	if common.GetBoolConfigWithDefault("ObjectStoreConfig.NamespaceKeys", false) &&
+		common.GetBoolConfigWithDefault("ObjectStoreConfig.MigrateLegacyKeys", false) && pipelinePath != "" {
+		// Moves the specs stored before namespacing was enabled under the spec namespace.
+		moved, err := objectStore.MigrateKeyNamespace(ctx, pipelinePath+"/",
+			common.GetIntConfigWithDefault("ObjectStoreConfig.MigrateLegacyKeysConcurrency", 8))
+		if err != nil {
+			glog.Fatalf("Failed to migrate the object store keys. Error: %v", err)
+		}
+		glog.Infof("Migrated %v object store keys to the spec namespace", moved)
+	}
+	if common.GetBoolConfigWithDefault("ObjectStoreConfig.CheckPermissionsAtStartup", false) {
+		if _, err := objectStore.LogPermissions(ctx, pipelinePath); err != nil {
+			glog.Warningf("Failed to check the object store permissions. Error: %v", err)
+		}
+	}
 
-	return storage.NewMinioObjectStore(&storage.MinioClient{Client: minioClient}, bucketName, pipelinePath, disableMultipart)
+	var store storage.ObjectStoreInterface = objectStore
+	if window := common.GetDurationConfigWithDefault("ObjectStoreConfig.WriteCoalescingWindow", 0); window > 0 {
+		store = storage.NewCoalescingObjectStore(store, window)
+	}
+	if maxEntries := common.GetIntConfigWithDefault("ObjectStoreConfig.Cache.MaxEntries", 0); maxEntries > 0 {
+		cachingStore := storage.NewCachingObjectStore(store, maxEntries)
+		if prefixes := common.GetStringConfigWithDefault("ObjectStoreConfig.Cache.ImmutablePrefixes", ""); prefixes != "" {
+			cachingStore.SetImmutablePrefixes(strings.Split(prefixes, ","))
+		}
+		store = cachingStore
+	}
+	if common.GetBoolConfigWithDefault("ObjectStoreConfig.RequestMemo", false) {
+		// The API server attaches a memo to the context of every request.
+		store = storage.NewMemoizingObjectStore(store)
+	}
+	if common.GetBoolConfigWithDefault("ObjectStoreConfig.Tracing", false) {
+		store = storage.NewTracingObjectStore(store, otel.GetTracerProvider())
+	}
+	return store
 }
 
 func createMinioBucket(ctx context.Context, minioClient *minio.Client, bucketName, region string) {
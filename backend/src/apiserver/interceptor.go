@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"google.golang.org/grpc"
 )
@@ -27,7 +28,9 @@ import (
 // For more details, see https://github.com/grpc/grpc-go/blob/master/interceptor.go
 func apiServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 	glog.Infof("%v handler starting", info.FullMethod)
-	resp, err = handler(ctx, req)
+	// Files read again during the request are served from its memo, if the object store
+	// memoizes reads.
+	resp, err = handler(storage.WithRequestMemo(ctx), req)
 	if err != nil {
 		util.LogError(util.Wrapf(err, "%s call failed", info.FullMethod))
 		// Convert error to gRPC errors
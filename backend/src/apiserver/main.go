@@ -39,6 +39,7 @@ import (
 	"github.com/kubeflow/pipelines/backend/src/apiserver/config/proxy"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/server"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/template"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/webhook"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
@@ -315,6 +316,11 @@ func startHttpProxy(resourceManager *resource.ResourceManager, usePipelinesKuber
 	// Register a handler for Prometheus to poll.
 	topMux.Handle("/metrics", promhttp.Handler())
 
+	// The object store admin lever is opt-in, since the HTTP proxy is not authenticated.
+	if common.GetBoolConfigWithDefault("ObjectStoreConfig.AdminEndpoint", false) {
+		topMux.HandleFunc("/admin/objectstore/force-fresh-reads", storage.ForceFreshReadsHandler)
+	}
+
 	http.ListenAndServe(*httpPortFlag, topMux)
 	glog.Info("Http Proxy started")
 }
@@ -0,0 +1,24 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// ObjectStoreMetric aggregates the successful object store operations on a file, for reporting.
+type ObjectStoreMetric struct {
+	FilePath     string `gorm:"column:FilePath; not null; primary_key;"`
+	Reads        int64  `gorm:"column:Reads; not null;"`
+	Writes       int64  `gorm:"column:Writes; not null;"`
+	BytesRead    int64  `gorm:"column:BytesRead; not null;"`
+	BytesWritten int64  `gorm:"column:BytesWritten; not null;"`
+}
@@ -997,7 +997,8 @@ func (r *ResourceManager) readRunLogFromArchive(workflowManifest string, nodeId
 		return util.NewInternalServerError(err, "Failed to read logs from archive %v", nodeId)
 	}
 
-	logContent, err := r.objectStore.GetFile(context.TODO(), logPath)
+	// Logs are archived by Argo at the key recorded in the workflow, never namespaced.
+	logContent, err := r.objectStore.GetFile(storage.WithKeyNamespace(context.TODO(), storage.KeyNamespaceNone), logPath)
 	if err != nil {
 		return util.NewInternalServerError(err, "Failed to read logs from archive %v due to error fetching the log file", nodeId)
 	}
@@ -1609,7 +1610,11 @@ func (r *ResourceManager) ReadArtifact(runID string, nodeID string, artifactName
 		return nil, util.NewResourceNotFoundError(
 			"artifact", common.CreateArtifactPath(runID, nodeID, artifactName))
 	}
-	return r.objectStore.GetFile(context.TODO(), artifactPath)
+	// Object store errors carry their class, so an artifact missing from the store is
+	// reported as NotFound, like one missing from the workflow.
+	// Artifacts are written by the launchers at the key recorded in the workflow, never
+	// namespaced.
+	return r.objectStore.GetFile(storage.WithKeyNamespace(context.TODO(), storage.KeyNamespaceNone), artifactPath)
 }
 
 // Fetches the default experiment id.
@@ -75,6 +75,18 @@ func (m *FakeBadObjectStore) GetFromYamlFile(ctx context.Context, o interface{},
 	return util.NewInternalServerError(errors.New("Error"), "bad object store")
 }
 
+func (m *FakeBadObjectStore) GetFileInfo(ctx context.Context, filePath string) (*storage.FileInfo, error) {
+	return nil, util.NewInternalServerError(errors.New("Error"), "bad object store")
+}
+
+func (m *FakeBadObjectStore) MoveFile(ctx context.Context, srcPath string, dstPath string) error {
+	return util.NewInternalServerError(errors.New("Error"), "bad object store")
+}
+
+func (m *FakeBadObjectStore) Close() error {
+	return nil
+}
+
 func createPipelineV1(name string) *model.Pipeline {
 	return &model.Pipeline{
 		Name:   name,
@@ -40,6 +40,7 @@ func NewFakeDB() (*DB, error) {
 		&model.Task{},
 		&model.DBStatus{},
 		&model.DefaultExperiment{},
+		&model.ObjectStoreMetric{},
 	)
 	return NewDB(db.DB(), NewSQLiteDialect()), nil
 }
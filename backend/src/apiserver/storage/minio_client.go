@@ -17,19 +17,73 @@ package storage
 import (
 	"context"
 	"io"
+	"net/http"
+	"net/url"
+	"time"
 
 	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
 )
 
+//go:generate mockgen -source=minio_client.go -destination=minio_client_mock.go -package=storage -mock_names=MinioClientInterface=MockMinioClient
+
 // Create interface for minio client struct, making it more unit testable.
 type MinioClientInterface interface {
 	PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (n int64, err error)
 	GetObject(ctx context.Context, bucketName, objectName string, opts minio.GetObjectOptions) (io.Reader, error)
 	DeleteObject(ctx context.Context, bucketName, objectName string) error
+	StatObject(ctx context.Context, bucketName, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error)
+	ListObjects(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo
+	CopyObject(ctx context.Context, dst minio.CopyDestOptions, src minio.CopySrcOptions) (minio.UploadInfo, error)
+	GetObjectTagging(ctx context.Context, bucketName, objectName string, opts minio.GetObjectTaggingOptions) (*tags.Tags, error)
+	PutObjectTagging(ctx context.Context, bucketName, objectName string, otags *tags.Tags, opts minio.PutObjectTaggingOptions) error
+	PutObjectRetention(ctx context.Context, bucketName, objectName string, opts minio.PutObjectRetentionOptions) error
+	PutObjectLegalHold(ctx context.Context, bucketName, objectName string, opts minio.PutObjectLegalHoldOptions) error
+	PresignedGetObject(ctx context.Context, bucketName, objectName string, expires time.Duration, reqParams url.Values) (*url.URL, error)
+	ListIncompleteUploads(ctx context.Context, bucketName, objectPrefix string, recursive bool) <-chan minio.ObjectMultipartInfo
+	RemoveIncompleteUpload(ctx context.Context, bucketName, objectName string) error
 }
 
+// The minio client wrapper must keep up with the interface.
+var _ MinioClientInterface = (*MinioClient)(nil)
+
+// *minio.Client must provide every method of the interface, so drifts of the upstream API
+// fail the build. Most are passed through as is. PutObject, GetObject and DeleteObject are
+// adapted by the wrapper, so the upstream methods they call are asserted instead.
+var _ interface {
+	PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (minio.UploadInfo, error)
+	GetObject(ctx context.Context, bucketName, objectName string, opts minio.GetObjectOptions) (*minio.Object, error)
+	RemoveObject(ctx context.Context, bucketName, objectName string, opts minio.RemoveObjectOptions) error
+	StatObject(ctx context.Context, bucketName, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error)
+	ListObjects(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo
+	CopyObject(ctx context.Context, dst minio.CopyDestOptions, src minio.CopySrcOptions) (minio.UploadInfo, error)
+	GetObjectTagging(ctx context.Context, bucketName, objectName string, opts minio.GetObjectTaggingOptions) (*tags.Tags, error)
+	PutObjectTagging(ctx context.Context, bucketName, objectName string, otags *tags.Tags, opts minio.PutObjectTaggingOptions) error
+	PutObjectRetention(ctx context.Context, bucketName, objectName string, opts minio.PutObjectRetentionOptions) error
+	PutObjectLegalHold(ctx context.Context, bucketName, objectName string, opts minio.PutObjectLegalHoldOptions) error
+	PresignedGetObject(ctx context.Context, bucketName, objectName string, expires time.Duration, reqParams url.Values) (*url.URL, error)
+	ListIncompleteUploads(ctx context.Context, bucketName, objectPrefix string, recursive bool) <-chan minio.ObjectMultipartInfo
+	RemoveIncompleteUpload(ctx context.Context, bucketName, objectName string) error
+} = (*minio.Client)(nil)
+
 type MinioClient struct {
 	Client *minio.Client
+	// Transport is the transport the client was created with, if known. Its idle
+	// connections are released when the client is closed.
+	Transport *http.Transport
+}
+
+// Close releases the idle connections held by the client's transport.
+func (c *MinioClient) Close() error {
+	if c.Transport != nil {
+		c.Transport.CloseIdleConnections()
+	}
+	return nil
+}
+
+// SetUserAgent appends "<name>/<version>" to the user agent of the requests of the client.
+func (c *MinioClient) SetUserAgent(name string, version string) {
+	c.Client.SetAppInfo(name, version)
 }
 
 func (c *MinioClient) PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (n int64, err error) {
@@ -47,3 +101,43 @@ func (c *MinioClient) GetObject(ctx context.Context, bucketName, objectName stri
 func (c *MinioClient) DeleteObject(ctx context.Context, bucketName, objectName string) error {
 	return c.Client.RemoveObject(ctx, bucketName, objectName, minio.RemoveObjectOptions{})
 }
+
+func (c *MinioClient) StatObject(ctx context.Context, bucketName, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error) {
+	return c.Client.StatObject(ctx, bucketName, objectName, opts)
+}
+
+func (c *MinioClient) ListObjects(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo {
+	return c.Client.ListObjects(ctx, bucketName, opts)
+}
+
+func (c *MinioClient) CopyObject(ctx context.Context, dst minio.CopyDestOptions, src minio.CopySrcOptions) (minio.UploadInfo, error) {
+	return c.Client.CopyObject(ctx, dst, src)
+}
+
+func (c *MinioClient) GetObjectTagging(ctx context.Context, bucketName, objectName string, opts minio.GetObjectTaggingOptions) (*tags.Tags, error) {
+	return c.Client.GetObjectTagging(ctx, bucketName, objectName, opts)
+}
+
+func (c *MinioClient) PutObjectTagging(ctx context.Context, bucketName, objectName string, otags *tags.Tags, opts minio.PutObjectTaggingOptions) error {
+	return c.Client.PutObjectTagging(ctx, bucketName, objectName, otags, opts)
+}
+
+func (c *MinioClient) PutObjectRetention(ctx context.Context, bucketName, objectName string, opts minio.PutObjectRetentionOptions) error {
+	return c.Client.PutObjectRetention(ctx, bucketName, objectName, opts)
+}
+
+func (c *MinioClient) PutObjectLegalHold(ctx context.Context, bucketName, objectName string, opts minio.PutObjectLegalHoldOptions) error {
+	return c.Client.PutObjectLegalHold(ctx, bucketName, objectName, opts)
+}
+
+func (c *MinioClient) PresignedGetObject(ctx context.Context, bucketName, objectName string, expires time.Duration, reqParams url.Values) (*url.URL, error) {
+	return c.Client.PresignedGetObject(ctx, bucketName, objectName, expires, reqParams)
+}
+
+func (c *MinioClient) ListIncompleteUploads(ctx context.Context, bucketName, objectPrefix string, recursive bool) <-chan minio.ObjectMultipartInfo {
+	return c.Client.ListIncompleteUploads(ctx, bucketName, objectPrefix, recursive)
+}
+
+func (c *MinioClient) RemoveIncompleteUpload(ctx context.Context, bucketName, objectName string) error {
+	return c.Client.RemoveIncompleteUpload(ctx, bucketName, objectName)
+}
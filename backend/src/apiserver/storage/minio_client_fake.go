@@ -17,53 +17,428 @@ package storage
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
 	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/minio/minio-go/v7"
-	"github.com/pkg/errors"
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+	"github.com/minio/minio-go/v7/pkg/tags"
 )
 
 type FakeMinioClient struct {
-	minioClient map[string][]byte
+	mutex             sync.Mutex
+	minioClient       map[string][]byte
+	objectInfo        map[string]minio.ObjectInfo
+	incompleteUploads map[string][]minio.ObjectMultipartInfo
+	clock             Clock
 }
 
 func NewFakeMinioClient() *FakeMinioClient {
 	return &FakeMinioClient{
-		minioClient: make(map[string][]byte),
+		minioClient:       make(map[string][]byte),
+		objectInfo:        make(map[string]minio.ObjectInfo),
+		incompleteUploads: make(map[string][]minio.ObjectMultipartInfo),
+		clock:             NewRealClock(),
 	}
 }
 
+// SetClock sets the clock the modification times of the objects are read from.
+func (c *FakeMinioClient) SetClock(clock Clock) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.clock = clock
+}
+
 func (c *FakeMinioClient) PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader,
 	objectSize int64, opts minio.PutObjectOptions,
 ) (int64, error) {
 	buf := new(bytes.Buffer)
-	buf.ReadFrom(reader)
+	if _, err := buf.ReadFrom(reader); err != nil {
+		return 0, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.checkPutConditions(objectName, opts); err != nil {
+		return 0, err
+	}
 	c.minioClient[objectName] = buf.Bytes()
-	return 1, nil
+	c.objectInfo[objectName] = newFakeObjectInfo(objectName, buf.Bytes(), opts, c.clock.Now())
+	return int64(buf.Len()), nil
+}
+
+// checkPutConditions fails like the real backend if the If-Match or If-None-Match
+// conditions of a put do not hold. Like S3, puts only support If-None-Match: *.
+func (c *FakeMinioClient) checkPutConditions(objectName string, opts minio.PutObjectOptions) error {
+	headers := opts.Header()
+	info, exists := c.objectInfo[objectName]
+	if match := headers.Get("If-Match"); match != "" {
+		if !exists || (match != "*" && strings.Trim(match, "\"") != info.ETag) {
+			return newFakePreconditionFailedError(objectName)
+		}
+	}
+	if noneMatch := headers.Get("If-None-Match"); noneMatch != "" {
+		if noneMatch != "*" {
+			return newFakeNotImplementedError(objectName, "If-None-Match")
+		}
+		if exists {
+			return newFakePreconditionFailedError(objectName)
+		}
+	}
+	return nil
+}
+
+// newFakeObjectInfo builds the object info the real client would report for an object stored with opts.
+func newFakeObjectInfo(objectName string, content []byte, opts minio.PutObjectOptions, lastModified time.Time) minio.ObjectInfo {
+	sum := md5.Sum(content)
+	metadata := http.Header{}
+	if opts.ContentType != "" {
+		metadata.Set("Content-Type", opts.ContentType)
+	}
+	if opts.ContentEncoding != "" {
+		metadata.Set("Content-Encoding", opts.ContentEncoding)
+	}
+	userMetadata := minio.StringMap{}
+	for k, v := range opts.UserMetadata {
+		userMetadata[http.CanonicalHeaderKey(k)] = v
+	}
+	return minio.ObjectInfo{
+		Key:          objectName,
+		ETag:         hex.EncodeToString(sum[:]),
+		Size:         int64(len(content)),
+		LastModified: lastModified,
+		ContentType:  opts.ContentType,
+		Metadata:     metadata,
+		UserMetadata: userMetadata,
+		StorageClass: opts.StorageClass,
+		UserTags:     opts.UserTags,
+	}
 }
 
 func (c *FakeMinioClient) GetObject(ctx context.Context, bucketName, objectName string,
 	opts minio.GetObjectOptions,
 ) (io.Reader, error) {
-	if _, ok := c.minioClient[objectName]; !ok {
-		return nil, errors.New("object not found")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	content, ok := c.minioClient[objectName]
+	if !ok {
+		return nil, newFakeNoSuchKeyError(objectName)
+	}
+	info := c.objectInfo[objectName]
+	if noneMatch := opts.Header().Get("If-None-Match"); noneMatch != "" && strings.Trim(noneMatch, "\"") == info.ETag {
+		return nil, newFakeNotModifiedError(objectName)
+	}
+	// Like the real backend, serves the range requested, if any, reporting its size.
+	var start, end int64
+	if _, err := fmt.Sscanf(opts.Header().Get("Range"), "bytes=%d-%d", &start, &end); err == nil {
+		if start >= int64(len(content)) {
+			return nil, newFakeInvalidRangeError(objectName)
+		}
+		content = content[start:min(end+1, int64(len(content)))]
+		info.Size = int64(len(content))
 	}
-	return bytes.NewReader(c.minioClient[objectName]), nil
+	return &fakeObject{Reader: bytes.NewReader(content), info: info}, nil
+}
+
+// fakeObject is a stored object, which like minio.Object reports its info when stat-ed.
+type fakeObject struct {
+	*bytes.Reader
+	info minio.ObjectInfo
+}
+
+func (o *fakeObject) Stat() (minio.ObjectInfo, error) {
+	return o.info, nil
 }
 
 func (c *FakeMinioClient) DeleteObject(ctx context.Context, bucketName, objectName string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	if _, ok := c.minioClient[objectName]; !ok {
-		return errors.New("object not found")
+		return newFakeNoSuchKeyError(objectName)
 	}
 	delete(c.minioClient, objectName)
+	delete(c.objectInfo, objectName)
+	return nil
+}
+
+func (c *FakeMinioClient) StatObject(ctx context.Context, bucketName, objectName string,
+	opts minio.StatObjectOptions,
+) (minio.ObjectInfo, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	info, ok := c.objectInfo[objectName]
+	if !ok {
+		return minio.ObjectInfo{}, newFakeNoSuchKeyError(objectName)
+	}
+	return info, nil
+}
+
+// CopyObject copies the source object, replacing its user metadata, and storage class, if
+// requested.
+func (c *FakeMinioClient) CopyObject(ctx context.Context, dst minio.CopyDestOptions,
+	src minio.CopySrcOptions,
+) (minio.UploadInfo, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	content, ok := c.minioClient[src.Object]
+	if !ok {
+		return minio.UploadInfo{}, newFakeNoSuchKeyError(src.Object)
+	}
+	info := c.objectInfo[src.Object]
+	if src.MatchETag != "" && src.MatchETag != info.ETag {
+		return minio.UploadInfo{}, newFakePreconditionFailedError(src.Object)
+	}
+	info.Key = dst.Object
+	info.LastModified = c.clock.Now()
+	if dst.ReplaceMetadata {
+		info.UserMetadata = minio.StringMap{}
+		for k, v := range dst.UserMetadata {
+			if http.CanonicalHeaderKey(k) == "X-Amz-Storage-Class" {
+				info.StorageClass = v
+				continue
+			}
+			info.UserMetadata[http.CanonicalHeaderKey(k)] = v
+		}
+	}
+	c.minioClient[dst.Object] = append([]byte(nil), content...)
+	c.objectInfo[dst.Object] = info
+	return minio.UploadInfo{Bucket: dst.Bucket, Key: dst.Object, ETag: info.ETag, Size: info.Size}, nil
+}
+
+// GetObjectTagging returns the tags the object was stored with.
+func (c *FakeMinioClient) GetObjectTagging(ctx context.Context, bucketName, objectName string,
+	opts minio.GetObjectTaggingOptions,
+) (*tags.Tags, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	info, ok := c.objectInfo[objectName]
+	if !ok {
+		return nil, newFakeNoSuchKeyError(objectName)
+	}
+	return tags.MapToObjectTags(info.UserTags)
+}
+
+// PutObjectTagging replaces the tags of the object, leaving its content and modification
+// time as they are.
+func (c *FakeMinioClient) PutObjectTagging(ctx context.Context, bucketName, objectName string,
+	otags *tags.Tags, opts minio.PutObjectTaggingOptions,
+) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	info, ok := c.objectInfo[objectName]
+	if !ok {
+		return newFakeNoSuchKeyError(objectName)
+	}
+	info.UserTags = otags.ToMap()
+	c.objectInfo[objectName] = info
 	return nil
 }
 
+// PutObjectRetention fails like the real backend if the object does not exist. Retention
+// is not enforced by the fake.
+func (c *FakeMinioClient) PutObjectRetention(ctx context.Context, bucketName, objectName string,
+	opts minio.PutObjectRetentionOptions,
+) error {
+	return c.checkExists(objectName)
+}
+
+// PutObjectLegalHold fails like the real backend if the object does not exist. Legal holds
+// are not enforced by the fake.
+func (c *FakeMinioClient) PutObjectLegalHold(ctx context.Context, bucketName, objectName string,
+	opts minio.PutObjectLegalHoldOptions,
+) error {
+	return c.checkExists(objectName)
+}
+
+// PresignedGetObject returns an unsigned URL of the object on a fake endpoint, with its key
+// escaped like the real client escapes it.
+func (c *FakeMinioClient) PresignedGetObject(ctx context.Context, bucketName, objectName string,
+	expires time.Duration, reqParams url.Values,
+) (*url.URL, error) {
+	query := url.Values{"X-Amz-Expires": {strconv.FormatInt(int64(expires.Seconds()), 10)}}
+	for k, v := range reqParams {
+		query[k] = v
+	}
+	return url.Parse("http://minio.fake/" + bucketName + "/" + s3utils.EncodePath(objectName) + "?" + query.Encode())
+}
+
+func (c *FakeMinioClient) checkExists(objectName string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.objectInfo[objectName]; !ok {
+		return newFakeNoSuchKeyError(objectName)
+	}
+	return nil
+}
+
+// ListObjects lists the objects under opts.Prefix in key order. Unless the listing is
+// recursive, keys below the next "/" are rolled up into a single common prefix entry.
+func (c *FakeMinioClient) ListObjects(ctx context.Context, bucketName string,
+	opts minio.ListObjectsOptions,
+) <-chan minio.ObjectInfo {
+	c.mutex.Lock()
+	var objects []minio.ObjectInfo
+	seenPrefixes := make(map[string]bool)
+	for key, info := range c.objectInfo {
+		if !strings.HasPrefix(key, opts.Prefix) {
+			continue
+		}
+		if !opts.Recursive {
+			if i := strings.Index(key[len(opts.Prefix):], "/"); i >= 0 {
+				commonPrefix := key[:len(opts.Prefix)+i+1]
+				if !seenPrefixes[commonPrefix] {
+					seenPrefixes[commonPrefix] = true
+					objects = append(objects, minio.ObjectInfo{Key: commonPrefix})
+				}
+				continue
+			}
+		}
+		objects = append(objects, info)
+	}
+	c.mutex.Unlock()
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+
+	objectCh := make(chan minio.ObjectInfo)
+	go func() {
+		defer close(objectCh)
+		for _, object := range objects {
+			select {
+			case objectCh <- object:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return objectCh
+}
+
+// StartIncompleteUpload records a multipart upload of the object, initiated now according
+// to the clock of the client, which is never completed.
+func (c *FakeMinioClient) StartIncompleteUpload(objectName string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.incompleteUploads[objectName] = append(c.incompleteUploads[objectName], minio.ObjectMultipartInfo{
+		Key:       objectName,
+		UploadID:  fmt.Sprintf("upload-%d", len(c.incompleteUploads[objectName])),
+		Initiated: c.clock.Now(),
+	})
+}
+
+// ListIncompleteUploads lists the incomplete uploads of the objects under objectPrefix in
+// key order. Listings are always recursive.
+func (c *FakeMinioClient) ListIncompleteUploads(ctx context.Context, bucketName, objectPrefix string,
+	recursive bool,
+) <-chan minio.ObjectMultipartInfo {
+	c.mutex.Lock()
+	var uploads []minio.ObjectMultipartInfo
+	for key, keyUploads := range c.incompleteUploads {
+		if strings.HasPrefix(key, objectPrefix) {
+			uploads = append(uploads, keyUploads...)
+		}
+	}
+	c.mutex.Unlock()
+	sort.SliceStable(uploads, func(i, j int) bool { return uploads[i].Key < uploads[j].Key })
+
+	uploadCh := make(chan minio.ObjectMultipartInfo)
+	go func() {
+		defer close(uploadCh)
+		for _, upload := range uploads {
+			select {
+			case uploadCh <- upload:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return uploadCh
+}
+
+// RemoveIncompleteUpload aborts every incomplete upload of the object.
+func (c *FakeMinioClient) RemoveIncompleteUpload(ctx context.Context, bucketName, objectName string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.incompleteUploads, objectName)
+	return nil
+}
+
+// IncompleteUploadCount returns the number of incomplete uploads.
+func (c *FakeMinioClient) IncompleteUploadCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	count := 0
+	for _, uploads := range c.incompleteUploads {
+		count += len(uploads)
+	}
+	return count
+}
+
+// newFakeNoSuchKeyError returns the error the real client reports for a missing object.
+func newFakeNoSuchKeyError(objectName string) error {
+	return minio.ErrorResponse{
+		Code:       "NoSuchKey",
+		Message:    "object not found",
+		Key:        objectName,
+		StatusCode: http.StatusNotFound,
+	}
+}
+
+// newFakeInvalidRangeError returns the error the real client reports for a range past the
+// end of an object.
+func newFakeInvalidRangeError(objectName string) error {
+	return minio.ErrorResponse{
+		Code:       "InvalidRange",
+		Message:    "The requested range is not satisfiable",
+		Key:        objectName,
+		StatusCode: http.StatusRequestedRangeNotSatisfiable,
+	}
+}
+
+// newFakeNotModifiedError returns the error the real client reports for a conditional read of
+// an object still at the ETag it was conditioned on.
+func newFakeNotModifiedError(objectName string) error {
+	return minio.ErrorResponse{
+		Code:       "304 Not Modified",
+		Key:        objectName,
+		StatusCode: http.StatusNotModified,
+	}
+}
+
+// newFakePreconditionFailedError returns the error the real client reports for a failed condition.
+func newFakePreconditionFailedError(objectName string) error {
+	return minio.ErrorResponse{
+		Code:       "PreconditionFailed",
+		Message:    "At least one of the pre-conditions you specified did not hold",
+		Key:        objectName,
+		StatusCode: http.StatusPreconditionFailed,
+	}
+}
+
+func newFakeNotImplementedError(objectName string, header string) error {
+	return minio.ErrorResponse{
+		Code:       "NotImplemented",
+		Message:    fmt.Sprintf("The %v header you provided implies functionality that is not implemented", header),
+		Key:        objectName,
+		StatusCode: http.StatusNotImplemented,
+	}
+}
+
 func (c *FakeMinioClient) GetObjectCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	return len(c.minioClient)
 }
 
 func (c *FakeMinioClient) ExistObject(objectName string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 	_, ok := c.minioClient[objectName]
 	return ok
 }
@@ -0,0 +1,59 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// The hand written clients must keep up with the interface too.
+var (
+	_ MinioClientInterface = (*MockMinioClient)(nil)
+	_ MinioClientInterface = (*FakeMinioClient)(nil)
+	_ MinioClientInterface = (*FakeBadMinioClient)(nil)
+)
+
+func TestMockMinioClient_PutAndGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	minioClient := NewMockMinioClient(ctrl)
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+
+	var stored []byte
+	minioClient.EXPECT().
+		PutObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any(), int64(multipartDefaultSize), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (int64, error) {
+			var err error
+			stored, err = io.ReadAll(reader)
+			return int64(len(stored)), err
+		})
+	minioClient.EXPECT().
+		GetObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, bucketName, objectName string, opts minio.GetObjectOptions) (io.Reader, error) {
+			return bytes.NewReader(stored), nil
+		})
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), manager.GetPipelineKey("1")))
+	data, err := manager.GetFile(context.TODO(), manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+}
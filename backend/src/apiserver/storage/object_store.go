@@ -17,11 +17,17 @@ package storage
 import (
 	"bytes"
 	"context"
+	"io"
 	"path"
 	"regexp"
+	"sync/atomic"
+	"time"
 
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/yaml"
 )
 
@@ -29,6 +35,9 @@ const (
 	multipartDefaultSize = -1
 )
 
+// ErrObjectStoreClosed is the cause of errors returned by operations on a closed store.
+var ErrObjectStoreClosed = errors.New("object store closed")
+
 // Interface for object store.
 type ObjectStoreInterface interface {
 	AddFile(ctx context.Context, template []byte, filePath string) error
@@ -37,14 +46,82 @@ type ObjectStoreInterface interface {
 	AddAsYamlFile(ctx context.Context, o interface{}, filePath string) error
 	GetFromYamlFile(ctx context.Context, o interface{}, filePath string) error
 	GetPipelineKey(pipelineId string) string
+	GetFileInfo(ctx context.Context, filePath string) (*FileInfo, error)
+	// MoveFile moves the file at srcPath to dstPath, deleting the source once the copy is verified.
+	MoveFile(ctx context.Context, srcPath string, dstPath string) error
+	// Close releases the resources held by the store. Operations on a closed store fail.
+	Close() error
+}
+
+// FileInfo describes a stored object.
+type FileInfo struct {
+	Key string
+	// Size is the size of the stored object, compressed if it is stored compressed.
+	Size         int64
+	ETag         string
+	LastModified time.Time
+	ContentType  string
+	// ContentEncoding is the encoding the object is stored with, e.g. gzip.
+	ContentEncoding string
+	// LogicalSize is the size of the content once decompressed, as served by
+	// GetFileDecompressed. It is Size for objects stored uncompressed, and -1 for compressed
+	// objects whose decompressed size was not recorded when written.
+	LogicalSize int64
+	// ContentSha256 is the SHA-256 of the content recorded when it was written, empty if
+	// none was, e.g. in listings, which do not carry the user metadata.
+	ContentSha256 string
+	// OpaqueETag is whether the ETag of the object is not a hash of its content, as for
+	// objects encrypted with KMS or customer keys.
+	OpaqueETag bool
 }
 
 // Managing pipeline using Minio.
 type MinioObjectStore struct {
-	minioClient      MinioClientInterface
-	bucketName       string
-	baseFolder       string
-	disableMultipart bool
+	minioClient                MinioClientInterface
+	bucketName                 string
+	baseFolder                 string
+	disableMultipart           bool
+	partSize                   uint64
+	retryPolicy                RetryPolicy
+	encryption                 encrypt.ServerSide
+	keyNamespacer              KeyNamespacer
+	eventRecorder              record.EventRecorder
+	urlImportPolicy            URLImportPolicy
+	softDelete                 bool
+	validateYaml               bool
+	keyLocks                   keyLocks
+	maxPointerDepth            int
+	environmentPrefix          string
+	restrictReadsToEnvironment bool
+	verifyUploads              bool
+	prefixRewrites             []PrefixRewrite
+	batchManifest              bool
+	maxKeyLength               int
+	clock                      Clock
+	quotaChecker               QuotaChecker
+	readAfterWriteTimeout      time.Duration
+	versionKeyBuilder          VersionKeyBuilder
+	operationTimeout           time.Duration
+	disableKeyNormalization    bool
+	minYamlFileSize            int
+	rejectSmallYamlFiles       bool
+	healthPolicy               HealthPolicy
+	keySharding                bool
+	userAgent                  string
+	maxInFlightBytes           int64
+	inFlightBytes              *prioritySemaphore
+	sizeDeadlineBase           time.Duration
+	sizeDeadlinePerMegabyte    time.Duration
+	keyTransform               KeyTransform
+	gzipVariants               bool
+	accessTrackingInterval     time.Duration
+	accessTimes                accessTimes
+	contentDecoders            []ContentDecoder
+	auditHook                  AuditHook
+	auditFailClosed            bool
+	health                     healthTracker
+	maintenance                atomic.Bool
+	closed                     atomic.Bool
 }
 
 // GetPipelineKey adds the configured base folder to pipeline id.
@@ -52,7 +129,33 @@ func (m *MinioObjectStore) GetPipelineKey(pipelineID string) string {
 	return path.Join(m.baseFolder, pipelineID)
 }
 
+// AddFile stores file at filePath, holding the lock of the file meanwhile.
 func (m *MinioObjectStore) AddFile(ctx context.Context, file []byte, filePath string) error {
+	return m.WithKeyLock(ctx, filePath, func(ctx context.Context) error {
+		return m.addFile(ctx, file, filePath)
+	})
+}
+
+func (m *MinioObjectStore) addFile(ctx context.Context, file []byte, filePath string) error {
+	if err := m.checkOpen("store file", filePath); err != nil {
+		return err
+	}
+	if err := m.checkMaintenance("store file", filePath); err != nil {
+		return err
+	}
+	if err := m.checkEnvironment(filePath, true); err != nil {
+		return err
+	}
+	key := m.resolveKey(ctx, filePath)
+	if err := m.checkKeyLength("store file", filePath, key); err != nil {
+		return err
+	}
+	if err := m.checkYamlContent(ctx, file, filePath); err != nil {
+		return err
+	}
+	if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationWrite, Path: filePath, Size: int64(len(file))}); err != nil {
+		return err
+	}
 	var parts int64
 
 	if m.disableMultipart {
@@ -61,42 +164,227 @@ func (m *MinioObjectStore) AddFile(ctx context.Context, file []byte, filePath st
 		parts = multipartDefaultSize
 	}
 
-	_, err := m.minioClient.PutObject(
-		ctx,
-		m.bucketName, filePath, bytes.NewReader(file),
-		parts, minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	opts := m.putObjectOptions(ctx)
+	opts.StorageClass = storageClassFromContext(ctx)
+	if err := setObjectRetention(ctx, &opts, filePath); err != nil {
+		return err
+	}
+	if idempotencyKey := idempotencyKeyFromContext(ctx); idempotencyKey != "" {
+		if m.isDuplicateWrite(ctx, key, idempotencyKey) {
+			return nil
+		}
+		setUserMetadata(&opts, idempotencyKeyMetadata, idempotencyKey)
+	}
+	setUserMetadata(&opts, contentSha256Metadata, contentSha256(file))
+	if versionID := versionIDFromContext(ctx); versionID != "" {
+		setUserMetadata(&opts, versionIDMetadata, versionID)
+	}
+	tenant, quota := m.tenantQuota(ctx)
+	var previousSize int64
+	if quota {
+		previousSize = m.storedSize(ctx, key)
+		if err := m.checkQuota(ctx, tenant, filePath, int64(len(file))-previousSize); err != nil {
+			return err
+		}
+	}
+
+	release, err := m.reserveInFlightBytes(ctx, "store file", filePath, int64(len(file)))
 	if err != nil {
-		return util.NewInternalServerError(err, "Failed to store file %v", filePath)
+		return err
 	}
+	defer release()
+	putCtx, cancel := m.sizeDeadlineContext(ctx, int64(len(file)))
+	defer cancel()
+	err = m.retry(putCtx, func(ctx context.Context) error {
+		_, err := m.minioClient.PutObject(
+			ctx,
+			m.bucketName, key, bytes.NewReader(file),
+			parts, opts)
+		return err
+	})
+	if err != nil {
+		m.recordWarningEvent(ctx, eventReasonWriteFailed, "Failed to store file %v: %v", filePath, err)
+		return newObjectStoreError(err, "Failed to store file %v", filePath)
+	}
+	if m.readAfterWriteTimeout > 0 {
+		if err := m.waitUntilVisible(ctx, key, filePath); err != nil {
+			m.recordWarningEvent(ctx, eventReasonWriteFailed, "Failed to read back file %v: %v", filePath, err)
+			return err
+		}
+	}
+	if m.verifyUploads {
+		if err := m.verifyUpload(ctx, key, filePath, len(file)); err != nil {
+			m.recordWarningEvent(ctx, eventReasonWriteFailed, "Failed to verify file %v: %v", filePath, err)
+			return err
+		}
+	}
+	if quota {
+		m.quotaChecker.RecordUsage(ctx, tenant, int64(len(file))-previousSize)
+	}
+	m.recordUpload(len(file))
 	return nil
 }
 
+// DeleteFile deletes the file at filePath, holding the lock of the file meanwhile.
 func (m *MinioObjectStore) DeleteFile(ctx context.Context, filePath string) error {
-	err := m.minioClient.DeleteObject(ctx, m.bucketName, filePath)
+	return m.WithKeyLock(ctx, filePath, func(ctx context.Context) error {
+		return m.deleteFile(ctx, filePath)
+	})
+}
+
+func (m *MinioObjectStore) deleteFile(ctx context.Context, filePath string) error {
+	if err := m.checkOpen("delete file", filePath); err != nil {
+		return err
+	}
+	if err := m.checkMaintenance("delete file", filePath); err != nil {
+		return err
+	}
+	if err := m.checkEnvironment(filePath, true); err != nil {
+		return err
+	}
+	if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationDelete, Path: filePath, Size: -1}); err != nil {
+		return err
+	}
+	key := m.resolveKey(ctx, filePath)
+	tenant, quota := m.tenantQuota(ctx)
+	var size int64
+	if quota {
+		size = m.storedSize(ctx, key)
+	}
+	var err error
+	if m.softDelete {
+		if err := m.checkKeyLength("delete file", filePath, m.recycleBinKey(ctx, filePath)); err != nil {
+			return err
+		}
+		err = m.softDeleteFile(ctx, filePath)
+		// Deleting a missing file succeeds, as hard deletes do on the backend.
+		if ClassifyError(err) == ErrNotFound {
+			err = nil
+		}
+	} else {
+		err = m.retry(ctx, func(ctx context.Context) error {
+			return m.minioClient.DeleteObject(ctx, m.bucketName, key)
+		})
+	}
+	if isObjectLocked(err) {
+		return newObjectLockedError(err, filePath)
+	}
 	if err != nil {
-		return util.NewInternalServerError(err, "Failed to delete file %v", filePath)
+		return newObjectStoreError(err, "Failed to delete file %v", filePath)
+	}
+	if quota {
+		m.quotaChecker.RecordUsage(ctx, tenant, -size)
 	}
 	return nil
 }
 
 func (m *MinioObjectStore) GetFile(ctx context.Context, filePath string) ([]byte, error) {
-	reader, err := m.minioClient.GetObject(ctx, m.bucketName, filePath, minio.GetObjectOptions{})
+	data, err := m.getDecodedFile(ctx, filePath)
 	if err != nil {
-		return nil, util.NewInternalServerError(err, "Failed to get file %v", filePath)
+		return nil, err
+	}
+	m.recordAccess(ctx, filePath)
+	m.auditRead(ctx, AuditEvent{Operation: AuditOperationRead, Path: filePath, Size: int64(len(data))})
+	if m.maxPointerDepth <= 0 {
+		return data, nil
 	}
+	return m.followPointers(ctx, filePath, data)
+}
 
-	buf := new(bytes.Buffer)
-	buf.ReadFrom(reader)
+// getFile returns the content of the file as stored, without decoding it.
+func (m *MinioObjectStore) getFile(ctx context.Context, filePath string) ([]byte, error) {
+	data, _, err := m.getFileWithInfo(ctx, filePath)
+	return data, err
+}
 
-	bytes := buf.Bytes()
+// getFileWithInfo returns the content of the file as stored, along with its attributes as
+// reported by the read. Readers not reporting them only fill in the key and size.
+func (m *MinioObjectStore) getFileWithInfo(ctx context.Context, filePath string) ([]byte, *FileInfo, error) {
+	if err := m.checkOpen("get file", filePath); err != nil {
+		return nil, nil, err
+	}
+	if err := m.checkEnvironment(filePath, false); err != nil {
+		return nil, nil, err
+	}
+	release, err := m.reserveReadInFlightBytes(ctx, filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+	getCtx, cancel := m.readSizeDeadlineContext(ctx, filePath)
+	defer cancel()
+	var data []byte
+	var info *FileInfo
+	err = m.retry(getCtx, func(ctx context.Context) error {
+		reader, err := m.minioClient.GetObject(ctx, m.bucketName, m.resolveKey(ctx, filePath), m.getObjectOptions(ctx))
+		if err != nil {
+			return err
+		}
+		defer closeReader(reader)
+		if object, ok := reader.(objectStater); ok {
+			objectInfo, err := object.Stat()
+			if err != nil {
+				return err
+			}
+			info = newFileInfo(filePath, objectInfo)
+		}
+		data, err = readObject(reader)
+		return err
+	})
+	if err != nil {
+		m.recordWarningEvent(ctx, eventReasonReadFailed, "Failed to get file %v: %v", filePath, err)
+		return nil, nil, newObjectStoreError(err, "Failed to get file %v", filePath)
+	}
+	recordOperationSize(sizedOperationGet, int64(len(data)))
+	if info == nil {
+		info = &FileInfo{Key: filePath, Size: int64(len(data)), LogicalSize: int64(len(data))}
+	}
 
-	// Remove single part signature if exists
+	return m.removeChunkSignatures(data), info, nil
+}
+
+// removeChunkSignatures removes the single part signatures stored with the content when
+// multipart uploads are disabled.
+func (m *MinioObjectStore) removeChunkSignatures(bytes []byte) []byte {
 	if m.disableMultipart {
 		re := regexp.MustCompile(`\w+;chunk-signature=\w+`)
 		bytes = []byte(re.ReplaceAllString(string(bytes), ""))
 	}
+	return bytes
+}
 
-	return bytes, nil
+// GetFileInfo returns the attributes of the file, without downloading it.
+func (m *MinioObjectStore) GetFileInfo(ctx context.Context, filePath string) (*FileInfo, error) {
+	if err := m.checkOpen("stat file", filePath); err != nil {
+		return nil, err
+	}
+	if err := m.checkEnvironment(filePath, false); err != nil {
+		return nil, err
+	}
+	var info minio.ObjectInfo
+	err := m.retry(ctx, func(ctx context.Context) error {
+		var err error
+		info, err = m.minioClient.StatObject(ctx, m.bucketName, m.resolveKey(ctx, filePath), m.getObjectOptions(ctx))
+		return err
+	})
+	if err != nil {
+		return nil, newObjectStoreError(err, "Failed to stat file %v", filePath)
+	}
+	return newFileInfo(filePath, info), nil
+}
+
+func newFileInfo(key string, info minio.ObjectInfo) *FileInfo {
+	return &FileInfo{
+		Key:             key,
+		Size:            info.Size,
+		ETag:            info.ETag,
+		LastModified:    info.LastModified,
+		ContentType:     info.ContentType,
+		ContentEncoding: info.Metadata.Get(contentEncodingHeader),
+		LogicalSize:     logicalSize(info),
+		ContentSha256:   userMetadataValue(info, contentSha256Metadata),
+		OpaqueETag:      hasOpaqueETag(info),
+	}
 }
 
 func (m *MinioObjectStore) AddAsYamlFile(ctx context.Context, o interface{}, filePath string) error {
@@ -104,25 +392,63 @@ func (m *MinioObjectStore) AddAsYamlFile(ctx context.Context, o interface{}, fil
 	if err != nil {
 		return util.NewInternalServerError(err, "Failed to marshal file %v: %v", filePath, err.Error())
 	}
+	if err := m.checkYamlFileSize(filePath, bytes); err != nil {
+		return err
+	}
 	err = m.AddFile(ctx, bytes, filePath)
 	if err != nil {
 		return util.Wrap(err, "Failed to add a yaml file")
 	}
+	if m.gzipVariants {
+		if err := m.addGzipVariant(ctx, bytes, filePath); err != nil {
+			return util.Wrap(err, "Failed to add a yaml file")
+		}
+	}
 	return nil
 }
 
 func (m *MinioObjectStore) GetFromYamlFile(ctx context.Context, o interface{}, filePath string) error {
-	bytes, err := m.GetFile(ctx, filePath)
-	if err != nil {
-		return util.Wrap(err, "Failed to read from a yaml file")
+	bytes, err := readYamlFile(ctx, m, filePath)
+	return unmarshalYamlFile(ctx, bytes, err, o, filePath)
+}
+
+// GetFromYamlFileWithRaw is GetFromYamlFile also returning the bytes of the file exactly as
+// stored, e.g. to store them again or hash them, without reading the file twice or
+// marshalling o back. The bytes are nil if o was set to the default of WithYamlDefault
+// because the file is missing.
+func (m *MinioObjectStore) GetFromYamlFileWithRaw(ctx context.Context, filePath string, o interface{}) ([]byte, error) {
+	raw, err := m.GetFile(ctx, filePath)
+	bytes := raw
+	if err == nil && charsetTranscodingFromContext(ctx) {
+		bytes, err = transcodeFile(ctx, m, filePath, bytes)
 	}
-	err = yaml.Unmarshal(bytes, o)
-	if err != nil {
-		return util.NewInternalServerError(err, "Failed to unmarshal file %v: %v", filePath, err.Error())
+	if err := unmarshalYamlFile(ctx, bytes, err, o, filePath); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// Close closes the store and releases the idle connections of its minio client.
+// Closing a closed store is a no-op.
+func (m *MinioObjectStore) Close() error {
+	if m.closed.Swap(true) {
+		return nil
+	}
+	if closer, ok := m.minioClient.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// checkOpen returns an error if the store has been closed.
+func (m *MinioObjectStore) checkOpen(operation string, filePath string) error {
+	if m.closed.Load() {
+		return util.NewUnavailableServerError(ErrObjectStoreClosed, "Failed to %v %v", operation, filePath)
 	}
 	return nil
 }
 
+// NewMinioObjectStore is NewMinioObjectStoreWithOptions for the settings most stores use.
 func NewMinioObjectStore(minioClient MinioClientInterface, bucketName string, baseFolder string, disableMultipart bool) *MinioObjectStore {
-	return &MinioObjectStore{minioClient: minioClient, bucketName: bucketName, baseFolder: baseFolder, disableMultipart: disableMultipart}
+	return NewMinioObjectStoreWithOptions(minioClient, bucketName, baseFolder, WithDisableMultipart(disableMultipart))
 }
@@ -0,0 +1,215 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+)
+
+// CheckPrefixAccess checks that the store's credentials can write, read and delete objects
+// under prefix, by doing so with a canary object. The error returned names the first
+// permission found missing, so an AccessDenied from the backend can be acted upon.
+func (m *MinioObjectStore) CheckPrefixAccess(ctx context.Context, prefix string) error {
+	filePath := path.Join(prefix, canaryFolder, uuid.NewString())
+	if err := m.checkOpen("check access", filePath); err != nil {
+		return err
+	}
+	if err := m.checkMaintenance("check access", filePath); err != nil {
+		return err
+	}
+	if err := m.checkEnvironment(filePath, true); err != nil {
+		return err
+	}
+	key := m.resolveKey(ctx, filePath)
+	if err := m.checkKeyLength("check access", filePath, key); err != nil {
+		return err
+	}
+	// The canary is deleted by the check itself, so the check is audited as one write.
+	if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationWrite, Path: filePath, Size: int64(len(canaryContent))}); err != nil {
+		return err
+	}
+
+	_, err := m.minioClient.PutObject(ctx, m.bucketName, key, bytes.NewReader(canaryContent),
+		int64(len(canaryContent)), m.putObjectOptions(ctx))
+	if err != nil {
+		return newPrefixAccessError(err, "write", prefix)
+	}
+	if err := m.readCanary(ctx, key); err != nil {
+		m.minioClient.DeleteObject(ctx, m.bucketName, key)
+		return newPrefixAccessError(err, "read", prefix)
+	}
+	if err := m.minioClient.DeleteObject(ctx, m.bucketName, key); err != nil {
+		return newPrefixAccessError(err, "delete", prefix)
+	}
+	return nil
+}
+
+// readCanary reads back the canary object stored at key.
+func (m *MinioObjectStore) readCanary(ctx context.Context, key string) error {
+	reader, err := m.minioClient.GetObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+	if err != nil {
+		return err
+	}
+	defer closeReader(reader)
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(data, canaryContent) {
+		return errors.Errorf("read back %d bytes differing from the canary written", len(data))
+	}
+	return nil
+}
+
+// newPrefixAccessError reports the failure of the given operation on a canary object.
+func newPrefixAccessError(err error, operation string, prefix string) *util.UserError {
+	if ClassifyError(err) == ErrAuth {
+		return newObjectStoreError(err, "Missing %v permission on prefix %v", operation, prefix)
+	}
+	return newObjectStoreError(err, "Failed to check %v access to prefix %v", operation, prefix)
+}
+
+// Permissions are the operations the credentials of the store are allowed on a prefix.
+type Permissions struct {
+	List   bool
+	Get    bool
+	Put    bool
+	Delete bool
+	Stat   bool
+}
+
+// permissionUses lists the permissions along with the KFP features failing without them.
+var permissionUses = []struct {
+	name    string
+	allowed func(p Permissions) bool
+	uses    string
+}{
+	{"list", func(p Permissions) bool { return p.List }, "listing files, computing usage and reaping orphaned specs"},
+	{"get", func(p Permissions) bool { return p.Get }, "reading pipeline specs and artifacts"},
+	{"put", func(p Permissions) bool { return p.Put }, "uploading pipeline specs and artifacts"},
+	{"delete", func(p Permissions) bool { return p.Delete }, "deleting pipelines, moving files and purging the recycle bin"},
+	{"stat", func(p Permissions) bool { return p.Stat }, "checking files exist and reading their metadata"},
+}
+
+// String summarizes the permissions, e.g. "list=allowed get=allowed put=denied ...".
+func (p Permissions) String() string {
+	summary := make([]string, 0, len(permissionUses))
+	for _, permission := range permissionUses {
+		state := "denied"
+		if permission.allowed(p) {
+			state = "allowed"
+		}
+		summary = append(summary, fmt.Sprintf("%v=%v", permission.name, state))
+	}
+	return strings.Join(summary, " ")
+}
+
+// CheckPermissions probes each operation the store relies on under prefix independently,
+// unlike CheckPrefixAccess which stops at the first one missing. Put, stat, get and delete
+// are probed with a canary object. If it cannot be written, the others are probed on the
+// missing canary, which the backend only reports not found to credentials allowed to read
+// it. Failures other than denied access are returned, as they tell nothing about the
+// permissions.
+func (m *MinioObjectStore) CheckPermissions(ctx context.Context, prefix string) (Permissions, error) {
+	var permissions Permissions
+	filePath := path.Join(prefix, canaryFolder, uuid.NewString())
+	if err := m.checkOpen("check permissions", filePath); err != nil {
+		return permissions, err
+	}
+	key := m.resolveKey(ctx, filePath)
+
+	var err error
+	if permissions.List, err = m.probePermission("list", prefix, m.probeList(ctx, prefix)); err != nil {
+		return permissions, err
+	}
+	if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationWrite, Path: filePath, Size: int64(len(canaryContent))}); err != nil {
+		return permissions, err
+	}
+	_, putErr := m.minioClient.PutObject(ctx, m.bucketName, key, bytes.NewReader(canaryContent),
+		int64(len(canaryContent)), m.putObjectOptions(ctx))
+	if permissions.Put, err = m.probePermission("put", prefix, putErr); err != nil {
+		return permissions, err
+	}
+	_, statErr := m.minioClient.StatObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+	if permissions.Stat, err = m.probePermission("stat", prefix, statErr); err != nil {
+		m.minioClient.DeleteObject(ctx, m.bucketName, key)
+		return permissions, err
+	}
+	getErr := m.readCanary(ctx, key)
+	if permissions.Get, err = m.probePermission("get", prefix, getErr); err != nil {
+		m.minioClient.DeleteObject(ctx, m.bucketName, key)
+		return permissions, err
+	}
+	deleteErr := m.minioClient.DeleteObject(ctx, m.bucketName, key)
+	if permissions.Delete, err = m.probePermission("delete", prefix, deleteErr); err != nil {
+		return permissions, err
+	}
+	return permissions, nil
+}
+
+// probeList lists the first object under prefix.
+func (m *MinioObjectStore) probeList(ctx context.Context, prefix string) error {
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	opts := minio.ListObjectsOptions{Prefix: m.transformPrefix(m.resolvePrefix(ctx, prefix+"/")), MaxKeys: 1}
+	for object := range m.minioClient.ListObjects(listCtx, m.bucketName, opts) {
+		return object.Err
+	}
+	return nil
+}
+
+// probePermission returns whether the outcome of probing an operation shows it allowed.
+func (m *MinioObjectStore) probePermission(operation string, prefix string, err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	switch ClassifyError(err) {
+	case ErrNotFound:
+		return true, nil
+	case ErrAuth:
+		return false, nil
+	default:
+		return false, newObjectStoreError(err, "Failed to check %v permission on prefix %v", operation, prefix)
+	}
+}
+
+// LogPermissions logs the permissions of the store under prefix, warning about each one
+// missing along with the features that will fail without it. It is meant to be called at
+// startup, so operators learn about misconfigured credentials before users do.
+func (m *MinioObjectStore) LogPermissions(ctx context.Context, prefix string) (Permissions, error) {
+	permissions, err := m.CheckPermissions(ctx, prefix)
+	if err != nil {
+		return permissions, err
+	}
+	glog.Infof("Object store permissions on %v/%v: %v", m.bucketName, prefix, permissions)
+	for _, permission := range permissionUses {
+		if !permission.allowed(permissions) {
+			glog.Warningf("Object store credentials lack the %v permission on %v/%v: %v will fail",
+				permission.name, m.bucketName, prefix, permission.uses)
+		}
+	}
+	return permissions, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+const (
+	arnPrefix           = "arn:"
+	accessPointAliasTag = "-s3alias"
+)
+
+var (
+	// accessPointARNPattern matches arn:<partition>:s3:<region>:<account-id>:accesspoint/<name>.
+	accessPointARNPattern = regexp.MustCompile(`^arn:aws[a-z-]*:s3:[a-z0-9-]+:[0-9]{12}:accesspoint[/:][a-z0-9-]{3,50}$`)
+	// accessPointAliasPattern matches the bucket-style aliases S3 generates for access points.
+	accessPointAliasPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,54}-s3alias$`)
+)
+
+// IsAccessPoint returns whether bucketName identifies an S3 access point, by ARN or by
+// alias, rather than a bucket.
+func IsAccessPoint(bucketName string) bool {
+	return strings.HasPrefix(bucketName, arnPrefix) || strings.HasSuffix(bucketName, accessPointAliasTag)
+}
+
+// ValidateAccessPoint checks that accessPoint is a well formed access point ARN or alias.
+func ValidateAccessPoint(accessPoint string) error {
+	if strings.HasPrefix(accessPoint, arnPrefix) {
+		if !accessPointARNPattern.MatchString(accessPoint) {
+			return util.NewInvalidInputError(
+				"Invalid access point ARN %q: expected arn:<partition>:s3:<region>:<account-id>:accesspoint/<name>", accessPoint)
+		}
+		return nil
+	}
+	if !accessPointAliasPattern.MatchString(accessPoint) {
+		return util.NewInvalidInputError("Invalid access point alias %q", accessPoint)
+	}
+	return nil
+}
+
+// NewAccessPointObjectStore creates a store routing every operation through an S3 access
+// point. accessPoint is an access point ARN or alias; it is passed to the minio client
+// unchanged, in place of a bucket name.
+func NewAccessPointObjectStore(minioClient MinioClientInterface, accessPoint string, baseFolder string,
+	opts ...MinioObjectStoreOption,
+) (*MinioObjectStore, error) {
+	if err := ValidateAccessPoint(accessPoint); err != nil {
+		return nil, err
+	}
+	return NewMinioObjectStoreWithOptions(minioClient, accessPoint, baseFolder, opts...), nil
+}
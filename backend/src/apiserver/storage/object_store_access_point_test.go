@@ -0,0 +1,97 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAccessPointARN = "arn:aws:s3:us-west-2:123456789012:accesspoint/pipelines"
+
+// bucketRecordingMinioClient records the bucket names the store passes to the client.
+type bucketRecordingMinioClient struct {
+	*FakeMinioClient
+	buckets []string
+}
+
+func (c *bucketRecordingMinioClient) PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader,
+	objectSize int64, opts minio.PutObjectOptions,
+) (int64, error) {
+	c.buckets = append(c.buckets, bucketName)
+	return c.FakeMinioClient.PutObject(ctx, bucketName, objectName, reader, objectSize, opts)
+}
+
+func (c *bucketRecordingMinioClient) GetObject(ctx context.Context, bucketName, objectName string,
+	opts minio.GetObjectOptions,
+) (io.Reader, error) {
+	c.buckets = append(c.buckets, bucketName)
+	return c.FakeMinioClient.GetObject(ctx, bucketName, objectName, opts)
+}
+
+func (c *bucketRecordingMinioClient) DeleteObject(ctx context.Context, bucketName, objectName string) error {
+	c.buckets = append(c.buckets, bucketName)
+	return c.FakeMinioClient.DeleteObject(ctx, bucketName, objectName)
+}
+
+func (c *bucketRecordingMinioClient) StatObject(ctx context.Context, bucketName, objectName string,
+	opts minio.StatObjectOptions,
+) (minio.ObjectInfo, error) {
+	c.buckets = append(c.buckets, bucketName)
+	return c.FakeMinioClient.StatObject(ctx, bucketName, objectName, opts)
+}
+
+func TestNewAccessPointObjectStore_PassesIdentifierThrough(t *testing.T) {
+	for _, accessPoint := range []string{testAccessPointARN, "pipelines-abcdefghij1234567890-s3alias"} {
+		minioClient := &bucketRecordingMinioClient{FakeMinioClient: NewFakeMinioClient()}
+		manager, err := NewAccessPointObjectStore(minioClient, accessPoint, "pipelines")
+		require.Nil(t, err)
+		ctx := context.TODO()
+
+		require.Nil(t, manager.AddFile(ctx, []byte("spec"), manager.GetPipelineKey("1")))
+		_, err = manager.GetFile(ctx, manager.GetPipelineKey("1"))
+		require.Nil(t, err)
+		_, err = manager.GetFileInfo(ctx, manager.GetPipelineKey("1"))
+		require.Nil(t, err)
+		require.Nil(t, manager.DeleteFile(ctx, manager.GetPipelineKey("1")))
+
+		assert.Equal(t, []string{accessPoint, accessPoint, accessPoint, accessPoint}, minioClient.buckets)
+	}
+}
+
+func TestNewAccessPointObjectStore_RejectsMalformedIdentifier(t *testing.T) {
+	for _, accessPoint := range []string{
+		"arn:aws:s3:us-west-2:123456789012:bucket/pipelines",
+		"arn:aws:s3:us-west-2:1234:accesspoint/pipelines",
+		"arn:aws:s3:::accesspoint/pipelines",
+		"arn:aws:iam::123456789012:accesspoint/pipelines",
+		"Pipelines_-s3alias",
+		"mlpipeline",
+	} {
+		_, err := NewAccessPointObjectStore(NewFakeMinioClient(), accessPoint, "pipelines")
+		assert.NotNil(t, err, accessPoint)
+	}
+}
+
+func TestIsAccessPoint(t *testing.T) {
+	assert.True(t, IsAccessPoint(testAccessPointARN))
+	assert.True(t, IsAccessPoint("pipelines-abcdefghij-s3alias"))
+	assert.False(t, IsAccessPoint("mlpipeline"))
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+)
+
+var errAccessDenied = minio.ErrorResponse{Code: "AccessDenied", StatusCode: http.StatusForbidden, Message: "Access Denied."}
+
+func isCanaryKey(key string) bool {
+	return strings.HasPrefix(key, "tenants/a/"+canaryFolder+"/")
+}
+
+func TestCheckPrefixAccess(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+
+	assert.Nil(t, manager.CheckPrefixAccess(context.TODO(), "tenants/a"))
+	assert.Equal(t, 0, minioClient.GetObjectCount())
+}
+
+func TestCheckPrefixAccess_Denied(t *testing.T) {
+	tests := []struct {
+		name      string
+		expect    func(minioClient *MockMinioClient)
+		errorText string
+	}{
+		{
+			name: "write",
+			expect: func(minioClient *MockMinioClient) {
+				minioClient.EXPECT().PutObject(gomock.Any(), "mlpipeline", gomock.Cond(isCanaryKey), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(0), errAccessDenied)
+			},
+			errorText: "Missing write permission on prefix tenants/a",
+		},
+		{
+			name: "read",
+			expect: func(minioClient *MockMinioClient) {
+				minioClient.EXPECT().PutObject(gomock.Any(), "mlpipeline", gomock.Cond(isCanaryKey), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(len(canaryContent)), nil)
+				minioClient.EXPECT().GetObject(gomock.Any(), "mlpipeline", gomock.Cond(isCanaryKey), gomock.Any()).
+					Return(nil, errAccessDenied)
+				// The canary is still cleaned up.
+				minioClient.EXPECT().DeleteObject(gomock.Any(), "mlpipeline", gomock.Cond(isCanaryKey)).Return(nil)
+			},
+			errorText: "Missing read permission on prefix tenants/a",
+		},
+		{
+			name: "delete",
+			expect: func(minioClient *MockMinioClient) {
+				minioClient.EXPECT().PutObject(gomock.Any(), "mlpipeline", gomock.Cond(isCanaryKey), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(len(canaryContent)), nil)
+				minioClient.EXPECT().GetObject(gomock.Any(), "mlpipeline", gomock.Cond(isCanaryKey), gomock.Any()).
+					Return(bytes.NewReader(canaryContent), nil)
+				minioClient.EXPECT().DeleteObject(gomock.Any(), "mlpipeline", gomock.Cond(isCanaryKey)).Return(errAccessDenied)
+			},
+			errorText: "Missing delete permission on prefix tenants/a",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			minioClient := NewMockMinioClient(gomock.NewController(t))
+			manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+			test.expect(minioClient)
+
+			err := manager.CheckPrefixAccess(context.TODO(), "tenants/a")
+			require.NotNil(t, err)
+			assert.Contains(t, err.Error(), test.errorText)
+			assert.True(t, errors.Is(err, ErrAuth))
+			assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+		})
+	}
+}
+
+func TestCheckPrefixAccess_Unavailable(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	minioClient.EXPECT().PutObject(gomock.Any(), "mlpipeline", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(int64(0), io.ErrUnexpectedEOF)
+
+	err := manager.CheckPrefixAccess(context.TODO(), "tenants/a")
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Failed to check write access to prefix tenants/a")
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+}
+
+// listResult returns the listing of a single result, failing with err if not nil.
+func listResult(err error) <-chan minio.ObjectInfo {
+	objectCh := make(chan minio.ObjectInfo, 1)
+	objectCh <- minio.ObjectInfo{Key: "tenants/a/1", Err: err}
+	close(objectCh)
+	return objectCh
+}
+
+// expectPermissionProbes expects the probes of CheckPermissions, each failing with the
+// given error, if any.
+func expectPermissionProbes(minioClient *MockMinioClient, listErr, putErr, statErr, getErr, deleteErr error) {
+	minioClient.EXPECT().ListObjects(gomock.Any(), "mlpipeline", gomock.Any()).Return(listResult(listErr))
+	minioClient.EXPECT().PutObject(gomock.Any(), "mlpipeline", gomock.Cond(isCanaryKey), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(int64(len(canaryContent)), putErr)
+	minioClient.EXPECT().StatObject(gomock.Any(), "mlpipeline", gomock.Cond(isCanaryKey), gomock.Any()).
+		Return(minio.ObjectInfo{}, statErr)
+	if getErr != nil {
+		minioClient.EXPECT().GetObject(gomock.Any(), "mlpipeline", gomock.Cond(isCanaryKey), gomock.Any()).
+			Return(nil, getErr)
+	} else {
+		minioClient.EXPECT().GetObject(gomock.Any(), "mlpipeline", gomock.Cond(isCanaryKey), gomock.Any()).
+			Return(bytes.NewReader(canaryContent), nil)
+	}
+	minioClient.EXPECT().DeleteObject(gomock.Any(), "mlpipeline", gomock.Cond(isCanaryKey)).Return(deleteErr)
+}
+
+func TestCheckPermissions(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+
+	permissions, err := manager.LogPermissions(context.TODO(), "tenants/a")
+
+	require.Nil(t, err)
+	assert.Equal(t, Permissions{List: true, Get: true, Put: true, Delete: true, Stat: true}, permissions)
+	assert.Equal(t, "list=allowed get=allowed put=allowed delete=allowed stat=allowed", permissions.String())
+	assert.Equal(t, 0, minioClient.GetObjectCount())
+}
+
+func TestCheckPermissions_Denied(t *testing.T) {
+	errNoSuchKey := newFakeNoSuchKeyError("tenants/a/.canary/1")
+	tests := []struct {
+		name    string
+		expect  func(minioClient *MockMinioClient)
+		summary string
+	}{
+		{
+			name: "list",
+			expect: func(minioClient *MockMinioClient) {
+				expectPermissionProbes(minioClient, errAccessDenied, nil, nil, nil, nil)
+			},
+			summary: "list=denied get=allowed put=allowed delete=allowed stat=allowed",
+		},
+		{
+			name: "delete",
+			expect: func(minioClient *MockMinioClient) {
+				expectPermissionProbes(minioClient, nil, nil, nil, nil, errAccessDenied)
+			},
+			summary: "list=allowed get=allowed put=allowed delete=denied stat=allowed",
+		},
+		{
+			name: "list and delete",
+			expect: func(minioClient *MockMinioClient) {
+				expectPermissionProbes(minioClient, errAccessDenied, nil, nil, nil, errAccessDenied)
+			},
+			summary: "list=denied get=allowed put=allowed delete=denied stat=allowed",
+		},
+		{
+			name: "read only",
+			expect: func(minioClient *MockMinioClient) {
+				// The canary is missing, which readers are told.
+				expectPermissionProbes(minioClient, nil, errAccessDenied, errNoSuchKey, errNoSuchKey, errAccessDenied)
+			},
+			summary: "list=allowed get=allowed put=denied delete=denied stat=allowed",
+		},
+		{
+			name: "write only",
+			expect: func(minioClient *MockMinioClient) {
+				expectPermissionProbes(minioClient, errAccessDenied, nil, errAccessDenied, errAccessDenied, errAccessDenied)
+			},
+			summary: "list=denied get=denied put=allowed delete=denied stat=denied",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			minioClient := NewMockMinioClient(gomock.NewController(t))
+			test.expect(minioClient)
+			manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+
+			permissions, err := manager.LogPermissions(context.TODO(), "tenants/a")
+
+			require.Nil(t, err)
+			assert.Equal(t, test.summary, permissions.String())
+		})
+	}
+}
+
+func TestCheckPermissions_Unreachable(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	minioClient.EXPECT().ListObjects(gomock.Any(), "mlpipeline", gomock.Any()).Return(listResult(errServiceUnavailable))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+
+	_, err := manager.CheckPermissions(context.TODO(), "tenants/a")
+
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Failed to check list permission on prefix tenants/a")
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// archiveFolder is the folder, under the compacted prefix, archives are written to.
+	archiveFolder = ".archives"
+	// maxArchivedFileSize keeps the files that are not small out of archives.
+	maxArchivedFileSize = 1 << 20
+	// archiveTrailerSize is the size of the trailer of archives, the big endian length of
+	// their index.
+	archiveTrailerSize = 8
+)
+
+// ArchiveIndex lists the files packed in an archive. Archives are the content of their
+// files, back to back, followed by the index as JSON and the trailer.
+type ArchiveIndex struct {
+	Files []ArchiveIndexEntry `json:"files"`
+}
+
+// ArchiveIndexEntry locates a file in the content of its archive.
+type ArchiveIndexEntry struct {
+	Path   string `json:"path"`
+	Offset int64  `json:"offset"`
+	Size   int64  `json:"size"`
+	Sha256 string `json:"sha256"`
+}
+
+// Compact packs the small files under prefix, up to 1MiB each, into a single archive
+// object indexing them, whose key it returns, to save the per-object overhead of storing
+// and listing many tiny files. The files are read back individually with OpenArchive. The
+// packed files are left in place, to be deleted once their readers use the archive.
+func (m *MinioObjectStore) Compact(ctx context.Context, prefix string) (string, error) {
+	var files []FileInfo
+	err := m.WalkFiles(ctx, prefix, func(file FileInfo) error {
+		if file.Size <= maxArchivedFileSize && !isArchivePath(file.Key) {
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", util.Wrapf(err, "Failed to compact files under %v", prefix)
+	}
+	if len(files) == 0 {
+		return "", util.NewFailedPreconditionError(errors.New("no files to compact"), "Failed to compact files under %v", prefix)
+	}
+
+	var content bytes.Buffer
+	index := ArchiveIndex{Files: make([]ArchiveIndexEntry, 0, len(files))}
+	for _, file := range files {
+		data, err := m.getFile(ctx, file.Key)
+		if err != nil {
+			return "", util.Wrapf(err, "Failed to compact files under %v", prefix)
+		}
+		index.Files = append(index.Files, ArchiveIndexEntry{
+			Path:   file.Key,
+			Offset: int64(content.Len()),
+			Size:   int64(len(data)),
+			Sha256: contentSha256(data),
+		})
+		content.Write(data)
+	}
+	indexContent, err := json.Marshal(index)
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Failed to marshal the index of the archive of %v", prefix)
+	}
+	content.Write(indexContent)
+	content.Write(binary.BigEndian.AppendUint64(nil, uint64(len(indexContent))))
+
+	archivePath := path.Join(prefix, archiveFolder, fmt.Sprintf("%d.pack", m.now().UnixNano()))
+	if err := m.AddFile(ctx, content.Bytes(), archivePath); err != nil {
+		return "", util.Wrapf(err, "Failed to store the archive of %v", prefix)
+	}
+	return archivePath, nil
+}
+
+// isArchivePath returns whether filePath is an archive.
+func isArchivePath(filePath string) bool {
+	return strings.HasPrefix(filePath, archiveFolder+"/") || strings.Contains(filePath, "/"+archiveFolder+"/")
+}
+
+// Archive reads the files packed in an archive written by Compact, each with a single
+// range read of the archive.
+type Archive struct {
+	store       *MinioObjectStore
+	archivePath string
+	entries     map[string]ArchiveIndexEntry
+	paths       []string
+}
+
+// OpenArchive reads the index of the archive at archivePath.
+func (m *MinioObjectStore) OpenArchive(ctx context.Context, archivePath string) (*Archive, error) {
+	info, err := m.GetFileInfo(ctx, archivePath)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to open archive %v", archivePath)
+	}
+	if info.Size < archiveTrailerSize {
+		return nil, newCorruptArchiveError(archivePath, "shorter than its trailer")
+	}
+	trailer, err := m.getFileRange(ctx, archivePath, info.Size-archiveTrailerSize, archiveTrailerSize)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to open archive %v", archivePath)
+	}
+	indexSize := binary.BigEndian.Uint64(trailer)
+	if indexSize > uint64(info.Size-archiveTrailerSize) {
+		return nil, newCorruptArchiveError(archivePath, "index larger than the archive")
+	}
+	indexOffset := info.Size - archiveTrailerSize - int64(indexSize)
+	indexContent, err := m.getFileRange(ctx, archivePath, indexOffset, int64(indexSize))
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to open archive %v", archivePath)
+	}
+	var index ArchiveIndex
+	if err := json.Unmarshal(indexContent, &index); err != nil {
+		return nil, newCorruptArchiveError(archivePath, "invalid index")
+	}
+	archive := &Archive{store: m, archivePath: archivePath, entries: make(map[string]ArchiveIndexEntry, len(index.Files))}
+	for _, entry := range index.Files {
+		if entry.Offset < 0 || entry.Size < 0 || entry.Offset+entry.Size > indexOffset {
+			return nil, newCorruptArchiveError(archivePath, fmt.Sprintf("entry %v out of bounds", entry.Path))
+		}
+		archive.entries[entry.Path] = entry
+		archive.paths = append(archive.paths, entry.Path)
+	}
+	return archive, nil
+}
+
+// Files returns the paths of the files in the archive, in key order.
+func (a *Archive) Files() []string {
+	return append([]string(nil), a.paths...)
+}
+
+// GetFile returns the content of the file packed at filePath, checked against the hash
+// recorded when it was packed.
+func (a *Archive) GetFile(ctx context.Context, filePath string) ([]byte, error) {
+	entry, ok := a.entries[filePath]
+	if !ok {
+		return nil, util.NewNotFoundError(errors.Errorf("file %v not in archive %v", filePath, a.archivePath),
+			"File %v not found in archive", filePath)
+	}
+	data, err := a.store.getFileRange(ctx, a.archivePath, entry.Offset, entry.Size)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to get file %v from archive %v", filePath, a.archivePath)
+	}
+	if contentSha256(data) != entry.Sha256 {
+		return nil, newCorruptArchiveError(a.archivePath, fmt.Sprintf("content of %v does not match its hash", filePath))
+	}
+	return data, nil
+}
+
+// getFileRange reads length bytes of the file from offset.
+func (m *MinioObjectStore) getFileRange(ctx context.Context, filePath string, offset int64, length int64) ([]byte, error) {
+	if length == 0 {
+		return []byte{}, nil
+	}
+	opts := m.getObjectOptions(ctx)
+	if err := opts.SetRange(offset, offset+length-1); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to read range of file %v", filePath)
+	}
+	reader, err := m.minioClient.GetObject(ctx, m.bucketName, m.resolveKey(ctx, filePath), opts)
+	if err != nil {
+		return nil, newObjectStoreError(err, "Failed to get file %v", filePath)
+	}
+	defer closeReader(reader)
+	data, err := io.ReadAll(io.LimitReader(reader, length))
+	if err != nil {
+		return nil, newObjectStoreError(err, "Failed to read file %v", filePath)
+	}
+	if int64(len(data)) != length {
+		return nil, util.NewInternalServerError(io.ErrUnexpectedEOF, "Failed to read file %v: range shorter than %v bytes", filePath, length)
+	}
+	return data, nil
+}
+
+func newCorruptArchiveError(archivePath string, reason string) error {
+	return util.NewFailedPreconditionError(errors.New(reason), "Archive %v is corrupted: %v", archivePath, reason)
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// rangeRecordingMinioClient records the ranges of the objects read.
+type rangeRecordingMinioClient struct {
+	*FakeMinioClient
+	ranges []string
+}
+
+func (c *rangeRecordingMinioClient) GetObject(ctx context.Context, bucketName, objectName string,
+	opts minio.GetObjectOptions,
+) (io.Reader, error) {
+	c.ranges = append(c.ranges, opts.Header().Get("Range"))
+	return c.FakeMinioClient.GetObject(ctx, bucketName, objectName, opts)
+}
+
+var archiveTestFiles = map[string]string{
+	"components/a.yaml":        "name: a\n",
+	"components/b.yaml":        "name: b\nimage: trainer\n",
+	"components/nested/c.yaml": "name: c\n",
+	"components/empty.yaml":    "",
+}
+
+func newArchiveTestStore(t *testing.T) (*MinioObjectStore, *rangeRecordingMinioClient) {
+	minioClient := &rangeRecordingMinioClient{FakeMinioClient: NewFakeMinioClient()}
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "components"}
+	for filePath, content := range archiveTestFiles {
+		require.Nil(t, manager.AddFile(context.TODO(), []byte(content), filePath))
+	}
+	return manager, minioClient
+}
+
+func TestCompact_RoundTrip(t *testing.T) {
+	manager, minioClient := newArchiveTestStore(t)
+
+	archivePath, err := manager.Compact(context.TODO(), "components/")
+	require.Nil(t, err)
+	assert.True(t, strings.HasPrefix(archivePath, "components/.archives/"))
+	// The packed files are left in place.
+	assert.Equal(t, len(archiveTestFiles)+1, minioClient.GetObjectCount())
+
+	archive, err := manager.OpenArchive(context.TODO(), archivePath)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"components/a.yaml", "components/b.yaml", "components/empty.yaml", "components/nested/c.yaml"}, archive.Files())
+	for filePath, content := range archiveTestFiles {
+		minioClient.ranges = nil
+		data, err := archive.GetFile(context.TODO(), filePath)
+		require.Nil(t, err, filePath)
+		assert.Equal(t, []byte(content), data, filePath)
+		for _, readRange := range minioClient.ranges {
+			assert.NotEmpty(t, readRange, "entries are read with range reads")
+		}
+	}
+}
+
+func TestCompact_SkipsArchivesAndLargeFiles(t *testing.T) {
+	manager, _ := newArchiveTestStore(t)
+	require.Nil(t, manager.AddFile(context.TODO(), bytes.Repeat([]byte("x"), maxArchivedFileSize+1), "components/large.bin"))
+	firstArchive, err := manager.Compact(context.TODO(), "components/")
+	require.Nil(t, err)
+
+	secondArchive, err := manager.Compact(context.TODO(), "components/")
+	require.Nil(t, err)
+
+	archive, err := manager.OpenArchive(context.TODO(), secondArchive)
+	require.Nil(t, err)
+	assert.NotContains(t, archive.Files(), firstArchive)
+	assert.NotContains(t, archive.Files(), "components/large.bin")
+	assert.Len(t, archive.Files(), len(archiveTestFiles))
+}
+
+func TestCompact_NoFiles(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "components"}
+
+	_, err := manager.Compact(context.TODO(), "components/")
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.FailedPrecondition, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestArchive_GetFileNotInArchive(t *testing.T) {
+	manager, _ := newArchiveTestStore(t)
+	archivePath, err := manager.Compact(context.TODO(), "components/")
+	require.Nil(t, err)
+	archive, err := manager.OpenArchive(context.TODO(), archivePath)
+	require.Nil(t, err)
+
+	_, err = archive.GetFile(context.TODO(), "components/missing.yaml")
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestOpenArchive_Corrupted(t *testing.T) {
+	manager, _ := newArchiveTestStore(t)
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("not an archive"), "components/.archives/corrupted.pack"))
+
+	_, err := manager.OpenArchive(context.TODO(), "components/.archives/corrupted.pack")
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.FailedPrecondition, err.(*util.UserError).ExternalStatusCode())
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+// ErrAuditFailed is wrapped by the errors of the mutating operations rejected since their
+// audit event could not be recorded, when the store fails closed.
+var ErrAuditFailed = errors.New("object store audit event not recorded")
+
+// AuditOperation is the kind of operation an audit event records.
+type AuditOperation string
+
+const (
+	AuditOperationWrite  AuditOperation = "write"
+	AuditOperationDelete AuditOperation = "delete"
+	AuditOperationMove   AuditOperation = "move"
+	AuditOperationRead   AuditOperation = "read"
+	// AuditOperationRestore records a file restored from the recycle bin.
+	AuditOperationRestore AuditOperation = "restore"
+	// AuditOperationUpdate records a change of the attributes of a file, e.g. its retention
+	// or storage class, leaving its content as is.
+	AuditOperationUpdate AuditOperation = "update"
+)
+
+// AuditEvent describes an operation on a file.
+type AuditEvent struct {
+	Operation AuditOperation
+	Path      string
+	// TargetPath is the path the file is moved to, for moves.
+	TargetPath string
+	// Size is the size of the content written or read, or -1 if unknown.
+	Size int64
+	Time time.Time
+}
+
+// AuditHook records an audit event, e.g. to an external audit sink. It returns an error if
+// the event could not be recorded.
+type AuditHook func(ctx context.Context, event AuditEvent) error
+
+// SetAuditHook sets the hook the operations on files are recorded with. Every operation
+// changing the backend, including maintenance ones like purges and probes, is recorded
+// before it is performed, reads once they succeed. With failClosed set, as regulated
+// deployments require, a mutating operation whose event could not be recorded is rejected
+// with an Unavailable error wrapping ErrAuditFailed. Otherwise, and for reads, the failure
+// is logged and the operation proceeds. A nil hook records nothing.
+func (m *MinioObjectStore) SetAuditHook(hook AuditHook, failClosed bool) {
+	m.auditHook = hook
+	m.auditFailClosed = failClosed
+}
+
+// auditMutation records the mutating operation described by event, returning an error if
+// the operation must be rejected since the event could not be recorded.
+func (m *MinioObjectStore) auditMutation(ctx context.Context, event AuditEvent) error {
+	err := m.recordAudit(ctx, event)
+	if err == nil {
+		return nil
+	}
+	if m.auditFailClosed {
+		return util.NewUnavailableServerError(fmt.Errorf("%w: %w", ErrAuditFailed, err),
+			"Failed to %v file %v: the audit event could not be recorded", event.Operation, event.Path)
+	}
+	glog.Warningf("Failed to record the audit event of the %v of file %v: %v", event.Operation, event.Path, err)
+	return nil
+}
+
+// auditRead records the read described by event. Failures are logged rather than returned,
+// since reads always fail open.
+func (m *MinioObjectStore) auditRead(ctx context.Context, event AuditEvent) {
+	if err := m.recordAudit(ctx, event); err != nil {
+		glog.Warningf("Failed to record the audit event of the %v of file %v: %v", event.Operation, event.Path, err)
+	}
+}
+
+// recordAudit passes event to the audit hook, if any, recovering from its panics.
+func (m *MinioObjectStore) recordAudit(ctx context.Context, event AuditEvent) (err error) {
+	if m.auditHook == nil {
+		return nil
+	}
+	event.Time = m.now()
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("audit hook panicked: %v", r)
+		}
+	}()
+	return m.auditHook(ctx, event)
+}
@@ -0,0 +1,311 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// auditSink collects the audit events, failing to record them while err is set.
+type auditSink struct {
+	events []AuditEvent
+	err    error
+}
+
+func (s *auditSink) record(ctx context.Context, event AuditEvent) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, event)
+	return nil
+}
+
+func newAuditedStore(t *testing.T, sink *auditSink, failClosed bool) (*MinioObjectStore, *FakeMinioClient) {
+	minioClient := NewFakeMinioClient()
+	store := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines",
+		WithClock(NewFakeClock(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))),
+		WithAuditHook(sink.record, failClosed))
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/existing"))
+	return store, minioClient
+}
+
+func TestAuditHook_RecordsOperations(t *testing.T) {
+	sink := &auditSink{}
+	store, _ := newAuditedStore(t, sink, true)
+
+	_, err := store.GetFile(context.TODO(), "pipelines/existing")
+	require.Nil(t, err)
+	require.Nil(t, store.MoveFile(context.TODO(), "pipelines/existing", "pipelines/moved"))
+	require.Nil(t, store.DeleteFile(context.TODO(), "pipelines/moved"))
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, []AuditEvent{
+		{Operation: AuditOperationWrite, Path: "pipelines/existing", Size: 4, Time: now},
+		{Operation: AuditOperationRead, Path: "pipelines/existing", Size: 4, Time: now},
+		{Operation: AuditOperationMove, Path: "pipelines/existing", TargetPath: "pipelines/moved", Size: -1, Time: now},
+		{Operation: AuditOperationDelete, Path: "pipelines/moved", Size: -1, Time: now},
+	}, sink.events)
+}
+
+func TestAuditHook_FailClosedRejectsWrites(t *testing.T) {
+	sink := &auditSink{}
+	store, minioClient := newAuditedStore(t, sink, true)
+	sink.err = errors.New("audit sink unavailable")
+
+	err := store.AddFile(context.TODO(), []byte("spec"), "pipelines/new")
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrAuditFailed))
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+	assert.False(t, minioClient.ExistObject("pipelines/new"))
+
+	err = store.DeleteFile(context.TODO(), "pipelines/existing")
+	assert.True(t, errors.Is(err, ErrAuditFailed))
+	err = store.MoveFile(context.TODO(), "pipelines/existing", "pipelines/moved")
+	assert.True(t, errors.Is(err, ErrAuditFailed))
+	assert.True(t, minioClient.ExistObject("pipelines/existing"))
+	assert.False(t, minioClient.ExistObject("pipelines/moved"))
+}
+
+func TestAuditHook_FailClosedAllowsReads(t *testing.T) {
+	sink := &auditSink{}
+	store, _ := newAuditedStore(t, sink, true)
+	sink.err = errors.New("audit sink unavailable")
+
+	data, err := store.GetFile(context.TODO(), "pipelines/existing")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+}
+
+func TestAuditHook_FailOpenAllowsWrites(t *testing.T) {
+	sink := &auditSink{}
+	store, minioClient := newAuditedStore(t, sink, false)
+	sink.err = errors.New("audit sink unavailable")
+
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/new"))
+	assert.True(t, minioClient.ExistObject("pipelines/new"))
+	require.Nil(t, store.DeleteFile(context.TODO(), "pipelines/existing"))
+	assert.False(t, minioClient.ExistObject("pipelines/existing"))
+}
+
+func TestAuditHook_PanicFailsClosed(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	store := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines")
+	store.SetAuditHook(func(ctx context.Context, event AuditEvent) error {
+		panic("sink misconfigured")
+	}, true)
+
+	err := store.AddFile(context.TODO(), []byte("spec"), "pipelines/new")
+	assert.True(t, errors.Is(err, ErrAuditFailed))
+	assert.Contains(t, err.Error(), "sink misconfigured")
+	assert.False(t, minioClient.ExistObject("pipelines/new"))
+}
+
+// fakeBackendState returns the attributes of the objects and the incomplete uploads of the
+// backend, so tests can check an operation left it unchanged.
+func fakeBackendState(minioClient *FakeMinioClient) (map[string]minio.ObjectInfo, int) {
+	minioClient.mutex.Lock()
+	objects := make(map[string]minio.ObjectInfo, len(minioClient.objectInfo))
+	for key, info := range minioClient.objectInfo {
+		objects[key] = info
+	}
+	minioClient.mutex.Unlock()
+	return objects, minioClient.IncompleteUploadCount()
+}
+
+func TestAuditHook_FailClosedRejectsEveryMutation(t *testing.T) {
+	server := newURLImportServer(t, urlImportSpec)
+	canaryPath := path.Join("pipelines", canaryFolder, "stale")
+	tests := []struct {
+		name   string
+		setup  func(t *testing.T, store *MinioObjectStore, minioClient *FakeMinioClient)
+		mutate func(ctx context.Context, store *MinioObjectStore) error
+	}{
+		{
+			name: "AddFileFromReader",
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				return store.AddFileFromReader(ctx, strings.NewReader("spec"), "pipelines/new", false)
+			},
+		},
+		{
+			name: "ImportFromURL",
+			setup: func(t *testing.T, store *MinioObjectStore, minioClient *FakeMinioClient) {
+				store.SetURLImportPolicy(URLImportPolicy{AllowedSchemes: []string{"http"}, AllowedHosts: []string{"127.0.0.1"}, MaxBytes: 1024})
+			},
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				return store.ImportFromURL(ctx, server.URL+"/spec.yaml", "pipelines/new")
+			},
+		},
+		{
+			name: "RestoreFile",
+			setup: func(t *testing.T, store *MinioObjectStore, minioClient *FakeMinioClient) {
+				store.SetSoftDelete(true)
+				require.Nil(t, store.DeleteFile(context.TODO(), "pipelines/existing"))
+			},
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				return store.RestoreFile(ctx, "pipelines/existing")
+			},
+		},
+		{
+			name: "PurgeRecycleBin",
+			setup: func(t *testing.T, store *MinioObjectStore, minioClient *FakeMinioClient) {
+				store.SetSoftDelete(true)
+				require.Nil(t, store.DeleteFile(context.TODO(), "pipelines/existing"))
+			},
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				_, err := store.PurgeRecycleBin(ctx, -time.Hour)
+				return err
+			},
+		},
+		{
+			name: "IncrementCounter",
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				_, err := store.IncrementCounter(ctx, "pipelines/counter")
+				return err
+			},
+		},
+		{
+			name: "RepairChunkedObject",
+			setup: func(t *testing.T, store *MinioObjectStore, minioClient *FakeMinioClient) {
+				putRawObject(t, minioClient, "pipelines/framed", frameChunks("pipelineSpec:\n"))
+			},
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				return store.RepairChunkedObject(ctx, "pipelines/framed")
+			},
+		},
+		{
+			name: "SetRetention",
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				return store.SetRetention(ctx, "pipelines/existing", ObjectRetention{LegalHold: minio.LegalHoldEnabled})
+			},
+		},
+		{
+			name: "SetStorageClass",
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				return store.SetStorageClass(ctx, "pipelines/existing", StorageClassGlacier)
+			},
+		},
+		{
+			name: "Reshard",
+			setup: func(t *testing.T, store *MinioObjectStore, minioClient *FakeMinioClient) {
+				store.SetKeySharding(true)
+			},
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				_, err := store.Reshard(ctx, "pipelines/", 1)
+				return err
+			},
+		},
+		{
+			name: "MigrateKeyNamespace",
+			setup: func(t *testing.T, store *MinioObjectStore, minioClient *FakeMinioClient) {
+				store.SetKeyNamespacer(DefaultKeyNamespacer)
+			},
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				_, err := store.MigrateKeyNamespace(ctx, "pipelines/", 1)
+				return err
+			},
+		},
+		{
+			name: "Quarantine",
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				_, err := store.ImportFiles(ctx, []ImportFile{{FilePath: "pipelines/invalid", Content: []byte("{")}}, nil)
+				return err
+			},
+		},
+		{
+			name: "GzipVariant",
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				return store.addGzipVariant(ctx, []byte("spec"), "pipelines/existing")
+			},
+		},
+		{
+			name: "PurgeIncompleteUploads",
+			setup: func(t *testing.T, store *MinioObjectStore, minioClient *FakeMinioClient) {
+				minioClient.StartIncompleteUpload("pipelines/upload")
+			},
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				_, err := store.PurgeIncompleteUploads(ctx, -time.Hour)
+				return err
+			},
+		},
+		{
+			name: "PurgeStaleCanaries",
+			setup: func(t *testing.T, store *MinioObjectStore, minioClient *FakeMinioClient) {
+				require.Nil(t, store.AddFile(context.TODO(), canaryContent, canaryPath))
+			},
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				_, err := store.PurgeStaleCanaries(ctx, -time.Hour)
+				return err
+			},
+		},
+		{
+			name: "MeasureLatency",
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				_, err := store.MeasureLatency(ctx)
+				return err
+			},
+		},
+		{
+			name: "CheckPrefixAccess",
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				return store.CheckPrefixAccess(ctx, "pipelines")
+			},
+		},
+		{
+			name: "CheckPermissions",
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				_, err := store.CheckPermissions(ctx, "pipelines")
+				return err
+			},
+		},
+		{
+			name: "LeaderElection",
+			mutate: func(ctx context.Context, store *MinioObjectStore) error {
+				_, err := NewLeaderElection(store, "pipelines/.leases/leader").Campaign(ctx, "replica", time.Minute)
+				return err
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sink := &auditSink{}
+			store, minioClient := newAuditedStore(t, sink, true)
+			minioClient.SetClock(store.clock)
+			if test.setup != nil {
+				test.setup(t, store, minioClient)
+			}
+			objects, uploads := fakeBackendState(minioClient)
+			sink.err = errors.New("audit sink unavailable")
+
+			err := test.mutate(context.TODO(), store)
+
+			require.NotNil(t, err)
+			assert.True(t, errors.Is(err, ErrAuditFailed), err.Error())
+			objectsAfter, uploadsAfter := fakeBackendState(minioClient)
+			assert.Equal(t, objects, objectsAfter)
+			assert.Equal(t, uploads, uploadsAfter)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+// batchManifestName is the name of the manifest written under the prefix of a batch.
+const batchManifestName = "manifest.json"
+
+// BatchManifest lists the files of a batch, so consumers can verify the set they read.
+type BatchManifest struct {
+	Files []BatchManifestEntry `json:"files"`
+}
+
+// BatchManifestEntry is a file of a batch, relative to the batch prefix.
+type BatchManifestEntry struct {
+	Path   string `json:"path"`
+	Sha256 string `json:"sha256"`
+	Size   int    `json:"size"`
+}
+
+// SetBatchManifest enables writing a manifest of the files stored by AddFiles, with their
+// SHA-256 and size, as manifest.json under the batch prefix.
+func (m *MinioObjectStore) SetBatchManifest(enabled bool) {
+	m.batchManifest = enabled
+}
+
+// AddFiles stores a batch of files under prefix, files mapping the path of each file
+// relative to prefix to its content. The manifest, if enabled, is written last, once all
+// the files are stored, so its presence means the batch is complete. If a file fails to be
+// stored, the files already stored are rolled back to their previous content, best-effort.
+func (m *MinioObjectStore) AddFiles(ctx context.Context, prefix string, files map[string][]byte) (err error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	undo := m.newBatchUndoLog()
+	defer func() {
+		if err != nil {
+			undo.rollback(ctx)
+		}
+	}()
+	manifest := BatchManifest{Files: make([]BatchManifestEntry, 0, len(names))}
+	for _, name := range names {
+		content := files[name]
+		if err := undo.record(ctx, path.Join(prefix, name)); err != nil {
+			return util.Wrapf(err, "Failed to store the batch of files under %v", prefix)
+		}
+		if err := m.addBatchFile(ctx, content, path.Join(prefix, name)); err != nil {
+			return util.Wrapf(err, "Failed to store the batch of files under %v", prefix)
+		}
+		manifest.Files = append(manifest.Files, BatchManifestEntry{
+			Path:   name,
+			Sha256: contentSha256(content),
+			Size:   len(content),
+		})
+	}
+	if !m.batchManifest {
+		return nil
+	}
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to marshal the manifest of the batch of files under %v", prefix)
+	}
+	if err := undo.record(ctx, path.Join(prefix, batchManifestName)); err != nil {
+		return util.Wrapf(err, "Failed to store the manifest of the batch of files under %v", prefix)
+	}
+	if err := m.addBatchFile(ctx, content, path.Join(prefix, batchManifestName)); err != nil {
+		return util.Wrapf(err, "Failed to store the manifest of the batch of files under %v", prefix)
+	}
+	return nil
+}
+
+// batchUndoLog records the content files had before a batch changed them, so the changes
+// can be undone if the batch fails midway.
+type batchUndoLog struct {
+	store   *MinioObjectStore
+	entries []batchUndoEntry
+}
+
+// batchUndoEntry is the content of a file before it was changed.
+type batchUndoEntry struct {
+	filePath string
+	existed  bool
+	content  []byte
+}
+
+func (m *MinioObjectStore) newBatchUndoLog() *batchUndoLog {
+	return &batchUndoLog{store: m}
+}
+
+// record saves the current content of filePath, before the batch changes it.
+func (l *batchUndoLog) record(ctx context.Context, filePath string) error {
+	content, err := l.store.getFile(ctx, filePath)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return util.Wrapf(err, "Failed to save the content of file %v before changing it", filePath)
+	}
+	l.entries = append(l.entries, batchUndoEntry{filePath: filePath, existed: err == nil, content: content})
+	return nil
+}
+
+// rollback restores the files recorded, in reverse order. Failures are logged rather than
+// returned, as the error that made the batch fail is the one worth reporting. It runs even
+// if ctx is done, since the batch usually fails because of that.
+func (l *batchUndoLog) rollback(ctx context.Context) {
+	ctx = context.WithoutCancel(ctx)
+	for i := len(l.entries) - 1; i >= 0; i-- {
+		entry := l.entries[i]
+		var err error
+		if !entry.existed {
+			err = l.store.DeleteFile(ctx, entry.filePath)
+			if errors.Is(err, ErrNotFound) {
+				err = nil
+			}
+		} else {
+			err = l.store.AddFile(ctx, entry.content, entry.filePath)
+		}
+		if err != nil {
+			glog.Errorf("Failed to roll back file %v of a failed batch: %v", entry.filePath, err)
+		}
+	}
+	l.entries = nil
+}
+
+// addBatchFile stores a file of a batch, within the budget left to the batch.
+func (m *MinioObjectStore) addBatchFile(ctx context.Context, content []byte, filePath string) error {
+	ctx, cancel, err := m.batchOperationContext(ctx, "store file", filePath)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	return m.AddFile(ctx, content, filePath)
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var batchFiles = map[string][]byte{
+	"pipeline.yaml":         []byte("pipelineInfo:\n  name: batch\n"),
+	"components/train.yaml": []byte("image: trainer\n"),
+}
+
+func TestAddFiles_Manifest(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	manager.SetBatchManifest(true)
+
+	require.Nil(t, manager.AddFiles(context.TODO(), "pipelines/batch", batchFiles))
+	assert.Equal(t, 3, minioClient.GetObjectCount())
+
+	var manifest BatchManifest
+	require.Nil(t, manager.GetFromYamlFile(context.TODO(), &manifest, "pipelines/batch/manifest.json"))
+	require.Len(t, manifest.Files, 2)
+	for _, entry := range manifest.Files {
+		data, err := manager.GetFile(context.TODO(), "pipelines/batch/"+entry.Path)
+		require.Nil(t, err)
+		sum := sha256.Sum256(data)
+		assert.Equal(t, hex.EncodeToString(sum[:]), entry.Sha256)
+		assert.Equal(t, len(batchFiles[entry.Path]), entry.Size)
+	}
+	assert.Equal(t, "components/train.yaml", manifest.Files[0].Path)
+	assert.Equal(t, "pipeline.yaml", manifest.Files[1].Path)
+
+	raw, err := manager.GetFile(context.TODO(), "pipelines/batch/manifest.json")
+	require.Nil(t, err)
+	assert.True(t, json.Valid(raw))
+}
+
+func TestAddFiles_NoManifest(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+
+	require.Nil(t, manager.AddFiles(context.TODO(), "pipelines/batch", batchFiles))
+	assert.Equal(t, 2, minioClient.GetObjectCount())
+	assert.False(t, minioClient.ExistObject("pipelines/batch/manifest.json"))
+}
+
+func TestAddFilesError(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipelines"}
+	manager.SetBatchManifest(true)
+
+	err := manager.AddFiles(context.TODO(), "pipelines/batch", batchFiles)
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Failed to store the batch of files under pipelines/batch")
+}
+
+func TestAddFilesError_RollsBackStoredFiles(t *testing.T) {
+	minioClient := &failingKeyMinioClient{FakeMinioClient: NewFakeMinioClient(), failKey: "pipelines/batch/pipeline.yaml"}
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("image: old\n"), "pipelines/batch/components/train.yaml"))
+
+	require.NotNil(t, manager.AddFiles(context.TODO(), "pipelines/batch", batchFiles))
+
+	assert.Equal(t, 1, minioClient.GetObjectCount())
+	content, err := manager.GetFile(context.TODO(), "pipelines/batch/components/train.yaml")
+	require.Nil(t, err)
+	assert.Equal(t, "image: old\n", string(content))
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+// BatchWriter buffers file writes and deletes until they are committed, so an operation
+// changing several files can still be abandoned without having changed any of them.
+// Nothing is read or written before Commit.
+type BatchWriter struct {
+	store   *MinioObjectStore
+	mutex   sync.Mutex
+	intents []batchIntent
+	done    bool
+}
+
+// batchIntent is a buffered write, or delete when delete is set.
+type batchIntent struct {
+	filePath string
+	content  []byte
+	delete   bool
+}
+
+// BeginBatch returns a writer buffering changes to the store until they are committed.
+func (m *MinioObjectStore) BeginBatch() *BatchWriter {
+	return &BatchWriter{store: m}
+}
+
+// AddFile buffers the write of content to filePath.
+func (b *BatchWriter) AddFile(content []byte, filePath string) error {
+	return b.buffer(batchIntent{filePath: filePath, content: append([]byte(nil), content...)})
+}
+
+// DeleteFile buffers the delete of filePath.
+func (b *BatchWriter) DeleteFile(filePath string) error {
+	return b.buffer(batchIntent{filePath: filePath, delete: true})
+}
+
+func (b *BatchWriter) buffer(intent batchIntent) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.done {
+		return util.NewFailedPreconditionError(errors.New("batch already committed or rolled back"),
+			"Failed to change file %v in batch", intent.filePath)
+	}
+	b.intents = append(b.intents, intent)
+	return nil
+}
+
+// Commit applies the buffered changes, in the order they were made. If one fails, the
+// changes already applied are rolled back, best-effort, like AddFiles does, and the
+// failure is returned. The batch cannot be used after Commit.
+func (b *BatchWriter) Commit(ctx context.Context) (err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.done {
+		return util.NewFailedPreconditionError(errors.New("batch already committed or rolled back"), "Failed to commit batch")
+	}
+	b.done = true
+	intents := b.intents
+	b.intents = nil
+
+	undo := b.store.newBatchUndoLog()
+	defer func() {
+		if err != nil {
+			undo.rollback(ctx)
+		}
+	}()
+	for _, intent := range intents {
+		if err := undo.record(ctx, intent.filePath); err != nil {
+			return util.Wrap(err, "Failed to commit batch")
+		}
+		if intent.delete {
+			err = b.store.DeleteFile(ctx, intent.filePath)
+		} else {
+			err = b.store.addBatchFile(ctx, intent.content, intent.filePath)
+		}
+		if err != nil {
+			return util.Wrap(err, "Failed to commit batch")
+		}
+	}
+	return nil
+}
+
+// Rollback discards the buffered changes. The batch cannot be used after Rollback.
+func (b *BatchWriter) Rollback() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.done = true
+	b.intents = nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// failingKeyMinioClient fails to store the object at failKey.
+type failingKeyMinioClient struct {
+	*FakeMinioClient
+	failKey string
+}
+
+func (c *failingKeyMinioClient) PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader,
+	objectSize int64, opts minio.PutObjectOptions,
+) (int64, error) {
+	if objectName == c.failKey {
+		return 0, errors.New("put failed")
+	}
+	return c.FakeMinioClient.PutObject(ctx, bucketName, objectName, reader, objectSize, opts)
+}
+
+func TestBatchWriter_Commit(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("old"), "pipelines/old"))
+
+	batch := manager.BeginBatch()
+	require.Nil(t, batch.AddFile([]byte("a"), "pipelines/a"))
+	require.Nil(t, batch.AddFile([]byte("b"), "pipelines/b"))
+	require.Nil(t, batch.DeleteFile("pipelines/old"))
+	// Nothing is written before the commit.
+	assert.False(t, minioClient.ExistObject("pipelines/a"))
+
+	require.Nil(t, batch.Commit(context.TODO()))
+	assert.Equal(t, 2, minioClient.GetObjectCount())
+	assert.True(t, minioClient.ExistObject("pipelines/a"))
+	assert.True(t, minioClient.ExistObject("pipelines/b"))
+	assert.False(t, minioClient.ExistObject("pipelines/old"))
+}
+
+func TestBatchWriter_Rollback(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+
+	batch := manager.BeginBatch()
+	require.Nil(t, batch.AddFile([]byte("a"), "pipelines/a"))
+	require.Nil(t, batch.DeleteFile("pipelines/b"))
+	batch.Rollback()
+
+	assert.Equal(t, 0, minioClient.GetObjectCount())
+	err := batch.Commit(context.TODO())
+	require.NotNil(t, err)
+	assert.Equal(t, codes.FailedPrecondition, err.(*util.UserError).ExternalStatusCode())
+	assert.NotNil(t, batch.AddFile([]byte("c"), "pipelines/c"))
+}
+
+func TestBatchWriter_CommitFailureRollsBack(t *testing.T) {
+	minioClient := &failingKeyMinioClient{FakeMinioClient: NewFakeMinioClient(), failKey: "pipelines/c"}
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("old a"), "pipelines/a"))
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("old b"), "pipelines/b"))
+
+	batch := manager.BeginBatch()
+	require.Nil(t, batch.AddFile([]byte("new a"), "pipelines/a"))
+	require.Nil(t, batch.DeleteFile("pipelines/b"))
+	require.Nil(t, batch.AddFile([]byte("new d"), "pipelines/d"))
+	require.Nil(t, batch.AddFile([]byte("c"), "pipelines/c"))
+
+	require.NotNil(t, batch.Commit(context.TODO()))
+	assert.Equal(t, 2, minioClient.GetObjectCount())
+	a, err := manager.GetFile(context.TODO(), "pipelines/a")
+	require.Nil(t, err)
+	assert.Equal(t, "old a", string(a))
+	b, err := manager.GetFile(context.TODO(), "pipelines/b")
+	require.Nil(t, err)
+	assert.Equal(t, "old b", string(b))
+	assert.False(t, minioClient.ExistObject("pipelines/d"))
+}
@@ -0,0 +1,266 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+)
+
+// CachingObjectStore caches file contents in memory. Before serving a cached file, it
+// compares the cached ETag with the one currently stored, so out-of-band updates are never
+// served stale while unchanged files are not downloaded again. The least recently used
+// entries are evicted once the cache holds maxEntries files.
+//
+// Files under the immutable prefixes, e.g. published pipeline versions, are immutable by
+// contract. They are served from the cache without revalidation, and kept apart from the
+// other files, up to maxEntries of them as well.
+//
+// Files read with a customer provided encryption key, see WithEncryptionOptions, are only
+// served from the cache to callers supplying the same key.
+type CachingObjectStore struct {
+	ObjectStoreInterface
+	immutablePrefixes []string
+	mutex             sync.Mutex
+	entries           *lruCache
+	immutable         *lruCache
+}
+
+// maxConcurrentPrefetches bounds the files fetched at once by Prefetch.
+const maxConcurrentPrefetches = 8
+
+type cacheEntry struct {
+	key  string
+	etag string
+	// customerKey identifies the customer provided key the file was read with, if any.
+	customerKey string
+	data        []byte
+}
+
+// lruCache holds up to maxEntries entries, evicting the least recently used ones. It is
+// guarded by the mutex of the store.
+type lruCache struct {
+	maxEntries int
+	elements   map[string]*list.Element
+	lru        *list.List
+}
+
+func newLRUCache(maxEntries int) *lruCache {
+	return &lruCache{maxEntries: maxEntries, elements: make(map[string]*list.Element), lru: list.New()}
+}
+
+// get returns the entry of key, marking it as the most recently used.
+func (l *lruCache) get(key string) (*cacheEntry, bool) {
+	element, ok := l.elements[key]
+	if !ok {
+		return nil, false
+	}
+	l.lru.MoveToFront(element)
+	return element.Value.(*cacheEntry), true
+}
+
+func (l *lruCache) put(entry *cacheEntry) {
+	if l.maxEntries <= 0 {
+		return
+	}
+	if element, ok := l.elements[entry.key]; ok {
+		element.Value = entry
+		l.lru.MoveToFront(element)
+		return
+	}
+	l.elements[entry.key] = l.lru.PushFront(entry)
+	for l.lru.Len() > l.maxEntries {
+		oldest := l.lru.Back()
+		l.lru.Remove(oldest)
+		delete(l.elements, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+func (l *lruCache) remove(key string) {
+	if element, ok := l.elements[key]; ok {
+		l.lru.Remove(element)
+		delete(l.elements, key)
+	}
+}
+
+func NewCachingObjectStore(store ObjectStoreInterface, maxEntries int) *CachingObjectStore {
+	return &CachingObjectStore{
+		ObjectStoreInterface: store,
+		entries:              newLRUCache(maxEntries),
+		immutable:            newLRUCache(maxEntries),
+	}
+}
+
+// SetImmutablePrefixes sets the prefixes of the files that never change once written.
+func (c *CachingObjectStore) SetImmutablePrefixes(prefixes []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.immutablePrefixes = prefixes
+}
+
+func (c *CachingObjectStore) GetFile(ctx context.Context, filePath string) ([]byte, error) {
+	if ForceFreshReads() {
+		return c.ObjectStoreInterface.GetFile(ctx, filePath)
+	}
+	key := cacheKey(ctx, filePath)
+	if c.isImmutable(filePath) {
+		return c.getImmutableFile(ctx, key, filePath)
+	}
+	info, err := c.ObjectStoreInterface.GetFileInfo(ctx, filePath)
+	if err != nil {
+		c.invalidate(key)
+		return c.ObjectStoreInterface.GetFile(ctx, filePath)
+	}
+	customerKey := customerKeyFingerprint(ctx)
+	if data, ok := c.lookup(key, info.ETag, customerKey); ok {
+		return data, nil
+	}
+	readCtx, report := withPointerReport(ctx)
+	data, err := c.ObjectStoreInterface.GetFile(readCtx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	// The content of a pointer target is not revalidated by the ETag of the pointer, so it
+	// is not cached.
+	if !report.followed.Load() {
+		c.store(key, info.ETag, customerKey, data)
+	}
+	return data, nil
+}
+
+func (c *CachingObjectStore) GetFromYamlFile(ctx context.Context, o interface{}, filePath string) error {
+	bytes, err := readYamlFile(ctx, c, filePath)
+	return unmarshalYamlFile(ctx, bytes, err, o, filePath)
+}
+
+func (c *CachingObjectStore) AddFile(ctx context.Context, file []byte, filePath string) error {
+	c.invalidate(cacheKey(ctx, filePath))
+	return c.ObjectStoreInterface.AddFile(ctx, file, filePath)
+}
+
+func (c *CachingObjectStore) AddAsYamlFile(ctx context.Context, o interface{}, filePath string) error {
+	c.invalidate(cacheKey(ctx, filePath))
+	return c.ObjectStoreInterface.AddAsYamlFile(ctx, o, filePath)
+}
+
+func (c *CachingObjectStore) DeleteFile(ctx context.Context, filePath string) error {
+	c.invalidate(cacheKey(ctx, filePath))
+	return c.ObjectStoreInterface.DeleteFile(ctx, filePath)
+}
+
+func (c *CachingObjectStore) MoveFile(ctx context.Context, srcPath string, dstPath string) error {
+	c.invalidate(cacheKey(ctx, srcPath))
+	c.invalidate(cacheKey(ctx, dstPath))
+	return c.ObjectStoreInterface.MoveFile(ctx, srcPath, dstPath)
+}
+
+// Prefetch fetches the files into the cache in the background, so later reads of them are
+// served from the cache, only revalidated like any cached file. It returns right away, and
+// the fetches outlive ctx, which usually belongs to the request anticipating the reads.
+// Failures are ignored: a file that could not be prefetched is fetched when read.
+func (c *CachingObjectStore) Prefetch(ctx context.Context, filePaths []string) {
+	go c.prefetch(context.WithoutCancel(ctx), filePaths)
+}
+
+// prefetch fetches the files into the cache, maxConcurrentPrefetches at a time.
+func (c *CachingObjectStore) prefetch(ctx context.Context, filePaths []string) {
+	paths := make(chan string)
+	var wg sync.WaitGroup
+	for worker := 0; worker < maxConcurrentPrefetches && worker < len(filePaths); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for filePath := range paths {
+				_, _ = c.GetFile(ctx, filePath)
+			}
+		}()
+	}
+	for _, filePath := range filePaths {
+		paths <- filePath
+	}
+	close(paths)
+	wg.Wait()
+}
+
+// getImmutableFile returns the immutable file at filePath, fetching it on first access only.
+func (c *CachingObjectStore) getImmutableFile(ctx context.Context, key string, filePath string) ([]byte, error) {
+	customerKey := customerKeyFingerprint(ctx)
+	c.mutex.Lock()
+	entry, ok := c.immutable.get(key)
+	c.mutex.Unlock()
+	if ok && entry.customerKey == customerKey {
+		return append([]byte(nil), entry.data...), nil
+	}
+	readCtx, report := withPointerReport(ctx)
+	data, err := c.ObjectStoreInterface.GetFile(readCtx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	// Pointers may be immutable while their target is not.
+	if report.followed.Load() {
+		return data, nil
+	}
+	c.mutex.Lock()
+	c.immutable.put(&cacheEntry{key: key, customerKey: customerKey, data: append([]byte(nil), data...)})
+	c.mutex.Unlock()
+	return data, nil
+}
+
+func (c *CachingObjectStore) isImmutable(filePath string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, prefix := range c.immutablePrefixes {
+		if strings.HasPrefix(filePath, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup returns a copy of the cached file if it is still at the given ETag, and was read
+// with the same customer provided key.
+func (c *CachingObjectStore) lookup(key string, etag string, customerKey string) ([]byte, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.entries.get(key)
+	if !ok || etag == "" || entry.etag != etag || entry.customerKey != customerKey {
+		return nil, false
+	}
+	return append([]byte(nil), entry.data...), true
+}
+
+func (c *CachingObjectStore) store(key string, etag string, customerKey string, data []byte) {
+	if etag == "" {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries.put(&cacheEntry{key: key, etag: etag, customerKey: customerKey, data: append([]byte(nil), data...)})
+}
+
+func (c *CachingObjectStore) invalidate(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	// Immutable files are only ever written once, but a failed write may be retried.
+	c.immutable.remove(key)
+	c.entries.remove(key)
+}
+
+// cacheKey identifies a file by its path and the namespace it is accessed in.
+func cacheKey(ctx context.Context, filePath string) string {
+	return string(keyNamespaceFromContext(ctx)) + ":" + filePath
+}
@@ -0,0 +1,301 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newCachingTestStore(maxEntries int) (*CachingObjectStore, *countingMinioClient) {
+	minioClient := newCountingMinioClient()
+	return NewCachingObjectStore(&MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}, maxEntries), minioClient
+}
+
+func TestCachingObjectStore_MatchingETagServedFromCache(t *testing.T) {
+	store, minioClient := newCachingTestStore(10)
+	require.Nil(t, store.AddFile(context.TODO(), []byte("id: 1"), store.GetPipelineKey("1")))
+
+	var foo Foo
+	require.Nil(t, store.GetFromYamlFile(context.TODO(), &foo, store.GetPipelineKey("1")))
+	assert.Equal(t, Foo{ID: 1}, foo)
+	require.Nil(t, store.GetFromYamlFile(context.TODO(), &foo, store.GetPipelineKey("1")))
+	assert.Equal(t, Foo{ID: 1}, foo)
+
+	assert.Equal(t, 1, minioClient.getCount)
+	assert.Equal(t, 2, minioClient.statCount)
+}
+
+func TestCachingObjectStore_ChangedETagRefetched(t *testing.T) {
+	store, minioClient := newCachingTestStore(10)
+	require.Nil(t, store.AddFile(context.TODO(), []byte("id: 1"), store.GetPipelineKey("1")))
+
+	var foo Foo
+	require.Nil(t, store.GetFromYamlFile(context.TODO(), &foo, store.GetPipelineKey("1")))
+	assert.Equal(t, Foo{ID: 1}, foo)
+
+	// Update the file behind the cache's back.
+	_, err := minioClient.FakeMinioClient.PutObject(context.TODO(), "", "pipeline/1",
+		bytes.NewReader([]byte("id: 2")), -1, minio.PutObjectOptions{})
+	require.Nil(t, err)
+
+	require.Nil(t, store.GetFromYamlFile(context.TODO(), &foo, store.GetPipelineKey("1")))
+	assert.Equal(t, Foo{ID: 2}, foo)
+	assert.Equal(t, 2, minioClient.getCount)
+
+	// The refreshed entry is served from the cache again.
+	require.Nil(t, store.GetFromYamlFile(context.TODO(), &foo, store.GetPipelineKey("1")))
+	assert.Equal(t, Foo{ID: 2}, foo)
+	assert.Equal(t, 2, minioClient.getCount)
+}
+
+func TestCachingObjectStore_WriteAndDeleteInvalidate(t *testing.T) {
+	store, minioClient := newCachingTestStore(10)
+	require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey("1")))
+	_, err := store.GetFile(context.TODO(), store.GetPipelineKey("1"))
+	require.Nil(t, err)
+
+	require.Nil(t, store.AddFile(context.TODO(), []byte("def"), store.GetPipelineKey("1")))
+	file, err := store.GetFile(context.TODO(), store.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("def"), file)
+	assert.Equal(t, 2, minioClient.getCount)
+
+	require.Nil(t, store.DeleteFile(context.TODO(), store.GetPipelineKey("1")))
+	_, err = store.GetFile(context.TODO(), store.GetPipelineKey("1"))
+	assert.NotNil(t, err)
+}
+
+func TestCachingObjectStore_Eviction(t *testing.T) {
+	store, minioClient := newCachingTestStore(1)
+	require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey("1")))
+	require.Nil(t, store.AddFile(context.TODO(), []byte("def"), store.GetPipelineKey("2")))
+
+	for _, id := range []string{"1", "2", "1"} {
+		_, err := store.GetFile(context.TODO(), store.GetPipelineKey(id))
+		require.Nil(t, err)
+	}
+	assert.Equal(t, 3, minioClient.getCount)
+}
+
+func TestCachingObjectStore_ReturnsCopies(t *testing.T) {
+	store, _ := newCachingTestStore(10)
+	require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey("1")))
+
+	file, err := store.GetFile(context.TODO(), store.GetPipelineKey("1"))
+	require.Nil(t, err)
+	file[0] = 'x'
+	file, err = store.GetFile(context.TODO(), store.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("abc"), file)
+}
+
+func TestCachingObjectStore_PointerTargetChangeServed(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	manager.SetPointerResolution(1)
+	store := NewCachingObjectStore(manager, 10)
+	store.SetImmutablePrefixes([]string{"pipeline/versions/"})
+	ctx := context.TODO()
+	require.Nil(t, store.AddFile(ctx, []byte("id: 1"), "pipeline/target"))
+	require.Nil(t, manager.AddPointerFile(ctx, "pipeline/1", "pipeline/target"))
+	require.Nil(t, manager.AddPointerFile(ctx, "pipeline/versions/1", "pipeline/target"))
+	for _, filePath := range []string{"pipeline/1", "pipeline/versions/1"} {
+		data, err := store.GetFile(ctx, filePath)
+		require.Nil(t, err)
+		assert.Equal(t, []byte("id: 1"), data)
+	}
+
+	// The target changes while the pointers do not.
+	require.Nil(t, store.AddFile(ctx, []byte("id: 2"), "pipeline/target"))
+
+	for _, filePath := range []string{"pipeline/1", "pipeline/versions/1"} {
+		data, err := store.GetFile(ctx, filePath)
+		require.Nil(t, err)
+		assert.Equal(t, []byte("id: 2"), data, filePath)
+	}
+}
+
+func TestCachingObjectStore_ImmutableServedWithoutRevalidation(t *testing.T) {
+	store, minioClient := newCachingTestStore(10)
+	store.SetImmutablePrefixes([]string{"pipeline/versions/"})
+	require.Nil(t, store.AddFile(context.TODO(), []byte("id: 1"), "pipeline/versions/1"))
+	require.Nil(t, store.AddFile(context.TODO(), []byte("id: 2"), "pipeline/2"))
+	minioClient.getCount, minioClient.statCount = 0, 0
+
+	for i := 0; i < 2; i++ {
+		data, err := store.GetFile(context.TODO(), "pipeline/versions/1")
+		require.Nil(t, err)
+		assert.Equal(t, []byte("id: 1"), data)
+	}
+	assert.Equal(t, 1, minioClient.getCount)
+	assert.Equal(t, 0, minioClient.statCount)
+
+	// Mutable files are still revalidated.
+	for i := 0; i < 2; i++ {
+		data, err := store.GetFile(context.TODO(), "pipeline/2")
+		require.Nil(t, err)
+		assert.Equal(t, []byte("id: 2"), data)
+	}
+	assert.Equal(t, 2, minioClient.getCount)
+	assert.Equal(t, 2, minioClient.statCount)
+}
+
+func TestCachingObjectStore_ImmutableNotEvictedByMutable(t *testing.T) {
+	store, minioClient := newCachingTestStore(1)
+	store.SetImmutablePrefixes([]string{"pipeline/versions/"})
+	for _, filePath := range []string{"pipeline/versions/1", "pipeline/2", "pipeline/3"} {
+		require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), filePath))
+		_, err := store.GetFile(context.TODO(), filePath)
+		require.Nil(t, err)
+	}
+	minioClient.getCount = 0
+
+	_, err := store.GetFile(context.TODO(), "pipeline/versions/1")
+	require.Nil(t, err)
+	assert.Equal(t, 0, minioClient.getCount)
+}
+
+func TestCachingObjectStore_ImmutableBounded(t *testing.T) {
+	store, minioClient := newCachingTestStore(2)
+	store.SetImmutablePrefixes([]string{"pipeline/versions/"})
+	for _, filePath := range []string{"pipeline/versions/1", "pipeline/versions/2", "pipeline/versions/3"} {
+		require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), filePath))
+		_, err := store.GetFile(context.TODO(), filePath)
+		require.Nil(t, err)
+	}
+	minioClient.getCount = 0
+
+	for _, filePath := range []string{"pipeline/versions/2", "pipeline/versions/3"} {
+		_, err := store.GetFile(context.TODO(), filePath)
+		require.Nil(t, err)
+	}
+	assert.Equal(t, 0, minioClient.getCount)
+	// The least recently used file was evicted.
+	_, err := store.GetFile(context.TODO(), "pipeline/versions/1")
+	require.Nil(t, err)
+	assert.Equal(t, 1, minioClient.getCount)
+	assert.Len(t, store.immutable.elements, 2)
+}
+
+func TestCachingObjectStore_CustomerKeyEntriesNotShared(t *testing.T) {
+	store, minioClient := newCachingTestStore(10)
+	store.SetImmutablePrefixes([]string{"pipeline/versions/"})
+	ctx := withTestCustomerKey(t, context.TODO())
+	for _, filePath := range []string{"pipeline/versions/1", "pipeline/2"} {
+		require.Nil(t, store.AddFile(ctx, []byte("secret"), filePath))
+		_, err := store.GetFile(ctx, filePath)
+		require.Nil(t, err)
+	}
+	minioClient.getCount = 0
+
+	for _, filePath := range []string{"pipeline/versions/1", "pipeline/2"} {
+		// The caller with the key is served from the cache.
+		_, err := store.GetFile(ctx, filePath)
+		require.Nil(t, err)
+		assert.Equal(t, 0, minioClient.getCount, filePath)
+	}
+	for _, filePath := range []string{"pipeline/versions/1", "pipeline/2"} {
+		// Callers without it go to the backend, which decides whether they may read it.
+		_, err := store.GetFile(context.TODO(), filePath)
+		require.Nil(t, err)
+	}
+	assert.Equal(t, 2, minioClient.getCount)
+}
+
+func TestCachingObjectStore_ImmutableNotCachedOnError(t *testing.T) {
+	store, minioClient := newCachingTestStore(10)
+	store.SetImmutablePrefixes([]string{"pipeline/versions/"})
+
+	_, err := store.GetFile(context.TODO(), "pipeline/versions/1")
+	require.NotNil(t, err)
+	require.Nil(t, store.AddFile(context.TODO(), []byte("id: 1"), "pipeline/versions/1"))
+	data, err := store.GetFile(context.TODO(), "pipeline/versions/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("id: 1"), data)
+	assert.Equal(t, 2, minioClient.getCount)
+}
+
+// newPrefetchTestStore returns a caching store expecting each of files to be downloaded once.
+func newPrefetchTestStore(t *testing.T, files map[string]string) *CachingObjectStore {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	for key, content := range files {
+		minioClient.EXPECT().
+			StatObject(gomock.Any(), "mlpipeline", key, gomock.Any()).
+			Return(minio.ObjectInfo{Key: key, ETag: "etag-" + key}, nil).
+			AnyTimes()
+		minioClient.EXPECT().
+			GetObject(gomock.Any(), "mlpipeline", key, gomock.Any()).
+			Return(bytes.NewReader([]byte(content)), nil).
+			Times(1)
+	}
+	return NewCachingObjectStore(&MinioObjectStore{minioClient: minioClient, bucketName: "mlpipeline", baseFolder: "pipeline"}, 10)
+}
+
+func (c *CachingObjectStore) isCached(ctx context.Context, filePath string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	_, ok := c.entries.elements[cacheKey(ctx, filePath)]
+	return ok
+}
+
+func TestCachingObjectStore_Prefetch(t *testing.T) {
+	store := newPrefetchTestStore(t, map[string]string{"pipeline/1": "id: 1", "pipeline/2": "id: 2"})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	store.Prefetch(ctx, []string{"pipeline/1", "pipeline/2"})
+	// The prefetch outlives the request it was made for.
+	cancel()
+	assert.Eventually(t, func() bool {
+		return store.isCached(ctx, "pipeline/1") && store.isCached(ctx, "pipeline/2")
+	}, 5*time.Second, time.Millisecond)
+
+	// Served from the cache, without downloading the files again.
+	data, err := store.GetFile(context.TODO(), "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("id: 1"), data)
+	data, err = store.GetFile(context.TODO(), "pipeline/2")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("id: 2"), data)
+}
+
+func TestCachingObjectStore_PrefetchIgnoresErrors(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	minioClient.EXPECT().
+		StatObject(gomock.Any(), "mlpipeline", "pipeline/missing", gomock.Any()).
+		Return(minio.ObjectInfo{}, newFakeNoSuchKeyError("pipeline/missing"))
+	minioClient.EXPECT().
+		GetObject(gomock.Any(), "mlpipeline", "pipeline/missing", gomock.Any()).
+		Return(nil, newFakeNoSuchKeyError("pipeline/missing"))
+	minioClient.EXPECT().
+		StatObject(gomock.Any(), "mlpipeline", "pipeline/broken", gomock.Any()).
+		Return(minio.ObjectInfo{Key: "pipeline/broken", ETag: "etag"}, nil)
+	minioClient.EXPECT().
+		GetObject(gomock.Any(), "mlpipeline", "pipeline/broken", gomock.Any()).
+		Return(nil, errors.New("connection reset"))
+	store := NewCachingObjectStore(&MinioObjectStore{minioClient: minioClient, bucketName: "mlpipeline", baseFolder: "pipeline"}, 10)
+
+	store.prefetch(context.TODO(), []string{"pipeline/missing", "pipeline/broken"})
+
+	assert.False(t, store.isCached(context.TODO(), "pipeline/missing"))
+	assert.False(t, store.isCached(context.TODO(), "pipeline/broken"))
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"mime"
+	"strings"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/ianaindex"
+	"golang.org/x/text/encoding/unicode"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+type charsetTranscodingContextKey struct{}
+
+// WithCharsetTranscoding makes GetFromYamlFile calls using ctx transcode files to UTF-8
+// before parsing them, e.g. specs written in Latin-1 by legacy exporters. The charset is
+// detected from the byte order mark of the file, or else the charset parameter of its
+// stored content type. Files without either are taken as UTF-8 and left untouched.
+func WithCharsetTranscoding(ctx context.Context) context.Context {
+	return context.WithValue(ctx, charsetTranscodingContextKey{}, true)
+}
+
+func charsetTranscodingFromContext(ctx context.Context) bool {
+	enabled, _ := ctx.Value(charsetTranscodingContextKey{}).(bool)
+	return enabled
+}
+
+// readYamlFile returns the content of the yaml file at filePath read from store, transcoded
+// to UTF-8 if requested with WithCharsetTranscoding. The stores wrapping another one read
+// their yaml files with it as well, passing themselves, so their reads transcode alike.
+func readYamlFile(ctx context.Context, store ObjectStoreInterface, filePath string) ([]byte, error) {
+	data, err := store.GetFile(ctx, filePath)
+	if err == nil && charsetTranscodingFromContext(ctx) {
+		data, err = transcodeFile(ctx, store, filePath, data)
+	}
+	return data, err
+}
+
+// transcodeFile returns data, the content of the file at filePath in store, transcoded to
+// UTF-8. Content not backed by a stored file, e.g. an embedded default, is taken as UTF-8
+// unless it starts with a byte order mark.
+func transcodeFile(ctx context.Context, store ObjectStoreInterface, filePath string, data []byte) ([]byte, error) {
+	if bomEncoding := detectBOMEncoding(data); bomEncoding != nil {
+		return decodeCharset(bomEncoding, data, filePath)
+	}
+	info, err := store.GetFileInfo(ctx, filePath)
+	if errors.Is(err, ErrNotFound) {
+		return data, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	charset := contentTypeCharset(info.ContentType)
+	switch charset {
+	case "", "utf-8", "utf8", "us-ascii":
+		return data, nil
+	}
+	charsetEncoding, err := ianaindex.IANA.Encoding(charset)
+	if err != nil || charsetEncoding == nil {
+		return nil, util.NewFailedPreconditionError(err, "Failed to transcode file %v: unsupported charset %q", filePath, charset)
+	}
+	return decodeCharset(charsetEncoding, data, filePath)
+}
+
+// detectBOMEncoding returns the Unicode encoding announced by the byte order mark data
+// starts with, nil if none.
+func detectBOMEncoding(data []byte) encoding.Encoding {
+	switch {
+	case bytes.HasPrefix(data, utf8BOM):
+		return unicode.UTF8BOM
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM)
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM)
+	default:
+		return nil
+	}
+}
+
+// contentTypeCharset returns the lower cased charset parameter of contentType, if any.
+func contentTypeCharset(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(params["charset"]))
+}
+
+func decodeCharset(charsetEncoding encoding.Encoding, data []byte, filePath string) ([]byte, error) {
+	decoded, err := charsetEncoding.NewDecoder().Bytes(data)
+	if err != nil {
+		return nil, util.NewFailedPreconditionError(err, "Failed to transcode file %v to UTF-8", filePath)
+	}
+	return decoded, nil
+}
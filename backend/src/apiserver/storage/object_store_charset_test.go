@@ -0,0 +1,124 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+type charsetTestSpec struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func putCharsetTestObject(t *testing.T, minioClient *FakeMinioClient, content []byte, contentType string) {
+	_, err := minioClient.PutObject(context.TODO(), "", "pipelines/1", bytes.NewReader(content), int64(len(content)),
+		minio.PutObjectOptions{ContentType: contentType})
+	require.Nil(t, err)
+}
+
+func TestGetFromYamlFile_TranscodesLatin1(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	// "Café für Ärzte" in Latin-1.
+	latin1 := []byte("name: caf\xe9\ndescription: f\xfcr \xc4rzte\n")
+	putCharsetTestObject(t, minioClient, latin1, "application/yaml; charset=ISO-8859-1")
+
+	var spec charsetTestSpec
+	require.Nil(t, manager.GetFromYamlFile(WithCharsetTranscoding(context.TODO()), &spec, "pipelines/1"))
+
+	assert.Equal(t, charsetTestSpec{Name: "café", Description: "für Ärzte"}, spec)
+}
+
+func TestGetFromYamlFile_TranscodesUTF16WithBOM(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	utf16 := []byte{0xFF, 0xFE}
+	for _, r := range "name: café\n" {
+		utf16 = append(utf16, byte(r), byte(r>>8))
+	}
+	putCharsetTestObject(t, minioClient, utf16, "application/octet-stream")
+
+	var spec charsetTestSpec
+	require.Nil(t, manager.GetFromYamlFile(WithCharsetTranscoding(context.TODO()), &spec, "pipelines/1"))
+
+	assert.Equal(t, "café", spec.Name)
+}
+
+func TestGetFromYamlFile_UTF8Untouched(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	utf8 := []byte("name: café\ndescription: für Ärzte ✓\n")
+	for _, contentType := range []string{"application/octet-stream", "application/yaml; charset=utf-8"} {
+		putCharsetTestObject(t, minioClient, utf8, contentType)
+
+		data, err := transcodeFile(context.TODO(), manager, "pipelines/1", utf8)
+		require.Nil(t, err)
+		assert.Equal(t, utf8, data, contentType)
+
+		var spec charsetTestSpec
+		require.Nil(t, manager.GetFromYamlFile(WithCharsetTranscoding(context.TODO()), &spec, "pipelines/1"))
+		assert.Equal(t, charsetTestSpec{Name: "café", Description: "für Ärzte ✓"}, spec, contentType)
+	}
+}
+
+func TestGetFromYamlFile_UnsupportedCharset(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	putCharsetTestObject(t, minioClient, []byte("name: a\n"), "application/yaml; charset=klingon")
+
+	var spec charsetTestSpec
+	err := manager.GetFromYamlFile(WithCharsetTranscoding(context.TODO()), &spec, "pipelines/1")
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.FailedPrecondition, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestGetFromYamlFile_WrappingStoresTranscode(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	putCharsetTestObject(t, minioClient, []byte("name: caf\xe9\n"), "application/yaml; charset=ISO-8859-1")
+	stores := map[string]ObjectStoreInterface{
+		"caching":           NewCachingObjectStore(manager, 10),
+		"memoizing":         NewMemoizingObjectStore(manager),
+		"embedded defaults": NewEmbeddedDefaultsObjectStore(manager, nil),
+		"quorum":            NewQuorumObjectStore(manager),
+	}
+
+	for name, store := range stores {
+		ctx := WithCharsetTranscoding(WithRequestMemo(context.TODO()))
+		var spec charsetTestSpec
+		require.Nil(t, store.GetFromYamlFile(ctx, &spec, "pipelines/1"), name)
+		assert.Equal(t, "café", spec.Name, name)
+	}
+}
+
+func TestGetFromYamlFile_EmbeddedDefaultTakenAsUTF8(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+	store := NewEmbeddedDefaultsObjectStore(manager, map[string][]byte{"pipelines/1": []byte("name: café\n")})
+
+	var spec charsetTestSpec
+	require.Nil(t, store.GetFromYamlFile(WithCharsetTranscoding(context.TODO()), &spec, "pipelines/1"))
+
+	assert.Equal(t, "café", spec.Name)
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+// chunkHeaderPattern matches the header line of a chunk of aws-chunked content.
+var chunkHeaderPattern = regexp.MustCompile(`^([0-9a-fA-F]+);chunk-signature=[0-9a-fA-F]+$`)
+
+var chunkLineEnd = []byte("\r\n")
+
+// RepairChunkedObject rewrites the file at filePath without the aws-chunked framing that
+// former single part uploads stored along with the content. Files without the framing are
+// left untouched. The rewrite fails if the file changes while being repaired.
+func (m *MinioObjectStore) RepairChunkedObject(ctx context.Context, filePath string) error {
+	_, err := m.repairChunkedObject(ctx, filePath, false)
+	return err
+}
+
+// RepairChunkedObjects repairs the files under prefix as RepairChunkedObject does, and
+// returns those that needed repairing. With dryRun set, the files are only reported.
+func (m *MinioObjectStore) RepairChunkedObjects(ctx context.Context, prefix string, dryRun bool) ([]string, error) {
+	var files []string
+	err := m.WalkFiles(ctx, prefix, func(file FileInfo) error {
+		framed, err := m.repairChunkedObject(ctx, file.Key, dryRun)
+		if err != nil {
+			return err
+		}
+		if framed {
+			files = append(files, file.Key)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// repairChunkedObject returns whether the file at filePath is framed, removing the framing
+// unless dryRun is set.
+func (m *MinioObjectStore) repairChunkedObject(ctx context.Context, filePath string, dryRun bool) (bool, error) {
+	if err := m.checkOpen("repair file", filePath); err != nil {
+		return false, err
+	}
+	if !dryRun {
+		if err := m.checkMaintenance("repair file", filePath); err != nil {
+			return false, err
+		}
+	}
+	if err := m.checkEnvironment(filePath, !dryRun); err != nil {
+		return false, err
+	}
+	key := m.resolveKey(ctx, filePath)
+	// The ETag is read before the content, so content newer than the ETag fails the put.
+	info, err := m.minioClient.StatObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+	if err != nil {
+		return false, newObjectStoreError(err, "Failed to stat file %v", filePath)
+	}
+	reader, err := m.minioClient.GetObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+	if err != nil {
+		return false, newObjectStoreError(err, "Failed to get file %v", filePath)
+	}
+	defer closeReader(reader)
+	data, err := readObject(reader)
+	if err != nil {
+		return false, newObjectStoreError(err, "Failed to get file %v", filePath)
+	}
+
+	content, framed, err := removeChunkFraming(data)
+	if err != nil {
+		return false, util.NewFailedPreconditionError(err, "Failed to repair file %v", filePath)
+	}
+	if !framed || dryRun {
+		return framed, nil
+	}
+	if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationWrite, Path: filePath, Size: int64(len(content))}); err != nil {
+		return true, err
+	}
+	opts := m.putObjectOptions(ctx)
+	setUserMetadata(&opts, contentSha256Metadata, contentSha256(content))
+	opts.SetMatchETag(info.ETag)
+	_, err = m.minioClient.PutObject(ctx, m.bucketName, key, bytes.NewReader(content), int64(len(content)), opts)
+	if err != nil {
+		return true, newObjectStoreError(err, "Failed to repair file %v", filePath)
+	}
+	glog.Infof("Removed the chunk framing of file %v", filePath)
+	return true, nil
+}
+
+// removeChunkFraming returns the content framed as aws-chunked in data, and whether data is
+// framed at all. Framed content is a sequence of chunks, each a
+// "<hex size>;chunk-signature=<signature>" line followed by that many bytes and a line
+// end, terminated by an empty chunk.
+func removeChunkFraming(data []byte) ([]byte, bool, error) {
+	if !isChunkHeader(data) {
+		return data, false, nil
+	}
+	var content []byte
+	for {
+		lineEnd := bytes.Index(data, chunkLineEnd)
+		if lineEnd < 0 {
+			return nil, true, errors.New("truncated chunk header")
+		}
+		match := chunkHeaderPattern.FindSubmatch(data[:lineEnd])
+		if match == nil {
+			return nil, true, errors.Errorf("invalid chunk header %q", data[:lineEnd])
+		}
+		size, err := strconv.ParseInt(string(match[1]), 16, 64)
+		if err != nil {
+			return nil, true, errors.Wrapf(err, "invalid chunk size %q", match[1])
+		}
+		data = data[lineEnd+len(chunkLineEnd):]
+		if size == 0 {
+			return content, true, nil
+		}
+		if int64(len(data)) < size+int64(len(chunkLineEnd)) {
+			return nil, true, errors.New("truncated chunk")
+		}
+		if !bytes.Equal(data[size:size+int64(len(chunkLineEnd))], chunkLineEnd) {
+			return nil, true, errors.New("chunk longer than its declared size")
+		}
+		content = append(content, data[:size]...)
+		data = data[size+int64(len(chunkLineEnd)):]
+	}
+}
+
+// isChunkHeader returns whether data starts with a chunk header line.
+func isChunkHeader(data []byte) bool {
+	lineEnd := bytes.Index(data, chunkLineEnd)
+	return lineEnd >= 0 && chunkHeaderPattern.Match(data[:lineEnd])
+}
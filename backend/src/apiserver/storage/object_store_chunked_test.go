@@ -0,0 +1,118 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+const chunkSignature = "ad80c730a21e5b8d04586a2213dd63b9a0e99e0e2307b0ade35a65485a288648"
+
+// frameChunks frames the chunks as aws-chunked content.
+func frameChunks(chunks ...string) []byte {
+	var framed strings.Builder
+	for _, chunk := range append(chunks, "") {
+		fmt.Fprintf(&framed, "%x;chunk-signature=%v\r\n%v\r\n", len(chunk), chunkSignature, chunk)
+	}
+	return []byte(framed.String())
+}
+
+func putRawObject(t *testing.T, minioClient MinioClientInterface, key string, content []byte) {
+	_, err := minioClient.PutObject(context.TODO(), "", key, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{})
+	require.Nil(t, err)
+}
+
+func TestRemoveChunkFraming(t *testing.T) {
+	content, framed, err := removeChunkFraming(frameChunks("pipelineSpec:\r\n", "  name: legacy\n"))
+	require.Nil(t, err)
+	assert.True(t, framed)
+	assert.Equal(t, "pipelineSpec:\r\n  name: legacy\n", string(content))
+
+	content, framed, err = removeChunkFraming([]byte("pipelineSpec: {}\n"))
+	require.Nil(t, err)
+	assert.False(t, framed)
+	assert.Equal(t, "pipelineSpec: {}\n", string(content))
+
+	_, _, err = removeChunkFraming(frameChunks("spec")[:90])
+	assert.NotNil(t, err)
+}
+
+func TestRepairChunkedObject(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline", disableMultipart: true}
+	putRawObject(t, minioClient, "pipeline/1", frameChunks("pipelineSpec:\n", "  name: legacy\n"))
+
+	require.Nil(t, manager.RepairChunkedObject(context.TODO(), "pipeline/1"))
+
+	data, err := manager.GetFile(context.TODO(), "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, "pipelineSpec:\n  name: legacy\n", string(data))
+	info, err := minioClient.StatObject(context.TODO(), "", "pipeline/1", minio.StatObjectOptions{})
+	require.Nil(t, err)
+	assert.Equal(t, int64(len(data)), info.Size)
+
+	// Repairing a clean file is a no-op.
+	require.Nil(t, manager.RepairChunkedObject(context.TODO(), "pipeline/1"))
+	repaired, err := minioClient.StatObject(context.TODO(), "", "pipeline/1", minio.StatObjectOptions{})
+	require.Nil(t, err)
+	assert.Equal(t, info.ETag, repaired.ETag)
+}
+
+func TestRepairChunkedObject_Corrupt(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	framed := frameChunks("pipelineSpec: {}\n")
+	putRawObject(t, minioClient, "pipeline/1", framed[:len(framed)-10])
+
+	err := manager.RepairChunkedObject(context.TODO(), "pipeline/1")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.FailedPrecondition, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestRepairChunkedObjects_DryRun(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	framed := frameChunks("pipelineSpec: {}\n")
+	putRawObject(t, minioClient, "pipeline/1", framed)
+	putRawObject(t, minioClient, "pipeline/2", []byte("pipelineSpec: {}\n"))
+
+	files, err := manager.RepairChunkedObjects(context.TODO(), "pipeline/", true)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"pipeline/1"}, files)
+	data, err := manager.GetFile(context.TODO(), "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, framed, data)
+
+	files, err = manager.RepairChunkedObjects(context.TODO(), "pipeline/", false)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"pipeline/1"}, files)
+	data, err = manager.GetFile(context.TODO(), "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, "pipelineSpec: {}\n", string(data))
+
+	files, err = manager.RepairChunkedObjects(context.TODO(), "pipeline/", true)
+	require.Nil(t, err)
+	assert.Empty(t, files)
+}
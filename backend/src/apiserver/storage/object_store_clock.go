@@ -0,0 +1,81 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// Clock is the source of the current time of the object store, so that the logic depending
+// on time, e.g. expiries, can be tested deterministically. util.TimeInterface
+// implementations are clocks.
+type Clock interface {
+	Now() time.Time
+}
+
+// NewRealClock returns the clock reading the system time.
+func NewRealClock() Clock {
+	return util.NewRealTime()
+}
+
+// SetClock sets the clock the store reads the time from. A nil clock is the real clock.
+func (m *MinioObjectStore) SetClock(clock Clock) {
+	m.clock = clock
+}
+
+// timerClock is implemented by the clocks that can also measure waits, like FakeClock.
+type timerClock interface {
+	After(d time.Duration) <-chan time.Time
+}
+
+// tickerClock is implemented by the clocks that can also tick periodically, like FakeClock.
+type tickerClock interface {
+	NewTicker(d time.Duration) (<-chan time.Time, func())
+}
+
+// after returns a channel receiving the time once d elapsed according to the clock of the
+// store. Clocks that cannot measure waits are waited on with the system timer.
+func (m *MinioObjectStore) after(d time.Duration) <-chan time.Time {
+	if clock, ok := m.clock.(timerClock); ok {
+		return clock.After(d)
+	}
+	return time.After(d)
+}
+
+// newTicker returns a channel receiving the time every d according to the clock of the
+// store, and the function stopping the ticker.
+func (m *MinioObjectStore) newTicker(d time.Duration) (<-chan time.Time, func()) {
+	return newClockTicker(m.clock, d)
+}
+
+// newClockTicker returns a channel receiving the time every d according to clock, and the
+// function stopping the ticker. Clocks that cannot tick are replaced by the system ticker.
+func newClockTicker(clock Clock, d time.Duration) (<-chan time.Time, func()) {
+	if clock, ok := clock.(tickerClock); ok {
+		return clock.NewTicker(d)
+	}
+	ticker := time.NewTicker(d)
+	return ticker.C, ticker.Stop
+}
+
+// now returns the current time according to the clock of the store.
+func (m *MinioObjectStore) now() time.Time {
+	if m.clock == nil {
+		return time.Now().UTC()
+	}
+	return m.clock.Now()
+}
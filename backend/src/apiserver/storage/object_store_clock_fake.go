@@ -0,0 +1,106 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is a clock whose time only changes when it is set or advanced.
+type FakeClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+// fakeTicker ticks whenever its clock moves past its next tick.
+type fakeTicker struct {
+	c        chan time.Time
+	interval time.Duration
+	next     time.Time
+	stopped  bool
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now.UTC()}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+// Set sets the time of the clock.
+func (c *FakeClock) Set(now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = now.UTC()
+	c.tick()
+}
+
+// Advance moves the time of the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = c.now.Add(d)
+	c.tick()
+}
+
+// After advances the clock by d and returns a channel already holding the new time, so
+// waits measured with the clock take no real time.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.Advance(d)
+	fired := make(chan time.Time, 1)
+	fired <- c.Now()
+	return fired
+}
+
+// NewTicker returns a channel receiving the time every d, as the clock is set or advanced
+// past each tick, and the function stopping the ticker. Like time.Ticker, ticks not
+// received in time are dropped.
+func (c *FakeClock) NewTicker(d time.Duration) (<-chan time.Time, func()) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	ticker := &fakeTicker{c: make(chan time.Time, 1), interval: d, next: c.now.Add(d)}
+	c.tickers = append(c.tickers, ticker)
+	return ticker.c, func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		ticker.stopped = true
+	}
+}
+
+// tick fires the tickers whose next tick is due. The mutex must be held.
+func (c *FakeClock) tick() {
+	active := c.tickers[:0]
+	for _, ticker := range c.tickers {
+		if ticker.stopped {
+			continue
+		}
+		if !ticker.next.After(c.now) {
+			select {
+			case ticker.c <- c.now:
+			default:
+			}
+			for !ticker.next.After(c.now) {
+				ticker.next = ticker.next.Add(ticker.interval)
+			}
+		}
+		active = append(active, ticker)
+	}
+	c.tickers = active
+}
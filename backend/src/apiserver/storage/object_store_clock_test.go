@@ -0,0 +1,104 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	assert.Equal(t, start, clock.Now())
+	assert.Equal(t, start, clock.Now())
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, start.Add(time.Minute), clock.Now())
+	clock.Set(start)
+	assert.Equal(t, start, clock.Now())
+}
+
+func TestFakeClock_NewTicker(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	ticks, stop := clock.NewTicker(time.Minute)
+
+	clock.Advance(time.Minute - time.Nanosecond)
+	assert.Empty(t, ticks)
+	clock.Advance(time.Nanosecond)
+	require.Len(t, ticks, 1)
+	assert.Equal(t, start.Add(time.Minute), <-ticks)
+	// Ticks not received in time are dropped.
+	clock.Advance(3 * time.Minute)
+	assert.Len(t, ticks, 1)
+	<-ticks
+
+	stop()
+	clock.Advance(time.Hour)
+	assert.Empty(t, ticks)
+}
+
+func TestPurgeRecycleBin_ExpiryBoundary(t *testing.T) {
+	clock := NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	minioClient := NewFakeMinioClient()
+	minioClient.SetClock(clock)
+	manager := NewMinioObjectStoreWithOptions(minioClient, "", "pipeline", WithSoftDelete(true), WithClock(clock))
+	ctx := context.TODO()
+	require.Nil(t, manager.AddFile(ctx, []byte("spec"), "pipeline/1"))
+	require.Nil(t, manager.DeleteFile(ctx, "pipeline/1"))
+
+	// A file deleted exactly olderThan ago is kept, it expires right after.
+	clock.Advance(24 * time.Hour)
+	purged, err := manager.PurgeRecycleBin(ctx, 24*time.Hour)
+	require.Nil(t, err)
+	assert.Equal(t, 0, purged)
+
+	clock.Advance(time.Nanosecond)
+	purged, err = manager.PurgeRecycleBin(ctx, 24*time.Hour)
+	require.Nil(t, err)
+	assert.Equal(t, 1, purged)
+	assert.Equal(t, 0, minioClient.GetObjectCount())
+}
+
+func TestCoalescingObjectStore_WindowBoundary(t *testing.T) {
+	clock := NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	minioClient := newCountingMinioClient()
+	store := NewCoalescingObjectStore(&MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}, time.Minute)
+	store.SetClock(clock)
+	require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey("1")))
+
+	clock.Advance(time.Minute - time.Nanosecond)
+	require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey("1")))
+	assert.Equal(t, 1, minioClient.putCount)
+
+	clock.Advance(time.Nanosecond)
+	require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey("1")))
+	assert.Equal(t, 2, minioClient.putCount)
+}
+
+func TestMeasureLatency_Clock(t *testing.T) {
+	clock := NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	manager := NewMinioObjectStoreWithOptions(NewFakeMinioClient(), "", "pipeline", WithClock(clock))
+	assert.Equal(t, Clock(clock), manager.Config().Clock)
+
+	report, err := manager.MeasureLatency(context.TODO())
+	require.Nil(t, err)
+	assert.Equal(t, LatencyReport{}, report)
+}
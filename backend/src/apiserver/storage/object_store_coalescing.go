@@ -0,0 +1,199 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"sigs.k8s.io/yaml"
+)
+
+// CoalescingObjectStore collapses repeated writes of identical content to the same key.
+// A write whose content matches what was last written to the key within the coalescing
+// window, while the object is still the one written then, is acknowledged after a stat
+// rather than rewritten. Writes of changed content, and writes carrying options on how the
+// object is stored, are always persisted immediately, so the latest content is never
+// lost. The writes of a key are forgotten once the window has passed, so memory use
+// follows the keys recently written.
+type CoalescingObjectStore struct {
+	ObjectStoreInterface
+	window    time.Duration
+	clock     Clock
+	mutex     sync.Mutex
+	entries   map[string]*coalescingEntry
+	lastSweep time.Time
+}
+
+// coalescingEntry tracks the last write to a key. Its mutex serializes writes to the key.
+// users, guarded by the mutex of the store, counts the writes holding the entry.
+type coalescingEntry struct {
+	mutex     sync.Mutex
+	hash      string
+	etag      string
+	writtenAt time.Time
+	users     int
+}
+
+func NewCoalescingObjectStore(store ObjectStoreInterface, window time.Duration) *CoalescingObjectStore {
+	return &CoalescingObjectStore{
+		ObjectStoreInterface: store,
+		window:               window,
+		clock:                NewRealClock(),
+		entries:              make(map[string]*coalescingEntry),
+	}
+}
+
+// SetClock sets the clock the coalescing window is measured with.
+func (c *CoalescingObjectStore) SetClock(clock Clock) {
+	c.clock = clock
+}
+
+func (c *CoalescingObjectStore) AddFile(ctx context.Context, file []byte, filePath string) error {
+	return c.coalesce(ctx, file, filePath, func() error {
+		return c.ObjectStoreInterface.AddFile(ctx, file, filePath)
+	})
+}
+
+// AddAsYamlFile coalesces on the marshalled content, but leaves the write to the wrapped
+// store's AddAsYamlFile, so its checks apply.
+func (c *CoalescingObjectStore) AddAsYamlFile(ctx context.Context, o interface{}, filePath string) error {
+	bytes, err := yaml.Marshal(o)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to marshal file %v: %v", filePath, err.Error())
+	}
+	return c.coalesce(ctx, bytes, filePath, func() error {
+		return c.ObjectStoreInterface.AddAsYamlFile(ctx, o, filePath)
+	})
+}
+
+// coalesce calls write unless content was last written to filePath within the window and
+// the object is still at the ETag it was written with, i.e. nobody wrote it since, be it
+// another replica or a write bypassing this store.
+func (c *CoalescingObjectStore) coalesce(ctx context.Context, content []byte, filePath string, write func() error) error {
+	if hasWriteOptions(ctx) {
+		return c.resetThenWrite(ctx, filePath, write)
+	}
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	entry := c.acquire(ctx, filePath)
+	defer c.release(entry)
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	now := c.clock.Now()
+	if !ForceFreshReads() && entry.hash == hash && now.Sub(entry.writtenAt) < c.window {
+		if info, err := c.ObjectStoreInterface.GetFileInfo(ctx, filePath); err == nil && info.ETag == entry.etag {
+			return nil
+		}
+	}
+	entry.hash = ""
+	if err := write(); err != nil {
+		return err
+	}
+	// Without the ETag of the write, it cannot be told whether the object changed since.
+	info, err := c.ObjectStoreInterface.GetFileInfo(ctx, filePath)
+	if err != nil || info.ETag == "" {
+		return nil
+	}
+	entry.hash = hash
+	entry.etag = info.ETag
+	entry.writtenAt = now
+	return nil
+}
+
+// resetThenWrite forgets the last write to filePath, then calls write without coalescing it.
+func (c *CoalescingObjectStore) resetThenWrite(ctx context.Context, filePath string, write func() error) error {
+	entry := c.acquire(ctx, filePath)
+	defer c.release(entry)
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	entry.hash = ""
+	return write()
+}
+
+// hasWriteOptions returns whether the writes using ctx carry options on how the object is
+// stored, e.g. its storage class, retention, encryption or version ID. Such writes are not
+// coalesced, since the object last written may have been stored with other options.
+func hasWriteOptions(ctx context.Context) bool {
+	_, retention := objectRetentionFromContext(ctx)
+	_, encryption := ctx.Value(encryptionContextKey{}).(encrypt.ServerSide)
+	return retention || encryption || storageClassFromContext(ctx) != "" ||
+		versionIDFromContext(ctx) != "" || idempotencyKeyFromContext(ctx) != ""
+}
+
+func (c *CoalescingObjectStore) DeleteFile(ctx context.Context, filePath string) error {
+	return c.resetThenWrite(ctx, filePath, func() error {
+		return c.ObjectStoreInterface.DeleteFile(ctx, filePath)
+	})
+}
+
+func (c *CoalescingObjectStore) MoveFile(ctx context.Context, srcPath string, dstPath string) error {
+	// Both entries are reset without holding them together, which could deadlock with a
+	// concurrent move in the opposite direction. A racing write is simply not coalesced.
+	for _, filePath := range []string{srcPath, dstPath} {
+		entry := c.acquire(ctx, filePath)
+		entry.mutex.Lock()
+		entry.hash = ""
+		entry.mutex.Unlock()
+		c.release(entry)
+	}
+	return c.ObjectStoreInterface.MoveFile(ctx, srcPath, dstPath)
+}
+
+// acquire returns the entry of the file, which must be released once done with. Entries
+// are keyed like the cache, so the same path in different namespaces is tracked apart.
+func (c *CoalescingObjectStore) acquire(ctx context.Context, filePath string) *coalescingEntry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.sweep()
+	key := cacheKey(ctx, filePath)
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &coalescingEntry{}
+		c.entries[key] = entry
+	}
+	entry.users++
+	return entry
+}
+
+func (c *CoalescingObjectStore) release(entry *coalescingEntry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry.users--
+}
+
+// sweep forgets the entries no write holds whose window has passed, at most once per
+// window. It must be called with the mutex held.
+func (c *CoalescingObjectStore) sweep() {
+	now := c.clock.Now()
+	if now.Sub(c.lastSweep) < c.window {
+		return
+	}
+	c.lastSweep = now
+	for key, entry := range c.entries {
+		// Entries no write holds are not written to, so reading them is safe.
+		if entry.users == 0 && (entry.hash == "" || now.Sub(entry.writtenAt) >= c.window) {
+			delete(c.entries, key)
+		}
+	}
+}
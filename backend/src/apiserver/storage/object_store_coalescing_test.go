@@ -0,0 +1,150 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoalescingObjectStore_IdenticalWritesCoalesced(t *testing.T) {
+	minioClient := newCountingMinioClient()
+	store := NewCoalescingObjectStore(&MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}, time.Minute)
+
+	for i := 0; i < 5; i++ {
+		require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey("1")))
+	}
+	assert.Equal(t, 1, minioClient.putCount)
+
+	file, err := store.GetFile(context.TODO(), store.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("abc"), file)
+}
+
+func TestCoalescingObjectStore_ChangedWriteFlushed(t *testing.T) {
+	minioClient := newCountingMinioClient()
+	store := NewCoalescingObjectStore(&MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}, time.Minute)
+
+	require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey("1")))
+	require.Nil(t, store.AddFile(context.TODO(), []byte("def"), store.GetPipelineKey("1")))
+	assert.Equal(t, 2, minioClient.putCount)
+
+	file, err := store.GetFile(context.TODO(), store.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("def"), file)
+
+	// Going back to the earlier content is a change as well.
+	require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey("1")))
+	assert.Equal(t, 3, minioClient.putCount)
+}
+
+func TestCoalescingObjectStore_WindowExpired(t *testing.T) {
+	minioClient := newCountingMinioClient()
+	store := NewCoalescingObjectStore(&MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}, 0)
+
+	require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey("1")))
+	require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey("1")))
+	assert.Equal(t, 2, minioClient.putCount)
+}
+
+func TestCoalescingObjectStore_DeleteResetsCoalescing(t *testing.T) {
+	minioClient := newCountingMinioClient()
+	store := NewCoalescingObjectStore(&MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}, time.Minute)
+
+	require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey("1")))
+	require.Nil(t, store.DeleteFile(context.TODO(), store.GetPipelineKey("1")))
+	require.Nil(t, store.AddAsYamlFile(context.TODO(), Foo{ID: 1}, store.GetPipelineKey("1")))
+	require.Nil(t, store.AddAsYamlFile(context.TODO(), Foo{ID: 1}, store.GetPipelineKey("1")))
+	assert.Equal(t, 2, minioClient.putCount)
+	assert.True(t, minioClient.ExistObject("pipeline/1"))
+}
+
+func TestCoalescingObjectStore_AddAsYamlFileUsesWrappedStore(t *testing.T) {
+	minioClient := newCountingMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	manager.SetMinYamlFileSize(1024, true)
+	store := NewCoalescingObjectStore(manager, time.Minute)
+
+	// The minimum size of the wrapped store applies to its AddAsYamlFile.
+	assert.NotNil(t, store.AddAsYamlFile(context.TODO(), Foo{ID: 1}, store.GetPipelineKey("1")))
+	assert.Equal(t, 0, minioClient.putCount)
+}
+
+func TestCoalescingObjectStore_KeyedByNamespace(t *testing.T) {
+	minioClient := newCountingMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	manager.SetKeyNamespacer(DefaultKeyNamespacer)
+	store := NewCoalescingObjectStore(manager, time.Minute)
+
+	require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), "pipeline/1"))
+	require.Nil(t, store.AddFile(WithKeyNamespace(context.TODO(), KeyNamespaceArtifact), []byte("abc"), "pipeline/1"))
+	assert.Equal(t, 2, minioClient.putCount)
+	assert.True(t, minioClient.ExistObject("spec/pipeline/1"))
+	assert.True(t, minioClient.ExistObject("artifact/pipeline/1"))
+}
+
+func TestCoalescingObjectStore_EvictsExpiredEntries(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	store := NewCoalescingObjectStore(&MinioObjectStore{minioClient: newCountingMinioClient(), baseFolder: "pipeline"}, time.Minute)
+	store.SetClock(clock)
+
+	for i := 0; i < 10; i++ {
+		require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey(strconv.Itoa(i))))
+	}
+	assert.Len(t, store.entries, 10)
+
+	clock.Advance(time.Minute)
+	require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey("10")))
+	assert.Len(t, store.entries, 1)
+}
+
+func TestCoalescingObjectStore_WriteOptionsNotCoalesced(t *testing.T) {
+	minioClient := newCountingMinioClient()
+	store := NewCoalescingObjectStore(&MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}, time.Minute)
+
+	require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey("1")))
+	ctx := WithStorageClass(context.TODO(), StorageClassGlacier)
+	require.Nil(t, store.AddFile(ctx, []byte("abc"), store.GetPipelineKey("1")))
+	assert.Equal(t, 2, minioClient.putCount)
+	info, err := minioClient.StatObject(ctx, "", "pipeline/1", minio.StatObjectOptions{})
+	require.Nil(t, err)
+	assert.Equal(t, StorageClassGlacier, info.StorageClass)
+
+	// A write carrying options also resets the coalescing of the key.
+	require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey("1")))
+	assert.Equal(t, 3, minioClient.putCount)
+}
+
+func TestCoalescingObjectStore_WriteBypassingStoreNotLost(t *testing.T) {
+	minioClient := newCountingMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	store := NewCoalescingObjectStore(manager, time.Minute)
+
+	require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey("1")))
+	require.Nil(t, manager.AddFileFromReader(context.TODO(), strings.NewReader("def"), store.GetPipelineKey("1"), false))
+	require.Nil(t, store.AddFile(context.TODO(), []byte("abc"), store.GetPipelineKey("1")))
+
+	assert.Equal(t, 3, minioClient.putCount)
+	file, err := store.GetFile(context.TODO(), store.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("abc"), file)
+}
@@ -0,0 +1,321 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+)
+
+const (
+	contentEncodingHeader = "Content-Encoding"
+	contentEncodingGzip   = "gzip"
+	contentEncodingZstd   = "zstd"
+)
+
+// decompressedSizeMetadata is the user metadata recording the size of the content of a
+// compressed object once decompressed.
+const decompressedSizeMetadata = "Kfp-Decompressed-Size"
+
+// lengthReader is implemented by the readers knowing the size of their remaining content,
+// e.g. bytes.Reader.
+type lengthReader interface {
+	Len() int
+}
+
+// AddFileFromReader streams the content of reader to filePath, without buffering it, which
+// suits large artifacts. With compress set, the content is gzipped on the fly and stored
+// with the gzip content encoding, so GetFileDecompressedReader restores the original bytes.
+// The lock of the file is held during the upload.
+func (m *MinioObjectStore) AddFileFromReader(ctx context.Context, reader io.Reader, filePath string, compress bool) error {
+	size := int64(-1)
+	if sized, ok := reader.(lengthReader); ok {
+		size = int64(sized.Len())
+	}
+	return m.WithKeyLock(ctx, filePath, func(ctx context.Context) error {
+		return m.addFileFromReader(ctx, reader, size, filePath, compress)
+	})
+}
+
+// addFileFromReader streams the size bytes of reader to filePath, with the checks of
+// AddFile. A negative size means unknown. Quotas are checked against the size if known,
+// and uploads of unknown size reserve the whole in-flight cap. Specs are buffered when
+// YAML validation applies to them, as YAML can only be validated whole. Uploads are only
+// retried if the content can be rewound, i.e. reader is an io.Seeker.
+func (m *MinioObjectStore) addFileFromReader(ctx context.Context, reader io.Reader, size int64, filePath string, compress bool) error {
+	if err := m.checkOpen("store file", filePath); err != nil {
+		return err
+	}
+	if err := m.checkMaintenance("store file", filePath); err != nil {
+		return err
+	}
+	if err := m.checkEnvironment(filePath, true); err != nil {
+		return err
+	}
+	key := m.resolveKey(ctx, filePath)
+	if err := m.checkKeyLength("store file", filePath, key); err != nil {
+		return err
+	}
+	if m.validateYaml && keyNamespaceFromContext(ctx) == KeyNamespaceSpec {
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return newObjectStoreError(err, "Failed to read file %v", filePath)
+		}
+		if err := m.checkYamlContent(ctx, content, filePath); err != nil {
+			return err
+		}
+		reader, size = bytes.NewReader(content), int64(len(content))
+	}
+	if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationWrite, Path: filePath, Size: size}); err != nil {
+		return err
+	}
+	tenant, quota := m.tenantQuota(ctx)
+	var previousSize int64
+	if quota {
+		previousSize = m.storedSize(ctx, key)
+		if err := m.checkQuota(ctx, tenant, filePath, max(size, 0)-previousSize); err != nil {
+			return err
+		}
+	}
+	reserved := size
+	if reserved < 0 {
+		reserved = m.maxInFlightBytes
+	}
+	release, err := m.reserveInFlightBytes(ctx, "store file", filePath, reserved)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	opts := m.putObjectOptions(ctx)
+	uploadSize := size
+	if compress {
+		// The decompressed size can only be recorded if known before the upload starts.
+		if size >= 0 {
+			setUserMetadata(&opts, decompressedSizeMetadata, strconv.FormatInt(size, 10))
+		}
+		opts.ContentEncoding = contentEncodingGzip
+		uploadSize = -1
+	}
+	seeker, rewindable := reader.(io.Seeker)
+	var start int64
+	if rewindable {
+		if start, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			rewindable = false
+		}
+	}
+	if !rewindable {
+		ctx = WithMaxRetries(ctx, 0)
+	}
+	var uploaded int64
+	err = m.retry(ctx, func(ctx context.Context) error {
+		if rewindable {
+			if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+				return err
+			}
+		}
+		content := reader
+		if compress {
+			compressed := newCompressingReader(reader)
+			// Stops the compression if the upload gives up on the content early.
+			defer compressed.Close()
+			content = compressed
+		}
+		// An unknown size makes the client stream the content as a multipart upload.
+		var err error
+		uploaded, err = m.minioClient.PutObject(ctx, m.bucketName, key, content, uploadSize, opts)
+		return err
+	})
+	if err != nil {
+		m.recordWarningEvent(ctx, eventReasonWriteFailed, "Failed to store file %v: %v", filePath, err)
+		return newObjectStoreError(err, "Failed to store file %v", filePath)
+	}
+	if quota {
+		m.quotaChecker.RecordUsage(ctx, tenant, uploaded-previousSize)
+	}
+	objectStoreUploads.WithLabelValues(uploadModeMultipart).Inc()
+	objectStoreUploadSize.WithLabelValues(uploadModeMultipart).Observe(float64(uploaded))
+	recordOperationSize(sizedOperationPut, uploaded)
+	return nil
+}
+
+// newCompressingReader returns a reader of the gzipped content of reader, compressed as
+// it is read. Closing it stops the compression.
+func newCompressingReader(reader io.Reader) io.ReadCloser {
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		gzipWriter := gzip.NewWriter(pipeWriter)
+		_, err := io.Copy(gzipWriter, reader)
+		if closeErr := gzipWriter.Close(); err == nil {
+			err = closeErr
+		}
+		pipeWriter.CloseWithError(err)
+	}()
+	return pipeReader
+}
+
+// GetFileDecompressedReader streams the file, transparently decompressing it according
+// to its stored content encoding. Objects without a known encoding are streamed as is.
+// Opening the file is retried, and its size is held against the in-flight cap until the
+// returned reader is closed, which the caller must do.
+func (m *MinioObjectStore) GetFileDecompressedReader(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	if err := m.checkOpen("get file", filePath); err != nil {
+		return nil, err
+	}
+	if err := m.checkEnvironment(filePath, false); err != nil {
+		return nil, err
+	}
+	release, err := m.reserveReadInFlightBytes(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	key := m.resolveKey(ctx, filePath)
+	var info minio.ObjectInfo
+	var decompressed io.ReadCloser
+	var decompressErr error
+	err = m.retry(ctx, func(ctx context.Context) error {
+		reader, err := m.minioClient.GetObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+		if err != nil {
+			return err
+		}
+		// The encoding is read from the object returned, as another version may be stored
+		// by the time a separate stat completes.
+		if object, ok := reader.(objectStater); ok {
+			info, err = object.Stat()
+		} else {
+			info, err = m.minioClient.StatObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+		}
+		if err != nil {
+			closeReader(reader)
+			return err
+		}
+		// Content that cannot be decompressed is not worth another attempt.
+		decompressed, decompressErr = newDecompressingReader(reader, info.Metadata.Get(contentEncodingHeader))
+		if decompressErr != nil {
+			closeReader(reader)
+		}
+		return nil
+	})
+	if err != nil {
+		release()
+		m.recordWarningEvent(ctx, eventReasonReadFailed, "Failed to get file %v: %v", filePath, err)
+		return nil, newObjectStoreError(err, "Failed to get file %v", filePath)
+	}
+	if decompressErr != nil {
+		release()
+		return nil, util.NewInternalServerError(decompressErr, "Failed to decompress file %v", filePath)
+	}
+	m.auditRead(ctx, AuditEvent{Operation: AuditOperationRead, Path: filePath, Size: info.Size})
+	var once sync.Once
+	return &readCloser{Reader: decompressed, close: func() error {
+		defer once.Do(release)
+		return decompressed.Close()
+	}}, nil
+}
+
+// GetFileDecompressed returns the content of the file, decompressed according to its
+// stored content encoding. GetFile, by contrast, returns the stored bytes as is, e.g. to
+// serve them again with the same encoding.
+func (m *MinioObjectStore) GetFileDecompressed(ctx context.Context, filePath string) ([]byte, error) {
+	reader, err := m.GetFileDecompressedReader(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to decompress file %v", filePath)
+	}
+	return data, nil
+}
+
+// isDecompressedEncoding returns whether objects with the given content encoding are
+// decompressed when read by GetFileDecompressedReader.
+func isDecompressedEncoding(contentEncoding string) bool {
+	switch strings.ToLower(strings.TrimSpace(contentEncoding)) {
+	case contentEncodingGzip, contentEncodingZstd:
+		return true
+	default:
+		return false
+	}
+}
+
+// logicalSize returns the size of the content of the object once decompressed, or -1 if
+// it is compressed and its decompressed size was not recorded.
+func logicalSize(info minio.ObjectInfo) int64 {
+	if !isDecompressedEncoding(info.Metadata.Get(contentEncodingHeader)) {
+		return info.Size
+	}
+	size, err := strconv.ParseInt(userMetadataValue(info, decompressedSizeMetadata), 10, 64)
+	if err != nil || size < 0 {
+		return -1
+	}
+	return size
+}
+
+// newDecompressingReader wraps reader with a decompressor for the given content encoding.
+func newDecompressingReader(reader io.Reader, contentEncoding string) (io.ReadCloser, error) {
+	switch strings.ToLower(strings.TrimSpace(contentEncoding)) {
+	case contentEncodingGzip:
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		return &readCloser{Reader: gzipReader, close: func() error {
+			gzipReader.Close()
+			return closeReader(reader)
+		}}, nil
+	case contentEncodingZstd:
+		zstdReader, err := zstd.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		return &readCloser{Reader: zstdReader, close: func() error {
+			zstdReader.Close()
+			return closeReader(reader)
+		}}, nil
+	default:
+		return &readCloser{Reader: reader, close: func() error {
+			return closeReader(reader)
+		}}, nil
+	}
+}
+
+// readCloser attaches a close function to a reader.
+type readCloser struct {
+	io.Reader
+	close func() error
+}
+
+func (r *readCloser) Close() error {
+	return r.close()
+}
+
+// closeReader closes reader if it holds any resources.
+func closeReader(reader io.Reader) error {
+	if closer, ok := reader.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
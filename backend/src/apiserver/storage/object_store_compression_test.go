@@ -0,0 +1,298 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+var compressionTestContent = []byte(strings.Repeat("pipelineSpec:\n  name: compressed\n", 1000))
+
+func gzipBytes(t *testing.T, content []byte) []byte {
+	buf := new(bytes.Buffer)
+	writer := gzip.NewWriter(buf)
+	_, err := writer.Write(content)
+	require.Nil(t, err)
+	require.Nil(t, writer.Close())
+	return buf.Bytes()
+}
+
+func zstdBytes(t *testing.T, content []byte) []byte {
+	encoder, err := zstd.NewWriter(nil)
+	require.Nil(t, err)
+	defer encoder.Close()
+	return encoder.EncodeAll(content, nil)
+}
+
+func putEncodedObject(t *testing.T, minioClient MinioClientInterface, key string, content []byte, encoding string) {
+	_, err := minioClient.PutObject(context.TODO(), "", key, bytes.NewReader(content), int64(len(content)),
+		minio.PutObjectOptions{ContentType: "application/octet-stream", ContentEncoding: encoding})
+	require.Nil(t, err)
+}
+
+func TestGetFileDecompressedReader(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding string
+		stored   []byte
+	}{
+		{name: "gzip", encoding: contentEncodingGzip, stored: gzipBytes(t, compressionTestContent)},
+		{name: "zstd", encoding: contentEncodingZstd, stored: zstdBytes(t, compressionTestContent)},
+		{name: "identity", encoding: "", stored: compressionTestContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minioClient := NewFakeMinioClient()
+			manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+			putEncodedObject(t, minioClient, manager.GetPipelineKey("1"), tt.stored, tt.encoding)
+
+			reader, err := manager.GetFileDecompressedReader(context.TODO(), manager.GetPipelineKey("1"))
+			require.Nil(t, err)
+			defer reader.Close()
+			content, err := io.ReadAll(reader)
+			require.Nil(t, err)
+			assert.Equal(t, compressionTestContent, content)
+		})
+	}
+}
+
+func TestGetFileDecompressedReader_CorruptContent(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	putEncodedObject(t, minioClient, manager.GetPipelineKey("1"), []byte("not gzip"), contentEncodingGzip)
+
+	_, err := manager.GetFileDecompressedReader(context.TODO(), manager.GetPipelineKey("1"))
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.Error(), "Failed to decompress")
+}
+
+func TestGetFileDecompressedReaderError(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipeline"}
+	_, err := manager.GetFileDecompressedReader(context.TODO(), manager.GetPipelineKey("1"))
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+}
+
+// staleStatMinioClient reports the attributes of a previous version of the objects when
+// stat-ed, one stored without any content encoding.
+type staleStatMinioClient struct {
+	*FakeMinioClient
+}
+
+func (c *staleStatMinioClient) StatObject(ctx context.Context, bucketName, objectName string,
+	opts minio.StatObjectOptions,
+) (minio.ObjectInfo, error) {
+	info, err := c.FakeMinioClient.StatObject(ctx, bucketName, objectName, opts)
+	info.Metadata = http.Header{}
+	return info, err
+}
+
+func TestGetFileDecompressedReader_EncodingOfObjectRead(t *testing.T) {
+	minioClient := &staleStatMinioClient{FakeMinioClient: NewFakeMinioClient()}
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	putEncodedObject(t, minioClient, manager.GetPipelineKey("1"), gzipBytes(t, compressionTestContent), contentEncodingGzip)
+
+	data, err := manager.GetFileDecompressed(context.TODO(), manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, compressionTestContent, data)
+}
+
+func TestGetFileDecompressedReader_Retried(t *testing.T) {
+	manager, minioClient := newFlakyStore(2, io.ErrUnexpectedEOF, 3)
+	putEncodedObject(t, minioClient.FakeMinioClient, manager.GetPipelineKey("1"), gzipBytes(t, compressionTestContent), contentEncodingGzip)
+
+	data, err := manager.GetFileDecompressed(context.TODO(), manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, compressionTestContent, data)
+	assert.Equal(t, 3, minioClient.calls)
+}
+
+func TestGetFileDecompressedReader_InFlightUntilClosed(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines", WithMaxInFlightBytes(10))
+	putEncodedObject(t, minioClient, manager.GetPipelineKey("1"), gzipBytes(t, compressionTestContent), contentEncodingGzip)
+
+	reader, err := manager.GetFileDecompressedReader(context.TODO(), manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+	ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+	defer cancel()
+	_, err = manager.GetFile(ctx, manager.GetPipelineKey("1"))
+	require.NotNil(t, err)
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+
+	require.Nil(t, reader.Close())
+	require.Nil(t, reader.Close())
+	_, err = manager.GetFile(context.TODO(), manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+}
+
+func TestGetFileDecompressedReader_Audited(t *testing.T) {
+	sink := &auditSink{}
+	store, _ := newAuditedStore(t, sink, true)
+
+	reader, err := store.GetFileDecompressedReader(context.TODO(), "pipelines/existing")
+	require.Nil(t, err)
+	require.Nil(t, reader.Close())
+	require.Len(t, sink.events, 2)
+	assert.Equal(t, AuditOperationRead, sink.events[1].Operation)
+	assert.Equal(t, "pipelines/existing", sink.events[1].Path)
+	assert.Equal(t, int64(len("spec")), sink.events[1].Size)
+}
+
+func TestAddFileFromReader_Compressed(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	// 8MiB, streamed without ever being held in memory by the caller.
+	content := io.LimitReader(&repeatingReader{pattern: compressionTestContent}, 8<<20)
+
+	require.Nil(t, manager.AddFileFromReader(context.TODO(), content, manager.GetPipelineKey("1"), true))
+
+	info, err := minioClient.StatObject(context.TODO(), "", "pipeline/1", minio.StatObjectOptions{})
+	require.Nil(t, err)
+	assert.Equal(t, contentEncodingGzip, info.Metadata.Get(contentEncodingHeader))
+	assert.Less(t, info.Size, int64(8<<20))
+
+	reader, err := manager.GetFileDecompressedReader(context.TODO(), manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+	defer reader.Close()
+	restored, err := io.ReadAll(reader)
+	require.Nil(t, err)
+	expected, err := io.ReadAll(io.LimitReader(&repeatingReader{pattern: compressionTestContent}, 8<<20))
+	require.Nil(t, err)
+	assert.Equal(t, expected, restored)
+}
+
+func TestAddFileFromReader_Uncompressed(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+
+	require.Nil(t, manager.AddFileFromReader(context.TODO(), bytes.NewReader(compressionTestContent), manager.GetPipelineKey("1"), false))
+
+	info, err := minioClient.StatObject(context.TODO(), "", "pipeline/1", minio.StatObjectOptions{})
+	require.Nil(t, err)
+	assert.Empty(t, info.Metadata.Get(contentEncodingHeader))
+	data, err := manager.GetFile(context.TODO(), manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, compressionTestContent, data)
+}
+
+func TestAddFileFromReaderError(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipeline"}
+	err := manager.AddFileFromReader(context.TODO(), bytes.NewReader(compressionTestContent), manager.GetPipelineKey("1"), true)
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+}
+
+// repeatingReader endlessly repeats a pattern.
+type repeatingReader struct {
+	pattern []byte
+	offset  int
+}
+
+func (r *repeatingReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		copied := copy(p[n:], r.pattern[r.offset:])
+		n += copied
+		r.offset = (r.offset + copied) % len(r.pattern)
+	}
+	return n, nil
+}
+
+func TestGetFileDecompressed(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	stored := gzipBytes(t, compressionTestContent)
+	putEncodedObject(t, minioClient, manager.GetPipelineKey("1"), stored, contentEncodingGzip)
+
+	raw, err := manager.GetFile(context.TODO(), manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, stored, raw)
+
+	data, err := manager.GetFileDecompressed(context.TODO(), manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, compressionTestContent, data)
+}
+
+func TestGetFileDecompressed_Uncompressed(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	putEncodedObject(t, minioClient, manager.GetPipelineKey("1"), compressionTestContent, "")
+
+	data, err := manager.GetFileDecompressed(context.TODO(), manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, compressionTestContent, data)
+}
+
+func TestGetFileDecompressed_TruncatedContent(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	stored := gzipBytes(t, compressionTestContent)
+	putEncodedObject(t, minioClient, manager.GetPipelineKey("1"), stored[:len(stored)/2], contentEncodingGzip)
+
+	_, err := manager.GetFileDecompressed(context.TODO(), manager.GetPipelineKey("1"))
+	require.NotNil(t, err)
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.Error(), "Failed to decompress")
+}
+
+func TestGetFileInfo_CompressedSizes(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	require.Nil(t, manager.AddFileFromReader(context.TODO(), bytes.NewReader(compressionTestContent), "pipeline/1", true))
+
+	info, err := manager.GetFileInfo(context.TODO(), "pipeline/1")
+
+	require.Nil(t, err)
+	assert.Equal(t, contentEncodingGzip, info.ContentEncoding)
+	assert.Equal(t, int64(len(compressionTestContent)), info.LogicalSize)
+	assert.Less(t, info.Size, info.LogicalSize)
+}
+
+func TestGetFileInfo_CompressedSizeUnknown(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	putEncodedObject(t, minioClient, "pipeline/1", zstdBytes(t, compressionTestContent), contentEncodingZstd)
+
+	info, err := manager.GetFileInfo(context.TODO(), "pipeline/1")
+
+	require.Nil(t, err)
+	assert.Equal(t, int64(-1), info.LogicalSize)
+}
+
+func TestGetFileInfo_UncompressedSizes(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	require.Nil(t, manager.AddFileFromReader(context.TODO(), bytes.NewReader(compressionTestContent), "pipeline/1", false))
+
+	info, err := manager.GetFileInfo(context.TODO(), "pipeline/1")
+
+	require.Nil(t, err)
+	assert.Equal(t, "", info.ContentEncoding)
+	assert.Equal(t, int64(len(compressionTestContent)), info.Size)
+	assert.Equal(t, info.Size, info.LogicalSize)
+}
@@ -0,0 +1,380 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// MinioObjectStoreConfig holds the settings of a MinioObjectStore.
+type MinioObjectStoreConfig struct {
+	BucketName       string
+	BaseFolder       string
+	DisableMultipart bool
+	// PartSize is the size of the parts of multipart uploads. Zero lets the client choose.
+	PartSize      uint64
+	Retry         RetryPolicy
+	Encryption    encrypt.ServerSide
+	KeyNamespacer KeyNamespacer
+	SoftDelete    bool
+	ValidateYaml  bool
+	VerifyUploads bool
+	BatchManifest bool
+	// MaxKeyLength is the maximum length of the keys written, in bytes. Zero is the S3 limit.
+	MaxKeyLength int
+	// Clock is the source of the current time. Nil is the real clock.
+	Clock Clock
+	// ReadAfterWriteTimeout bounds the wait for written files to be visible. Zero does not wait.
+	ReadAfterWriteTimeout time.Duration
+	// VersionKeyBuilder is the layout of the keys of pipeline versions. Nil is the default layout.
+	VersionKeyBuilder VersionKeyBuilder
+	// OperationTimeout bounds each operation of the batch helpers. Zero does not bound them.
+	OperationTimeout time.Duration
+	// DisableKeyNormalization keeps backslashes and duplicate slashes of file paths in keys.
+	DisableKeyNormalization bool
+	// MinYamlFileSize is the minimum plausible size of yaml files, in bytes. Zero disables the check.
+	MinYamlFileSize int
+	// RejectSmallYamlFiles rejects, rather than only flags, yaml files below MinYamlFileSize.
+	RejectSmallYamlFiles bool
+	// HealthPolicy sets how HealthCheck judges the success rate of recent operations.
+	HealthPolicy HealthPolicy
+	// KeySharding stores files under shard folders named after the hash of their name.
+	KeySharding bool
+	// UserAgent attributes the requests of the store to a KFP component. Empty keeps the
+	// user agent of the client.
+	UserAgent string
+	// MaxInFlightBytes caps the bytes stored and read at once. Zero does not cap them.
+	MaxInFlightBytes int64
+	// SizeDeadlineBase bounds the transfer of each file, along with SizeDeadlinePerMegabyte
+	// for every megabyte of the file. Zero does not bound them.
+	SizeDeadlineBase        time.Duration
+	SizeDeadlinePerMegabyte time.Duration
+	// KeyTransform maps the logical keys to the keys objects are stored under. Nil stores
+	// objects under their logical key.
+	KeyTransform KeyTransform
+	// GzipVariants stores a gzipped variant next to each yaml file.
+	GzipVariants bool
+	// AccessTrackingInterval is the minimum interval between the updates of the last access
+	// recorded for each file. Zero does not track accesses.
+	AccessTrackingInterval time.Duration
+	// ContentDecoders are the decoders GetFile passes the content of the files through, in
+	// order.
+	ContentDecoders []ContentDecoder
+	// AuditHook records the operations on files. AuditFailClosed rejects the mutating
+	// operations whose event could not be recorded.
+	AuditHook       AuditHook
+	AuditFailClosed bool
+}
+
+// MinioObjectStoreOption sets a setting of a MinioObjectStore when it is created.
+type MinioObjectStoreOption func(config *MinioObjectStoreConfig)
+
+// WithDisableMultipart makes uploads single part requests.
+func WithDisableMultipart(disable bool) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.DisableMultipart = disable
+	}
+}
+
+// WithPartSize sets the size of the parts of multipart uploads.
+func WithPartSize(partSize uint64) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.PartSize = partSize
+	}
+}
+
+// WithRetry retries the backend calls failing with transient errors according to policy.
+func WithRetry(policy RetryPolicy) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.Retry = policy
+	}
+}
+
+// WithEncryption stores objects with the given server side encryption. Customer provided
+// keys are also sent with every read, as the backend requires.
+func WithEncryption(encryption encrypt.ServerSide) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.Encryption = encryption
+	}
+}
+
+// WithKeyNamespacer is the option equivalent of SetKeyNamespacer.
+func WithKeyNamespacer(namespacer KeyNamespacer) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.KeyNamespacer = namespacer
+	}
+}
+
+// WithSoftDelete is the option equivalent of SetSoftDelete.
+func WithSoftDelete(enabled bool) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.SoftDelete = enabled
+	}
+}
+
+// WithYamlValidation is the option equivalent of SetYamlValidation.
+func WithYamlValidation(enabled bool) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.ValidateYaml = enabled
+	}
+}
+
+// WithUploadVerification is the option equivalent of SetUploadVerification.
+func WithUploadVerification(enabled bool) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.VerifyUploads = enabled
+	}
+}
+
+// WithBatchManifest is the option equivalent of SetBatchManifest.
+func WithBatchManifest(enabled bool) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.BatchManifest = enabled
+	}
+}
+
+// WithMaxKeyLength is the option equivalent of SetMaxKeyLength.
+func WithMaxKeyLength(maxKeyLength int) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.MaxKeyLength = maxKeyLength
+	}
+}
+
+// WithClock is the option equivalent of SetClock.
+func WithClock(clock Clock) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.Clock = clock
+	}
+}
+
+// WithReadAfterWriteTimeout is the option equivalent of SetReadAfterWriteTimeout.
+func WithReadAfterWriteTimeout(timeout time.Duration) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.ReadAfterWriteTimeout = timeout
+	}
+}
+
+// WithVersionKeyBuilder is the option equivalent of SetVersionKeyBuilder.
+func WithVersionKeyBuilder(builder VersionKeyBuilder) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.VersionKeyBuilder = builder
+	}
+}
+
+// WithOperationTimeout is the option equivalent of SetOperationTimeout.
+func WithOperationTimeout(timeout time.Duration) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.OperationTimeout = timeout
+	}
+}
+
+// WithKeyNormalization is the option equivalent of SetKeyNormalization.
+func WithKeyNormalization(enabled bool) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.DisableKeyNormalization = !enabled
+	}
+}
+
+// WithMinYamlFileSize is the option equivalent of SetMinYamlFileSize.
+func WithMinYamlFileSize(minSize int, reject bool) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.MinYamlFileSize = minSize
+		config.RejectSmallYamlFiles = reject
+	}
+}
+
+// WithHealthPolicy is the option equivalent of SetHealthPolicy.
+func WithHealthPolicy(policy HealthPolicy) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.HealthPolicy = policy
+	}
+}
+
+// WithKeySharding is the option equivalent of SetKeySharding.
+func WithKeySharding(enabled bool) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.KeySharding = enabled
+	}
+}
+
+// WithUserAgent is the option equivalent of SetUserAgent.
+func WithUserAgent(userAgent string) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.UserAgent = userAgent
+	}
+}
+
+// WithMaxInFlightBytes is the option equivalent of SetMaxInFlightBytes.
+func WithMaxInFlightBytes(maxBytes int64) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.MaxInFlightBytes = maxBytes
+	}
+}
+
+// WithSizeDeadline is the option equivalent of SetSizeDeadline.
+func WithSizeDeadline(base time.Duration, perMegabyte time.Duration) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.SizeDeadlineBase = base
+		config.SizeDeadlinePerMegabyte = perMegabyte
+	}
+}
+
+// WithKeyTransform is the option equivalent of SetKeyTransform.
+func WithKeyTransform(transform KeyTransform) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.KeyTransform = transform
+	}
+}
+
+// WithGzipVariants is the option equivalent of SetGzipVariants.
+func WithGzipVariants(enabled bool) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.GzipVariants = enabled
+	}
+}
+
+// WithAccessTracking is the option equivalent of SetAccessTracking.
+func WithAccessTracking(interval time.Duration) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.AccessTrackingInterval = interval
+	}
+}
+
+// WithContentDecoders is the option equivalent of registering each of decoders, in order,
+// with RegisterContentDecoder.
+func WithContentDecoders(decoders ...ContentDecoder) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.ContentDecoders = append(config.ContentDecoders, decoders...)
+	}
+}
+
+// WithAuditHook is the option equivalent of SetAuditHook.
+func WithAuditHook(hook AuditHook, failClosed bool) MinioObjectStoreOption {
+	return func(config *MinioObjectStoreConfig) {
+		config.AuditHook = hook
+		config.AuditFailClosed = failClosed
+	}
+}
+
+// NewMinioObjectStoreWithOptions creates a store of the objects under baseFolder in the
+// given bucket. Settings left out keep their zero value.
+func NewMinioObjectStoreWithOptions(minioClient MinioClientInterface, bucketName string, baseFolder string,
+	opts ...MinioObjectStoreOption,
+) *MinioObjectStore {
+	config := MinioObjectStoreConfig{BucketName: bucketName, BaseFolder: baseFolder}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	store := &MinioObjectStore{
+		minioClient:             minioClient,
+		bucketName:              config.BucketName,
+		baseFolder:              config.BaseFolder,
+		disableMultipart:        config.DisableMultipart,
+		partSize:                config.PartSize,
+		retryPolicy:             config.Retry,
+		encryption:              config.Encryption,
+		keyNamespacer:           config.KeyNamespacer,
+		softDelete:              config.SoftDelete,
+		validateYaml:            config.ValidateYaml,
+		verifyUploads:           config.VerifyUploads,
+		batchManifest:           config.BatchManifest,
+		maxKeyLength:            config.MaxKeyLength,
+		clock:                   config.Clock,
+		readAfterWriteTimeout:   config.ReadAfterWriteTimeout,
+		versionKeyBuilder:       config.VersionKeyBuilder,
+		operationTimeout:        config.OperationTimeout,
+		disableKeyNormalization: config.DisableKeyNormalization,
+		minYamlFileSize:         config.MinYamlFileSize,
+		rejectSmallYamlFiles:    config.RejectSmallYamlFiles,
+		healthPolicy:            config.HealthPolicy,
+		keySharding:             config.KeySharding,
+		sizeDeadlineBase:        config.SizeDeadlineBase,
+		sizeDeadlinePerMegabyte: config.SizeDeadlinePerMegabyte,
+		keyTransform:            config.KeyTransform,
+		gzipVariants:            config.GzipVariants,
+		accessTrackingInterval:  config.AccessTrackingInterval,
+		contentDecoders:         config.ContentDecoders,
+		auditHook:               config.AuditHook,
+		auditFailClosed:         config.AuditFailClosed,
+	}
+	store.SetUserAgent(config.UserAgent)
+	store.SetMaxInFlightBytes(config.MaxInFlightBytes)
+	return store
+}
+
+// Config returns the settings of the store.
+func (m *MinioObjectStore) Config() MinioObjectStoreConfig {
+	return MinioObjectStoreConfig{
+		BucketName:              m.bucketName,
+		BaseFolder:              m.baseFolder,
+		DisableMultipart:        m.disableMultipart,
+		PartSize:                m.partSize,
+		Retry:                   m.retryPolicy,
+		Encryption:              m.encryption,
+		KeyNamespacer:           m.keyNamespacer,
+		SoftDelete:              m.softDelete,
+		ValidateYaml:            m.validateYaml,
+		VerifyUploads:           m.verifyUploads,
+		BatchManifest:           m.batchManifest,
+		MaxKeyLength:            m.maxKeyLength,
+		Clock:                   m.clock,
+		ReadAfterWriteTimeout:   m.readAfterWriteTimeout,
+		VersionKeyBuilder:       m.versionKeyBuilder,
+		OperationTimeout:        m.operationTimeout,
+		DisableKeyNormalization: m.disableKeyNormalization,
+		MinYamlFileSize:         m.minYamlFileSize,
+		RejectSmallYamlFiles:    m.rejectSmallYamlFiles,
+		HealthPolicy:            m.healthPolicy,
+		KeySharding:             m.keySharding,
+		UserAgent:               m.userAgent,
+		MaxInFlightBytes:        m.maxInFlightBytes,
+		SizeDeadlineBase:        m.sizeDeadlineBase,
+		SizeDeadlinePerMegabyte: m.sizeDeadlinePerMegabyte,
+		KeyTransform:            m.keyTransform,
+		GzipVariants:            m.gzipVariants,
+		AccessTrackingInterval:  m.accessTrackingInterval,
+		ContentDecoders:         m.contentDecoders,
+		AuditHook:               m.auditHook,
+		AuditFailClosed:         m.auditFailClosed,
+	}
+}
+
+// putObjectOptions returns the options objects are stored with.
+func (m *MinioObjectStore) putObjectOptions(ctx context.Context) minio.PutObjectOptions {
+	return minio.PutObjectOptions{
+		ContentType:          "application/octet-stream",
+		PartSize:             m.partSize,
+		ServerSideEncryption: m.serverSideEncryption(ctx),
+	}
+}
+
+// getObjectOptions returns the options objects are read and stat-ed with.
+func (m *MinioObjectStore) getObjectOptions(ctx context.Context) minio.GetObjectOptions {
+	return minio.GetObjectOptions{ServerSideEncryption: m.serverSideEncryption(ctx)}
+}
+
+// copySourceEncryption returns the key needed to copy objects, if they are encrypted with
+// a customer provided key.
+func (m *MinioObjectStore) copySourceEncryption(ctx context.Context) encrypt.ServerSide {
+	encryption := m.serverSideEncryption(ctx)
+	if encryption != nil && encryption.Type() == encrypt.SSEC {
+		return encryption
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewMinioObjectStoreWithOptions_Defaults(t *testing.T) {
+	manager := NewMinioObjectStoreWithOptions(NewFakeMinioClient(), "mlpipeline", "pipelines")
+
+	assert.Equal(t, MinioObjectStoreConfig{BucketName: "mlpipeline", BaseFolder: "pipelines"}, manager.Config())
+}
+
+func TestNewMinioObjectStoreWithOptions(t *testing.T) {
+	encryption := encrypt.NewSSE()
+	policy := RetryPolicy{MaxAttempts: 3, Backoff: time.Second}
+	manager := NewMinioObjectStoreWithOptions(NewFakeMinioClient(), "mlpipeline", "pipelines",
+		WithDisableMultipart(true),
+		WithPartSize(32<<20),
+		WithRetry(policy),
+		WithEncryption(encryption),
+		WithSoftDelete(true),
+		WithYamlValidation(true),
+		WithUploadVerification(true),
+		WithBatchManifest(true),
+	)
+
+	config := manager.Config()
+	assert.Equal(t, "mlpipeline", config.BucketName)
+	assert.Equal(t, "pipelines", config.BaseFolder)
+	assert.True(t, config.DisableMultipart)
+	assert.Equal(t, uint64(32<<20), config.PartSize)
+	assert.Equal(t, policy, config.Retry)
+	assert.Equal(t, encryption, config.Encryption)
+	assert.True(t, config.SoftDelete)
+	assert.True(t, config.ValidateYaml)
+	assert.True(t, config.VerifyUploads)
+	assert.True(t, config.BatchManifest)
+}
+
+func TestNewMinioObjectStoreWithOptions_LastOptionWins(t *testing.T) {
+	manager := NewMinioObjectStoreWithOptions(NewFakeMinioClient(), "mlpipeline", "pipelines",
+		WithPartSize(16<<20), WithKeyNamespacer(DefaultKeyNamespacer), WithPartSize(64<<20))
+
+	assert.Equal(t, uint64(64<<20), manager.Config().PartSize)
+	assert.Equal(t, "spec/pipelines/1", manager.resolveKey(context.TODO(), manager.GetPipelineKey("1")))
+}
+
+func TestNewMinioObjectStore_Compatibility(t *testing.T) {
+	manager := NewMinioObjectStore(NewFakeMinioClient(), "mlpipeline", "pipelines", true)
+
+	assert.Equal(t, MinioObjectStoreConfig{BucketName: "mlpipeline", BaseFolder: "pipelines", DisableMultipart: true},
+		manager.Config())
+}
+
+func TestNewMinioObjectStoreWithOptions_AppliedToRequests(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	encryption := encrypt.NewSSE()
+	manager := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines",
+		WithPartSize(32<<20), WithEncryption(encryption))
+
+	minioClient.EXPECT().
+		PutObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any(), int64(multipartDefaultSize), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (int64, error) {
+			assert.Equal(t, uint64(32<<20), opts.PartSize)
+			assert.Equal(t, encryption, opts.ServerSideEncryption)
+			return objectSize, nil
+		})
+	minioClient.EXPECT().
+		StatObject(gomock.Any(), "mlpipeline", "pipelines/1", minio.StatObjectOptions{ServerSideEncryption: encryption}).
+		Return(minio.ObjectInfo{Key: "pipelines/1"}, nil)
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), manager.GetPipelineKey("1")))
+	_, err := manager.GetFileInfo(context.TODO(), manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+}
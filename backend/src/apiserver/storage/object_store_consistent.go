@@ -0,0 +1,49 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// defaultConsistencyWindow is how long after a write GetFileConsistent retries not found
+// errors, unless a read after write timeout is set.
+const defaultConsistencyWindow = 5 * time.Second
+
+// GetFileConsistent gets the file at filePath, known to have been written at writtenAt.
+// Until the read after write timeout elapsed since writtenAt, the file not being found is
+// retried, to ride out the window in which eventually consistent backends may not list
+// new objects yet. Past that window, it reads the file once like GetFile.
+func (m *MinioObjectStore) GetFileConsistent(ctx context.Context, filePath string, writtenAt time.Time) ([]byte, error) {
+	window := m.readAfterWriteTimeout
+	if window <= 0 {
+		window = defaultConsistencyWindow
+	}
+	consistentAt := writtenAt.Add(window)
+	interval := initialVisibilityPollInterval
+	for {
+		data, err := m.GetFile(ctx, filePath)
+		if err == nil || ClassifyError(err) != ErrNotFound || !m.now().Before(consistentAt) {
+			return data, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-m.after(min(interval, consistentAt.Sub(m.now()))):
+		}
+		interval = min(2*interval, maxVisibilityPollInterval)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// laggingMinioClient reports stored objects as not found for its first hiddenReads reads,
+// like an eventually consistent backend right after a write.
+type laggingMinioClient struct {
+	*FakeMinioClient
+	hiddenReads int
+	reads       int
+}
+
+func (c *laggingMinioClient) GetObject(ctx context.Context, bucketName, objectName string,
+	opts minio.GetObjectOptions,
+) (io.Reader, error) {
+	c.reads++
+	if c.reads <= c.hiddenReads {
+		return nil, newFakeNoSuchKeyError(objectName)
+	}
+	return c.FakeMinioClient.GetObject(ctx, bucketName, objectName, opts)
+}
+
+func newConsistentTestStore(t *testing.T, hiddenReads int) (*MinioObjectStore, *laggingMinioClient, *FakeClock) {
+	minioClient := &laggingMinioClient{FakeMinioClient: NewFakeMinioClient(), hiddenReads: hiddenReads}
+	clock := NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines", WithClock(clock))
+	require.Nil(t, store.AddFile(context.TODO(), []byte("content"), "pipelines/1"))
+	return store, minioClient, clock
+}
+
+func TestGetFileConsistent_RecentWriteRetriesNotFound(t *testing.T) {
+	store, minioClient, clock := newConsistentTestStore(t, 3)
+
+	data, err := store.GetFileConsistent(context.TODO(), "pipelines/1", clock.Now())
+
+	require.Nil(t, err)
+	assert.Equal(t, []byte("content"), data)
+	assert.Equal(t, 4, minioClient.reads)
+}
+
+func TestGetFileConsistent_RecentWriteGivesUpAfterWindow(t *testing.T) {
+	store, minioClient, clock := newConsistentTestStore(t, 1000)
+	store.SetReadAfterWriteTimeout(time.Second)
+	writtenAt := clock.Now()
+
+	_, err := store.GetFileConsistent(context.TODO(), "pipelines/1", writtenAt)
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+	assert.Greater(t, minioClient.reads, 1)
+	assert.Equal(t, writtenAt.Add(time.Second), clock.Now())
+}
+
+func TestGetFileConsistent_OldWriteFailsFast(t *testing.T) {
+	store, minioClient, clock := newConsistentTestStore(t, 1)
+	writtenAt := clock.Now().Add(-time.Hour)
+
+	_, err := store.GetFileConsistent(context.TODO(), "pipelines/1", writtenAt)
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+	assert.Equal(t, 1, minioClient.reads)
+}
+
+func TestGetFileConsistent_OtherErrorsNotRetried(t *testing.T) {
+	store, minioClient, clock := newConsistentTestStore(t, 0)
+	store.SetEnvironmentPrefix("pipelines", true)
+
+	_, err := store.GetFileConsistent(context.TODO(), "other/1", clock.Now())
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.FailedPrecondition, err.(*util.UserError).ExternalStatusCode())
+	assert.Equal(t, 0, minioClient.reads)
+}
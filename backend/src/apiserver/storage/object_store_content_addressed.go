@@ -0,0 +1,74 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// contentAddressedFolder is the folder, under the base folder, content addressed files
+	// are stored in, named after their SHA-256.
+	contentAddressedFolder = "content"
+	// contentRefPrefix prefixes the hex encoded SHA-256 of the content in references.
+	contentRefPrefix = "sha256:"
+)
+
+// AddFileContentAddressed stores file under a key derived from its SHA-256 and returns the
+// reference to retrieve it with GetByRef, "sha256:" followed by the hex encoded SHA-256.
+// The reference is an immutable handle on the content: storing identical content again
+// returns the same reference without writing it again.
+func (m *MinioObjectStore) AddFileContentAddressed(ctx context.Context, file []byte) (string, error) {
+	digest := contentSha256(file)
+	filePath := m.contentAddressedPath(digest)
+	_, err := m.GetFileInfo(ctx, filePath)
+	if err == nil {
+		return contentRefPrefix + digest, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return "", util.Wrap(err, "Failed to store content addressed file")
+	}
+	if err := m.AddFile(ctx, file, filePath); err != nil {
+		return "", util.Wrap(err, "Failed to store content addressed file")
+	}
+	return contentRefPrefix + digest, nil
+}
+
+// GetByRef returns the content stored by AddFileContentAddressed under ref. The content is
+// checked against its reference, so a corrupted object is never returned.
+func (m *MinioObjectStore) GetByRef(ctx context.Context, ref string) ([]byte, error) {
+	digest := strings.TrimPrefix(ref, contentRefPrefix)
+	if !strings.HasPrefix(ref, contentRefPrefix) || !isSha256Hex(digest) {
+		return nil, util.NewInvalidInputError("Invalid content reference %q: expected %v followed by a hex encoded SHA-256", ref, contentRefPrefix)
+	}
+	data, err := m.GetFile(ctx, m.contentAddressedPath(digest))
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to get content %v", ref)
+	}
+	if contentSha256(data) != digest {
+		return nil, util.NewInternalServerError(errors.New("content does not match its reference"),
+			"Failed to get content %v: the stored content is corrupted", ref)
+	}
+	return data, nil
+}
+
+func (m *MinioObjectStore) contentAddressedPath(digest string) string {
+	return path.Join(m.baseFolder, contentAddressedFolder, digest)
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestAddFileContentAddressed_Deduplicates(t *testing.T) {
+	minioClient := newCountingMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+
+	ref, err := manager.AddFileContentAddressed(context.TODO(), []byte("spec"))
+	require.Nil(t, err)
+	sameRef, err := manager.AddFileContentAddressed(context.TODO(), []byte("spec"))
+	require.Nil(t, err)
+	otherRef, err := manager.AddFileContentAddressed(context.TODO(), []byte("other spec"))
+	require.Nil(t, err)
+
+	assert.True(t, strings.HasPrefix(ref, "sha256:"))
+	assert.Equal(t, ref, sameRef)
+	assert.NotEqual(t, ref, otherRef)
+	assert.Equal(t, 2, minioClient.putCount)
+	assert.True(t, minioClient.ExistObject("pipelines/content/"+strings.TrimPrefix(ref, "sha256:")))
+}
+
+func TestGetByRef(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+	ref, err := manager.AddFileContentAddressed(context.TODO(), []byte("spec"))
+	require.Nil(t, err)
+
+	data, err := manager.GetByRef(context.TODO(), ref)
+
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+}
+
+func TestGetByRef_InvalidRef(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+
+	for _, ref := range []string{"", "sha256:", "sha256:abc", "md5:" + contentSha256([]byte("spec"))} {
+		_, err := manager.GetByRef(context.TODO(), ref)
+		require.NotNil(t, err, ref)
+		assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode(), ref)
+	}
+}
+
+func TestGetByRef_NotFound(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+
+	_, err := manager.GetByRef(context.TODO(), "sha256:"+contentSha256([]byte("spec")))
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestGetByRef_CorruptedContent(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+	digest := contentSha256([]byte("spec"))
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("tampered"), "pipelines/content/"+digest))
+
+	_, err := manager.GetByRef(context.TODO(), "sha256:"+digest)
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+}
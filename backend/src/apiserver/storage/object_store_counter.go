@@ -0,0 +1,107 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+const (
+	// maxCounterAttempts bounds the attempts of an increment losing the race to other writers.
+	maxCounterAttempts = 100
+	// maxCounterBackoff bounds the random wait before an increment is attempted again.
+	maxCounterBackoff = 10 * time.Millisecond
+)
+
+// IncrementCounter increments the counter stored at filePath, as decimal text, and returns
+// its new value. A missing counter starts from zero. The increment is a conditional put on
+// the ETag of the value read, attempted again whenever another writer got there first, so
+// concurrent increments never lose updates.
+func (m *MinioObjectStore) IncrementCounter(ctx context.Context, filePath string) (int64, error) {
+	if err := m.checkOpen("increment counter", filePath); err != nil {
+		return 0, err
+	}
+	if err := m.checkMaintenance("increment counter", filePath); err != nil {
+		return 0, err
+	}
+	if err := m.checkEnvironment(filePath, true); err != nil {
+		return 0, err
+	}
+	key := m.resolveKey(ctx, filePath)
+	if err := m.checkKeyLength("increment counter", filePath, key); err != nil {
+		return 0, err
+	}
+	if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationWrite, Path: filePath, Size: -1}); err != nil {
+		return 0, err
+	}
+	var err error
+	for attempt := 0; attempt < maxCounterAttempts; attempt++ {
+		var value int64
+		value, err = m.tryIncrementCounter(ctx, key, filePath)
+		if err == nil {
+			return value, nil
+		}
+		if ClassifyError(err) != ErrConflict {
+			return 0, err
+		}
+		select {
+		case <-ctx.Done():
+			return 0, util.NewUnavailableServerError(ctx.Err(), "Failed to increment counter %v", filePath)
+		case <-m.after(time.Duration(rand.Int63n(int64(maxCounterBackoff)))):
+		}
+	}
+	return 0, util.Wrapf(err, "Failed to increment counter %v after %d attempts", filePath, maxCounterAttempts)
+}
+
+// tryIncrementCounter increments the counter at key, failing with ErrConflict if it was
+// written by someone else in the meantime.
+func (m *MinioObjectStore) tryIncrementCounter(ctx context.Context, key string, filePath string) (int64, error) {
+	opts := m.putObjectOptions(ctx)
+	var value int64
+	// The ETag is read before the value, so a value newer than the ETag fails the put.
+	info, err := m.minioClient.StatObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+	switch {
+	case err == nil:
+		data, err := m.getFile(ctx, filePath)
+		if err != nil {
+			return 0, err
+		}
+		value, err = strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+		if err != nil {
+			return 0, util.NewFailedPreconditionError(err, "Failed to increment counter %v: invalid value %q", filePath, data)
+		}
+		opts.SetMatchETag(info.ETag)
+	case ClassifyError(err) == ErrNotFound:
+		// Only creates the counter if nobody else did.
+		opts.SetMatchETagExcept("*")
+	default:
+		return 0, newObjectStoreError(err, "Failed to stat counter %v", filePath)
+	}
+
+	value++
+	content := []byte(strconv.FormatInt(value, 10))
+	_, err = m.minioClient.PutObject(ctx, m.bucketName, key, bytes.NewReader(content), int64(len(content)), opts)
+	if err != nil {
+		return 0, newObjectStoreError(err, "Failed to store counter %v", filePath)
+	}
+	return value, nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestIncrementCounter(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+
+	for expected := int64(1); expected <= 3; expected++ {
+		value, err := manager.IncrementCounter(context.TODO(), "counters/versions")
+		require.Nil(t, err)
+		assert.Equal(t, expected, value)
+	}
+	data, err := manager.GetFile(context.TODO(), "counters/versions")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("3"), data)
+}
+
+func TestIncrementCounter_Concurrent(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+	const goroutines, increments = 8, 10
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	seen := make(map[int64]bool)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < increments; j++ {
+				value, err := manager.IncrementCounter(context.TODO(), "counters/versions")
+				if !assert.Nil(t, err) {
+					return
+				}
+				mutex.Lock()
+				assert.False(t, seen[value], "value %d returned twice", value)
+				seen[value] = true
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, seen, goroutines*increments)
+	data, err := manager.GetFile(context.TODO(), "counters/versions")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("80"), data)
+}
+
+func TestIncrementCounter_InvalidValue(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("not a number"), "counters/versions"))
+
+	_, err := manager.IncrementCounter(context.TODO(), "counters/versions")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.FailedPrecondition, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestIncrementCounterError(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipelines"}
+
+	_, err := manager.IncrementCounter(context.TODO(), "counters/versions")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+}
+
+// racingCounterClient stores the counter with the given value right after the first stat
+// reports it missing, as a concurrent first increment would.
+type racingCounterClient struct {
+	*FakeMinioClient
+	value string
+	raced bool
+}
+
+func (c *racingCounterClient) StatObject(ctx context.Context, bucketName, objectName string,
+	opts minio.StatObjectOptions,
+) (minio.ObjectInfo, error) {
+	info, err := c.FakeMinioClient.StatObject(ctx, bucketName, objectName, opts)
+	if err != nil && !c.raced {
+		c.raced = true
+		c.FakeMinioClient.PutObject(ctx, bucketName, objectName, strings.NewReader(c.value), int64(len(c.value)), minio.PutObjectOptions{})
+	}
+	return info, err
+}
+
+func TestIncrementCounter_CreatedConcurrently(t *testing.T) {
+	minioClient := &racingCounterClient{FakeMinioClient: NewFakeMinioClient(), value: "1"}
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+
+	value, err := manager.IncrementCounter(context.TODO(), "counters/versions")
+
+	require.Nil(t, err)
+	assert.True(t, minioClient.raced)
+	assert.Equal(t, int64(2), value)
+	data, err := manager.GetFile(context.TODO(), "counters/versions")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("2"), data)
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// EncryptionOptions are the encryption settings of the operations of a request.
+type EncryptionOptions struct {
+	// CustomerKey is the 32 byte key of objects encrypted with a customer provided key
+	// (SSE-C), managed outside of the apiserver. It is never logged.
+	CustomerKey []byte
+}
+
+// String redacts the customer key, so the options can be logged safely.
+func (o EncryptionOptions) String() string {
+	if len(o.CustomerKey) == 0 {
+		return "EncryptionOptions{}"
+	}
+	return "EncryptionOptions{CustomerKey: <redacted>}"
+}
+
+// GoString redacts the customer key when the options are printed with %#v.
+func (o EncryptionOptions) GoString() string {
+	return o.String()
+}
+
+type encryptionContextKey struct{}
+
+// WithEncryptionOptions applies the encryption options to the reads and writes using ctx,
+// taking precedence over the encryption of the store. Options without a customer key
+// leave ctx unchanged.
+func WithEncryptionOptions(ctx context.Context, opts EncryptionOptions) (context.Context, error) {
+	if len(opts.CustomerKey) == 0 {
+		return ctx, nil
+	}
+	encryption, err := encrypt.NewSSEC(opts.CustomerKey)
+	if err != nil {
+		// The error does not contain the key.
+		return ctx, util.NewInvalidInputError("Invalid customer provided encryption key: %v", err.Error())
+	}
+	return context.WithValue(ctx, encryptionContextKey{}, encryption), nil
+}
+
+// serverSideEncryption returns the encryption of the objects read and written using ctx.
+func (m *MinioObjectStore) serverSideEncryption(ctx context.Context) encrypt.ServerSide {
+	if encryption, ok := ctx.Value(encryptionContextKey{}).(encrypt.ServerSide); ok {
+		return encryption
+	}
+	return m.encryption
+}
+
+// customerKeyFingerprint identifies the customer provided key of the operations using ctx,
+// if any, without revealing it. It is the MD5 of the key sent along with the requests.
+func customerKeyFingerprint(ctx context.Context) string {
+	encryption, ok := ctx.Value(encryptionContextKey{}).(encrypt.ServerSide)
+	if !ok || encryption.Type() != encrypt.SSEC {
+		return ""
+	}
+	header := http.Header{}
+	encryption.Marshal(header)
+	return header.Get(encrypt.SseCustomerKeyMD5)
+}
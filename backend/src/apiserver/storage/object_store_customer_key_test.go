@@ -0,0 +1,107 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"k8s.io/client-go/tools/record"
+)
+
+var testCustomerKey = []byte("0123456789abcdef0123456789abcdef")
+
+const sseCustomerKeyHeader = "X-Amz-Server-Side-Encryption-Customer-Key"
+
+func withTestCustomerKey(t *testing.T, ctx context.Context) context.Context {
+	ctx, err := WithEncryptionOptions(ctx, EncryptionOptions{CustomerKey: testCustomerKey})
+	require.Nil(t, err)
+	return ctx
+}
+
+func TestCustomerKey_ReadAndWrite(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	ctx := withTestCustomerKey(t, context.TODO())
+	encodedKey := base64.StdEncoding.EncodeToString(testCustomerKey)
+	minioClient.EXPECT().
+		PutObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any(), gomock.Any(),
+			gomock.Cond(func(opts minio.PutObjectOptions) bool {
+				return opts.Header().Get(sseCustomerKeyHeader) == encodedKey
+			})).
+		Return(int64(4), nil)
+	minioClient.EXPECT().
+		GetObject(gomock.Any(), "mlpipeline", "pipelines/1",
+			gomock.Cond(func(opts minio.GetObjectOptions) bool {
+				return opts.Header().Get(sseCustomerKeyHeader) == encodedKey
+			})).
+		Return(io.Reader(bytes.NewReader([]byte("spec"))), nil)
+
+	require.Nil(t, manager.AddFile(ctx, []byte("spec"), manager.GetPipelineKey("1")))
+	data, err := manager.GetFile(ctx, manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+}
+
+func TestCustomerKey_OverridesStoreEncryption(t *testing.T) {
+	manager := &MinioObjectStore{}
+	assert.Nil(t, manager.getObjectOptions(context.TODO()).ServerSideEncryption)
+
+	opts := manager.getObjectOptions(withTestCustomerKey(t, context.TODO()))
+	assert.NotEmpty(t, opts.Header().Get(sseCustomerKeyHeader))
+	assert.NotNil(t, manager.copySourceEncryption(withTestCustomerKey(t, context.TODO())))
+}
+
+func TestCustomerKey_NotLogged(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	manager := &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipeline"}
+	manager.SetEventRecorder(recorder)
+	ctx := withTestCustomerKey(t, WithEventObject(context.TODO(), eventTestObject))
+	encodedKey := base64.StdEncoding.EncodeToString(testCustomerKey)
+
+	err := manager.AddFile(ctx, []byte("spec"), manager.GetPipelineKey("1"))
+	require.NotNil(t, err)
+	_, readErr := manager.GetFile(ctx, manager.GetPipelineKey("1"))
+	require.NotNil(t, readErr)
+	require.Len(t, recorder.Events, 2)
+	emitted := []string{err.Error(), fmt.Sprintf("%+v", err), readErr.Error(), <-recorder.Events, <-recorder.Events}
+	options := EncryptionOptions{CustomerKey: testCustomerKey}
+	emitted = append(emitted, fmt.Sprint(options), fmt.Sprintf("%+v", options), fmt.Sprintf("%#v", options))
+	for _, message := range emitted {
+		assert.NotContains(t, message, string(testCustomerKey))
+		assert.NotContains(t, message, encodedKey)
+	}
+}
+
+func TestWithEncryptionOptions_InvalidKey(t *testing.T) {
+	_, err := WithEncryptionOptions(context.TODO(), EncryptionOptions{CustomerKey: []byte("short key")})
+	require.NotNil(t, err)
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+	assert.NotContains(t, err.Error(), "short key")
+
+	ctx, err := WithEncryptionOptions(context.TODO(), EncryptionOptions{})
+	require.Nil(t, err)
+	assert.Equal(t, context.TODO(), ctx)
+}
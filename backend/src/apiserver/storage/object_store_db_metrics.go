@@ -0,0 +1,76 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"sigs.k8s.io/yaml"
+)
+
+// MetricsRecordingObjectStore counts the reads and writes of each file, and the bytes they
+// transfer, in the metrics table of the apiserver DB, for reporting. Only successful
+// operations are counted. Recording is best effort: failing to record an operation is
+// logged, and never fails the operation.
+type MetricsRecordingObjectStore struct {
+	ObjectStoreInterface
+	metricStore ObjectStoreMetricStoreInterface
+}
+
+func NewMetricsRecordingObjectStore(store ObjectStoreInterface, metricStore ObjectStoreMetricStoreInterface) *MetricsRecordingObjectStore {
+	return &MetricsRecordingObjectStore{ObjectStoreInterface: store, metricStore: metricStore}
+}
+
+func (r *MetricsRecordingObjectStore) AddFile(ctx context.Context, file []byte, filePath string) error {
+	if err := r.ObjectStoreInterface.AddFile(ctx, file, filePath); err != nil {
+		return err
+	}
+	r.record(&model.ObjectStoreMetric{FilePath: filePath, Writes: 1, BytesWritten: int64(len(file))})
+	return nil
+}
+
+func (r *MetricsRecordingObjectStore) AddAsYamlFile(ctx context.Context, o interface{}, filePath string) error {
+	bytes, err := yaml.Marshal(o)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to marshal file %v: %v", filePath, err.Error())
+	}
+	if err := r.AddFile(ctx, bytes, filePath); err != nil {
+		return util.Wrap(err, "Failed to add a yaml file")
+	}
+	return nil
+}
+
+func (r *MetricsRecordingObjectStore) GetFile(ctx context.Context, filePath string) ([]byte, error) {
+	bytes, err := r.ObjectStoreInterface.GetFile(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	r.record(&model.ObjectStoreMetric{FilePath: filePath, Reads: 1, BytesRead: int64(len(bytes))})
+	return bytes, nil
+}
+
+func (r *MetricsRecordingObjectStore) GetFromYamlFile(ctx context.Context, o interface{}, filePath string) error {
+	bytes, err := r.GetFile(ctx, filePath)
+	return unmarshalYamlFile(ctx, bytes, err, o, filePath)
+}
+
+func (r *MetricsRecordingObjectStore) record(delta *model.ObjectStoreMetric) {
+	if err := r.metricStore.AddObjectStoreMetric(delta); err != nil {
+		glog.Warningf("Failed to record the object store metrics of file %v: %v", delta.FilePath, err)
+	}
+}
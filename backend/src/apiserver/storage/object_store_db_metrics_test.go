@@ -0,0 +1,67 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsRecordingObjectStore(t *testing.T) {
+	db := NewFakeDBOrFatal()
+	defer db.Close()
+	metricStore := NewObjectStoreMetricStore(db)
+	store := NewMetricsRecordingObjectStore(NewFakeObjectStore(), metricStore)
+	ctx := context.TODO()
+
+	require.Nil(t, store.AddFile(ctx, []byte("spec"), "pipelines/1"))
+	require.Nil(t, store.AddAsYamlFile(ctx, map[string]string{"name": "spec"}, "pipelines/1"))
+	_, err := store.GetFile(ctx, "pipelines/1")
+	require.Nil(t, err)
+	var spec map[string]string
+	require.Nil(t, store.GetFromYamlFile(ctx, &spec, "pipelines/1"))
+	// Failed operations are not counted.
+	_, err = store.GetFile(ctx, "pipelines/missing")
+	require.NotNil(t, err)
+
+	metric, err := metricStore.GetObjectStoreMetric("pipelines/1")
+	require.Nil(t, err)
+	yamlSize := int64(len("name: spec\n"))
+	assert.Equal(t, &model.ObjectStoreMetric{
+		FilePath:     "pipelines/1",
+		Reads:        2,
+		Writes:       2,
+		BytesRead:    2 * yamlSize,
+		BytesWritten: int64(len("spec")) + yamlSize,
+	}, metric)
+	metric, err = metricStore.GetObjectStoreMetric("pipelines/missing")
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), metric.Reads)
+}
+
+func TestMetricsRecordingObjectStore_BestEffort(t *testing.T) {
+	db := NewFakeDBOrFatal()
+	store := NewMetricsRecordingObjectStore(NewFakeObjectStore(), NewObjectStoreMetricStore(db))
+	db.Close()
+
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+	data, err := store.GetFile(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// SetOperationTimeout bounds each operation of the batch helpers, e.g. AddFiles, to
+// timeout. Zero, the default, does not bound them. Either way, the operations of a batch
+// share the deadline of its context: an operation only gets what is left of the budget of
+// the batch, and the remaining operations are abandoned once it is exhausted.
+func (m *MinioObjectStore) SetOperationTimeout(timeout time.Duration) {
+	m.operationTimeout = timeout
+}
+
+// batchOperationContext returns the context to run the next operation of a batch with,
+// whose deadline is the earliest of the deadline of the batch and the operation timeout.
+// It fails if the budget of the batch is exhausted.
+func (m *MinioObjectStore) batchOperationContext(ctx context.Context, operation string, filePath string) (context.Context, context.CancelFunc, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, util.NewUnavailableServerError(err,
+			"Failed to %v %v: the deadline of the batch was exceeded", operation, filePath)
+	}
+	if m.operationTimeout <= 0 {
+		ctx, cancel := context.WithCancel(ctx)
+		return ctx, cancel, nil
+	}
+	// The child context never outlives ctx, so the timeout is capped to the remaining budget.
+	ctx, cancel := context.WithTimeout(ctx, m.operationTimeout)
+	return ctx, cancel, nil
+}
+
+// bytesPerMegabyte is the unit the allowance of size based deadlines is given for.
+const bytesPerMegabyte = 1 << 20
+
+// SetSizeDeadline bounds the transfer of each file stored with AddFile or read with
+// GetFile to base plus perMegabyte for every started megabyte of the file, so small specs
+// fail fast while large artifacts get the time they need. Calls whose context already has
+// a deadline keep it. Reads stat the file first to learn its size. A zero base, the
+// default, does not bound the transfers.
+func (m *MinioObjectStore) SetSizeDeadline(base time.Duration, perMegabyte time.Duration) {
+	m.sizeDeadlineBase = base
+	m.sizeDeadlinePerMegabyte = perMegabyte
+}
+
+// sizeDeadline returns how long the transfer of size bytes may take.
+func (m *MinioObjectStore) sizeDeadline(size int64) time.Duration {
+	megabytes := (max(size, 0) + bytesPerMegabyte - 1) / bytesPerMegabyte
+	return m.sizeDeadlineBase + time.Duration(megabytes)*m.sizeDeadlinePerMegabyte
+}
+
+// sizeDeadlineContext returns the context to transfer size bytes with, bounded by the size
+// based deadline unless ctx already has a deadline.
+func (m *MinioObjectStore) sizeDeadlineContext(ctx context.Context, size int64) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || m.sizeDeadlineBase <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, m.sizeDeadline(size))
+}
+
+// readSizeDeadlineContext is sizeDeadlineContext for reading the file at filePath. If the
+// file cannot be stat-ed, the read is not bounded and reports the failure.
+func (m *MinioObjectStore) readSizeDeadlineContext(ctx context.Context, filePath string) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || m.sizeDeadlineBase <= 0 {
+		return ctx, func() {}
+	}
+	info, err := m.minioClient.StatObject(ctx, m.bucketName, m.resolveKey(ctx, filePath), m.getObjectOptions(ctx))
+	if err != nil {
+		return ctx, func() {}
+	}
+	return m.sizeDeadlineContext(ctx, info.Size)
+}
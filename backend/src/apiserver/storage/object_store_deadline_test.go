@@ -0,0 +1,196 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// deadlineMinioClient takes delay to store each object, unless its context is done first.
+type deadlineMinioClient struct {
+	*FakeMinioClient
+	delay    time.Duration
+	putCount int32
+}
+
+func (c *deadlineMinioClient) PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader,
+	objectSize int64, opts minio.PutObjectOptions,
+) (int64, error) {
+	atomic.AddInt32(&c.putCount, 1)
+	select {
+	case <-time.After(c.delay):
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+	return c.FakeMinioClient.PutObject(ctx, bucketName, objectName, reader, objectSize, opts)
+}
+
+func newBatchFiles(count int) map[string][]byte {
+	files := make(map[string][]byte)
+	for i := 0; i < count; i++ {
+		files[string(rune('a'+i))] = []byte("spec")
+	}
+	return files
+}
+
+func TestAddFiles_DeadlineAbortsRemainingFiles(t *testing.T) {
+	minioClient := &deadlineMinioClient{FakeMinioClient: NewFakeMinioClient(), delay: 20 * time.Millisecond}
+	manager := &MinioObjectStore{minioClient: minioClient, bucketName: "", baseFolder: "pipeline"}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := manager.AddFiles(ctx, "batch", newBatchFiles(10))
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+	// The budget only fits a couple of writes, the remaining files are not attempted.
+	assert.LessOrEqual(t, int(atomic.LoadInt32(&minioClient.putCount)), 3)
+	assert.Less(t, minioClient.GetObjectCount(), 10)
+}
+
+func TestAddFiles_OperationTimeout(t *testing.T) {
+	minioClient := &deadlineMinioClient{FakeMinioClient: NewFakeMinioClient(), delay: time.Minute}
+	manager := NewMinioObjectStoreWithOptions(minioClient, "", "pipeline", WithOperationTimeout(10*time.Millisecond))
+
+	start := time.Now()
+	err := manager.AddFiles(context.Background(), "batch", newBatchFiles(2))
+
+	require.NotNil(t, err)
+	assert.Less(t, time.Since(start), time.Minute)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&minioClient.putCount))
+}
+
+func TestAddFiles_WithinDeadline(t *testing.T) {
+	minioClient := &deadlineMinioClient{FakeMinioClient: NewFakeMinioClient(), delay: time.Millisecond}
+	manager := NewMinioObjectStoreWithOptions(minioClient, "", "pipeline", WithOperationTimeout(time.Minute))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	assert.Nil(t, manager.AddFiles(ctx, "batch", newBatchFiles(3)))
+	assert.Equal(t, 3, minioClient.GetObjectCount())
+}
+
+func TestBatchOperationContext_CapsTimeoutToBudget(t *testing.T) {
+	manager := &MinioObjectStore{operationTimeout: time.Hour}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	opCtx, opCancel, err := manager.batchOperationContext(ctx, "store file", "a")
+	require.Nil(t, err)
+	defer opCancel()
+	batchDeadline, _ := ctx.Deadline()
+	opDeadline, ok := opCtx.Deadline()
+	assert.True(t, ok)
+	assert.False(t, opDeadline.After(batchDeadline))
+}
+
+// deadlineRecordingMinioClient records the time left before the deadline of the contexts
+// objects are stored and read with, or zero if they have none.
+type deadlineRecordingMinioClient struct {
+	*FakeMinioClient
+	putTimeLeft time.Duration
+	getTimeLeft time.Duration
+}
+
+func timeLeft(ctx context.Context) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline)
+	}
+	return 0
+}
+
+func (c *deadlineRecordingMinioClient) PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader,
+	objectSize int64, opts minio.PutObjectOptions,
+) (int64, error) {
+	c.putTimeLeft = timeLeft(ctx)
+	return c.FakeMinioClient.PutObject(ctx, bucketName, objectName, reader, objectSize, opts)
+}
+
+func (c *deadlineRecordingMinioClient) GetObject(ctx context.Context, bucketName, objectName string,
+	opts minio.GetObjectOptions,
+) (io.Reader, error) {
+	c.getTimeLeft = timeLeft(ctx)
+	return c.FakeMinioClient.GetObject(ctx, bucketName, objectName, opts)
+}
+
+func newSizeDeadlineTestStore() (*MinioObjectStore, *deadlineRecordingMinioClient) {
+	minioClient := &deadlineRecordingMinioClient{FakeMinioClient: NewFakeMinioClient()}
+	store := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines",
+		WithSizeDeadline(time.Minute, time.Hour))
+	return store, minioClient
+}
+
+func TestSizeDeadline(t *testing.T) {
+	store, _ := newSizeDeadlineTestStore()
+
+	assert.Equal(t, time.Minute, store.sizeDeadline(0))
+	assert.Equal(t, time.Minute+time.Hour, store.sizeDeadline(1))
+	assert.Equal(t, time.Minute+time.Hour, store.sizeDeadline(bytesPerMegabyte))
+	assert.Equal(t, time.Minute+2*time.Hour, store.sizeDeadline(bytesPerMegabyte+1))
+}
+
+func TestSizeDeadline_LargeFilesGetLongerDeadlines(t *testing.T) {
+	store, minioClient := newSizeDeadlineTestStore()
+
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/small"))
+	smallPut := minioClient.putTimeLeft
+	require.Nil(t, store.AddFile(context.TODO(), make([]byte, 3*bytesPerMegabyte), "pipelines/large"))
+	largePut := minioClient.putTimeLeft
+
+	assert.InDelta(t, float64(time.Minute+time.Hour), float64(smallPut), float64(time.Second))
+	assert.InDelta(t, float64(time.Minute+3*time.Hour), float64(largePut), float64(time.Second))
+
+	_, err := store.GetFile(context.TODO(), "pipelines/small")
+	require.Nil(t, err)
+	smallGet := minioClient.getTimeLeft
+	_, err = store.GetFile(context.TODO(), "pipelines/large")
+	require.Nil(t, err)
+	largeGet := minioClient.getTimeLeft
+
+	assert.InDelta(t, float64(time.Minute+time.Hour), float64(smallGet), float64(time.Second))
+	assert.InDelta(t, float64(time.Minute+3*time.Hour), float64(largeGet), float64(time.Second))
+}
+
+func TestSizeDeadline_ExplicitDeadlineKept(t *testing.T) {
+	store, minioClient := newSizeDeadlineTestStore()
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	require.Nil(t, store.AddFile(ctx, make([]byte, 3*bytesPerMegabyte), "pipelines/large"))
+
+	assert.LessOrEqual(t, minioClient.putTimeLeft, time.Second)
+}
+
+func TestSizeDeadline_Disabled(t *testing.T) {
+	minioClient := &deadlineRecordingMinioClient{FakeMinioClient: NewFakeMinioClient()}
+	store := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines")
+
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+	_, err := store.GetFile(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+
+	assert.Zero(t, minioClient.putTimeLeft)
+	assert.Zero(t, minioClient.getTimeLeft)
+}
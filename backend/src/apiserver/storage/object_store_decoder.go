@@ -0,0 +1,96 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"io"
+	"unicode/utf8"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// gzipMagic starts the content of every gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// ContentDecoder decodes the files stored in one of the historical encodings of specs. It
+// returns the decoded content of the file and true if it recognizes its encoding, from the
+// attributes of the stored object or from data itself. Otherwise it returns false, leaving
+// data to the next decoders.
+type ContentDecoder func(info *FileInfo, data []byte) ([]byte, bool, error)
+
+// GzipContentDecoder decompresses gzipped files, recognized by their magic bytes.
+func GzipContentDecoder(info *FileInfo, data []byte) ([]byte, bool, error) {
+	if !bytes.HasPrefix(data, gzipMagic) {
+		return nil, false, nil
+	}
+	gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, true, err
+	}
+	defer gzipReader.Close()
+	decoded, err := io.ReadAll(gzipReader)
+	return decoded, true, err
+}
+
+// Base64ContentDecoder decodes the files wrapped in base64 by the legacy export, recognized
+// by their content being standard base64, possibly broken into lines, of text or gzip.
+// YAML specs are not valid base64, since their keys are followed by colons.
+func Base64ContentDecoder(info *FileInfo, data []byte) ([]byte, bool, error) {
+	encoded := bytes.Join(bytes.Fields(data), nil)
+	if len(encoded) == 0 {
+		return nil, false, nil
+	}
+	decoded := make([]byte, base64.StdEncoding.DecodedLen(len(encoded)))
+	n, err := base64.StdEncoding.Decode(decoded, encoded)
+	if err != nil {
+		return nil, false, nil
+	}
+	decoded = decoded[:n]
+	if !utf8.Valid(decoded) && !bytes.HasPrefix(decoded, gzipMagic) {
+		return nil, false, nil
+	}
+	return decoded, true, nil
+}
+
+// RegisterContentDecoder appends decoder to the decoders GetFile passes the content of the
+// files through. The decoders are consulted in the order they were registered, each
+// decoding the output of the previous ones, so those of the outer encodings go first, e.g.
+// Base64ContentDecoder before GzipContentDecoder for gzipped files wrapped in base64.
+// Content no decoder recognizes is returned as stored.
+func (m *MinioObjectStore) RegisterContentDecoder(decoder ContentDecoder) {
+	m.contentDecoders = append(m.contentDecoders, decoder)
+}
+
+// getDecodedFile returns the content of the file, passed through the registered decoders.
+func (m *MinioObjectStore) getDecodedFile(ctx context.Context, filePath string) ([]byte, error) {
+	data, info, err := m.getFileWithInfo(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	for _, decoder := range m.contentDecoders {
+		decoded, ok, err := decoder(info, data)
+		if err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to decode file %v", filePath)
+		}
+		if ok {
+			data = decoded
+		}
+	}
+	return data, nil
+}
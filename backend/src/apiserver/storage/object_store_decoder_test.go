@@ -0,0 +1,125 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+const decoderTestSpec = "pipelineInfo:\n  name: hello-world\n"
+
+func newDecodingStore(decoders ...ContentDecoder) *MinioObjectStore {
+	return NewMinioObjectStoreWithOptions(NewFakeMinioClient(), "mlpipeline", "pipelines",
+		WithContentDecoders(decoders...))
+}
+
+func TestGetFile_ContentDecoders(t *testing.T) {
+	store := newDecodingStore(Base64ContentDecoder, GzipContentDecoder)
+	gzipped := gzipBytes(t, []byte(decoderTestSpec))
+	files := map[string][]byte{
+		"pipelines/plain":         []byte(decoderTestSpec),
+		"pipelines/gzipped":       gzipped,
+		"pipelines/base64":        []byte(base64.StdEncoding.EncodeToString([]byte(decoderTestSpec))),
+		"pipelines/base64-gzip":   []byte(base64.StdEncoding.EncodeToString(gzipped)),
+		"pipelines/base64-folded": []byte("cGlwZWxpbmVJbmZvOgogIG5h\nbWU6IGhlbGxvLXdvcmxkCg==\n"),
+	}
+	for filePath, data := range files {
+		require.Nil(t, store.AddFile(context.TODO(), data, filePath))
+	}
+
+	for filePath := range files {
+		data, err := store.GetFile(context.TODO(), filePath)
+		require.Nil(t, err, filePath)
+		assert.Equal(t, decoderTestSpec, string(data), filePath)
+	}
+}
+
+func TestGetFile_ContentDecodersPassThroughUnrecognizedContent(t *testing.T) {
+	store := newDecodingStore(Base64ContentDecoder, GzipContentDecoder)
+	for filePath, data := range map[string][]byte{
+		"pipelines/yaml":   []byte(decoderTestSpec),
+		"pipelines/json":   []byte(`{"pipelineInfo": {"name": "hello-world"}}`),
+		"pipelines/binary": {0x00, 0xff, 0xfe, 0x10},
+		"pipelines/empty":  {},
+	} {
+		require.Nil(t, store.AddFile(context.TODO(), data, filePath))
+		stored, err := store.GetFile(context.TODO(), filePath)
+		require.Nil(t, err, filePath)
+		assert.Equal(t, data, stored, filePath)
+	}
+}
+
+func TestGetFile_ContentDecoderByMetadata(t *testing.T) {
+	var seen *FileInfo
+	reverse := ContentDecoder(func(info *FileInfo, data []byte) ([]byte, bool, error) {
+		if info.ContentType != "application/x-reversed" {
+			return nil, false, nil
+		}
+		seen = info
+		decoded := make([]byte, len(data))
+		for i, b := range data {
+			decoded[len(data)-1-i] = b
+		}
+		return decoded, true, nil
+	})
+	minioClient := NewFakeMinioClient()
+	store := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines")
+	store.RegisterContentDecoder(reverse)
+	store.RegisterContentDecoder(GzipContentDecoder)
+	_, err := minioClient.PutObject(context.TODO(), "mlpipeline", "pipelines/reversed", bytes.NewReader([]byte("cba")), 3,
+		minio.PutObjectOptions{ContentType: "application/x-reversed"})
+	require.Nil(t, err)
+	require.Nil(t, store.AddFile(context.TODO(), []byte("cba"), "pipelines/plain"))
+
+	data, err := store.GetFile(context.TODO(), "pipelines/reversed")
+	require.Nil(t, err)
+	assert.Equal(t, "abc", string(data))
+	require.NotNil(t, seen)
+	assert.Equal(t, "pipelines/reversed", seen.Key)
+	assert.Equal(t, int64(3), seen.Size)
+
+	data, err = store.GetFile(context.TODO(), "pipelines/plain")
+	require.Nil(t, err)
+	assert.Equal(t, "cba", string(data))
+}
+
+func TestGetFile_ContentDecoderFailure(t *testing.T) {
+	store := newDecodingStore(GzipContentDecoder)
+	require.Nil(t, store.AddFile(context.TODO(), []byte{0x1f, 0x8b, 0x00}, "pipelines/truncated"))
+
+	_, err := store.GetFile(context.TODO(), "pipelines/truncated")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.Error(), "Failed to decode file pipelines/truncated")
+}
+
+func TestGetFile_NoContentDecoders(t *testing.T) {
+	store := newDecodingStore()
+	gzipped := gzipBytes(t, []byte(decoderTestSpec))
+	require.Nil(t, store.AddFile(context.TODO(), gzipped, "pipelines/gzipped"))
+
+	data, err := store.GetFile(context.TODO(), "pipelines/gzipped")
+	require.Nil(t, err)
+	assert.Equal(t, gzipped, data)
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+type yamlDefaultContextKey struct{}
+
+// WithYamlDefault makes GetFromYamlFile calls using ctx return defaultValue, instead of a
+// NotFound error, when the file does not exist. Any other failure is still returned.
+func WithYamlDefault(ctx context.Context, defaultValue interface{}) context.Context {
+	return context.WithValue(ctx, yamlDefaultContextKey{}, defaultValue)
+}
+
+func yamlDefaultFromContext(ctx context.Context) (interface{}, bool) {
+	defaultValue := ctx.Value(yamlDefaultContextKey{})
+	return defaultValue, defaultValue != nil
+}
+
+// unmarshalYamlFile unmarshals the content read from filePath into o. When the read failed
+// because the file does not exist, the default value set on ctx is used instead, if any.
+func unmarshalYamlFile(ctx context.Context, bytes []byte, readErr error, o interface{}, filePath string) error {
+	if readErr != nil {
+		defaultValue, ok := yamlDefaultFromContext(ctx)
+		if !ok || !errors.Is(readErr, ErrNotFound) {
+			return util.Wrap(readErr, "Failed to read from a yaml file")
+		}
+		var err error
+		bytes, err = yaml.Marshal(defaultValue)
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to marshal the default value of file %v: %v", filePath, err.Error())
+		}
+	}
+	err := yaml.Unmarshal(bytes, o)
+	if err != nil {
+		if typeErr := findYamlTypeError(bytes, o); typeErr != nil {
+			return util.NewInternalServerError(err, "Failed to unmarshal file %v: field %v has type %v, expected %v",
+				filePath, typeErr.Field, typeErr.Value, typeErr.Type)
+		}
+		return util.NewInternalServerError(err, "Failed to unmarshal file %v: %v", filePath, err.Error())
+	}
+	return nil
+}
+
+// findYamlTypeError returns the first field of content holding a value of the wrong type
+// for o, with the path of the field. The YAML unmarshaller only reports a flattened
+// message, so this decodes content again to find it.
+func findYamlTypeError(content []byte, o interface{}) *json.UnmarshalTypeError {
+	target := reflect.TypeOf(o)
+	if target == nil || target.Kind() != reflect.Pointer {
+		return nil
+	}
+	jsonContent, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return nil
+	}
+	var typeErr *json.UnmarshalTypeError
+	err = json.Unmarshal(jsonContent, reflect.New(target.Elem()).Interface())
+	// The YAML unmarshaller turns scalars into the strings expected, so these are no errors.
+	if !errors.As(err, &typeErr) || typeErr.Type.Kind() == reflect.String || typeErr.Field == "" {
+		return nil
+	}
+	return typeErr
+}
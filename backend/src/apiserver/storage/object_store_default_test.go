@@ -0,0 +1,109 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+type defaultSpec struct {
+	Name  string `json:"name"`
+	Tasks int    `json:"tasks"`
+}
+
+func TestGetFromYamlFile_DefaultIgnoredWhenFound(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	require.Nil(t, manager.AddAsYamlFile(context.TODO(), defaultSpec{Name: "stored", Tasks: 3}, "pipeline/1"))
+
+	var spec defaultSpec
+	err := manager.GetFromYamlFile(WithYamlDefault(context.TODO(), defaultSpec{Name: "default"}), &spec, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, defaultSpec{Name: "stored", Tasks: 3}, spec)
+}
+
+func TestGetFromYamlFile_DefaultReturnedWhenNotFound(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+
+	var spec defaultSpec
+	err := manager.GetFromYamlFile(WithYamlDefault(context.TODO(), defaultSpec{Name: "default"}), &spec, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, defaultSpec{Name: "default"}, spec)
+}
+
+func TestGetFromYamlFile_NotFoundWithoutDefault(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+
+	var spec defaultSpec
+	err := manager.GetFromYamlFile(context.TODO(), &spec, "pipeline/1")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestGetFromYamlFile_DefaultDoesNotHideOtherErrors(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipeline"}
+
+	var spec defaultSpec
+	err := manager.GetFromYamlFile(WithYamlDefault(context.TODO(), defaultSpec{Name: "default"}), &spec, "pipeline/1")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+	assert.Equal(t, defaultSpec{}, spec)
+}
+
+func TestGetFromYamlFile_DefaultThroughCache(t *testing.T) {
+	store := NewCachingObjectStore(&MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}, 10)
+
+	var spec defaultSpec
+	err := store.GetFromYamlFile(WithYamlDefault(context.TODO(), defaultSpec{Name: "default"}), &spec, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, defaultSpec{Name: "default"}, spec)
+}
+
+type typedSpec struct {
+	Root struct {
+		Components []struct {
+			Name    string `json:"name"`
+			Retries int    `json:"retries"`
+		} `json:"components"`
+	} `json:"root"`
+}
+
+func TestGetFromYamlFile_TypeMismatchNamesField(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	spec := "root:\n  components:\n  - name: train\n    retries: three\n"
+	require.Nil(t, manager.AddFile(context.TODO(), []byte(spec), "pipeline/1"))
+
+	var typed typedSpec
+	err := manager.GetFromYamlFile(context.TODO(), &typed, "pipeline/1")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+	// Newer encoding/json versions also report the index of the component.
+	assert.Regexp(t, `field root\.components\.(0\.)?retries has type string, expected int`, err.Error())
+}
+
+func TestGetFromYamlFile_InvalidYamlKeepsMessage(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("root: [unclosed"), "pipeline/1"))
+
+	var typed typedSpec
+	err := manager.GetFromYamlFile(context.TODO(), &typed, "pipeline/1")
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Failed to unmarshal file pipeline/1: error converting YAML to JSON")
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+// ConfigSummary is a snapshot of the effective settings of a store, fit to be served by a
+// debug endpoint. It never holds credentials or encryption keys: the endpoint is stripped
+// of any user info, and only the kind of encryption is reported.
+type ConfigSummary struct {
+	Endpoint              string            `json:"endpoint,omitempty"`
+	Bucket                string            `json:"bucket"`
+	BaseFolder            string            `json:"base_folder"`
+	Multipart             bool              `json:"multipart"`
+	PartSize              uint64            `json:"part_size,omitempty"`
+	RetryMaxAttempts      int               `json:"retry_max_attempts"`
+	RetryBackoff          string            `json:"retry_backoff"`
+	RetryMaxRetryAfter    string            `json:"retry_max_retry_after"`
+	Encryption            string            `json:"encryption,omitempty"`
+	UserAgent             string            `json:"user_agent,omitempty"`
+	EnvironmentPrefix     string            `json:"environment_prefix,omitempty"`
+	PrefixRewrites        map[string]string `json:"prefix_rewrites,omitempty"`
+	MaxKeyLength          int               `json:"max_key_length,omitempty"`
+	MinYamlFileSize       int               `json:"min_yaml_file_size,omitempty"`
+	MaxInFlightBytes      int64             `json:"max_in_flight_bytes,omitempty"`
+	ReadAfterWriteTimeout string            `json:"read_after_write_timeout"`
+	OperationTimeout      string            `json:"operation_timeout"`
+	// Features tells which optional behaviors of the store are enabled, by name.
+	Features map[string]bool `json:"features"`
+}
+
+// DescribeConfig returns a redacted snapshot of the effective settings of the store.
+func (m *MinioObjectStore) DescribeConfig() ConfigSummary {
+	config := m.Config()
+	summary := ConfigSummary{
+		Bucket:                config.BucketName,
+		BaseFolder:            config.BaseFolder,
+		Multipart:             !config.DisableMultipart,
+		PartSize:              config.PartSize,
+		RetryMaxAttempts:      config.Retry.MaxAttempts,
+		RetryBackoff:          config.Retry.Backoff.String(),
+		RetryMaxRetryAfter:    config.Retry.MaxRetryAfter.String(),
+		UserAgent:             config.UserAgent,
+		EnvironmentPrefix:     m.environmentPrefix,
+		MaxKeyLength:          config.MaxKeyLength,
+		MinYamlFileSize:       config.MinYamlFileSize,
+		MaxInFlightBytes:      config.MaxInFlightBytes,
+		ReadAfterWriteTimeout: config.ReadAfterWriteTimeout.String(),
+		OperationTimeout:      config.OperationTimeout.String(),
+		Features: map[string]bool{
+			"soft_delete":              config.SoftDelete,
+			"validate_yaml":            config.ValidateYaml,
+			"verify_uploads":           config.VerifyUploads,
+			"batch_manifest":           config.BatchManifest,
+			"key_namespacing":          config.KeyNamespacer != nil,
+			"key_normalization":        !config.DisableKeyNormalization,
+			"key_sharding":             config.KeySharding,
+			"key_transform":            config.KeyTransform != nil,
+			"gzip_variants":            config.GzipVariants,
+			"access_tracking":          config.AccessTrackingInterval > 0,
+			"content_decoders":         len(config.ContentDecoders) > 0,
+			"audit":                    config.AuditHook != nil,
+			"audit_fail_closed":        config.AuditFailClosed,
+			"reject_small_yaml_files":  config.RejectSmallYamlFiles,
+			"restrict_reads_to_prefix": m.restrictReadsToEnvironment,
+			"maintenance":              m.maintenance.Load(),
+			"closed":                   m.closed.Load(),
+		},
+	}
+	if client, ok := m.minioClient.(*MinioClient); ok && client.Client != nil {
+		endpoint := client.Client.EndpointURL()
+		endpoint.User = nil
+		summary.Endpoint = endpoint.String()
+	}
+	if config.Encryption != nil {
+		summary.Encryption = string(config.Encryption.Type())
+	}
+	if len(m.prefixRewrites) > 0 {
+		summary.PrefixRewrites = make(map[string]string, len(m.prefixRewrites))
+		for _, rewrite := range m.prefixRewrites {
+			summary.PrefixRewrites[rewrite.From] = rewrite.To
+		}
+	}
+	return summary
+}
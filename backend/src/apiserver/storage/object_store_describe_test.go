@@ -0,0 +1,100 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	describeTestAccessKey = "AKIADESCRIBETEST"
+	describeTestSecretKey = "describe-test-secret-key"
+)
+
+func TestDescribeConfig(t *testing.T) {
+	client, err := minio.New("minio.example:9000", &minio.Options{
+		Creds:  credentials.NewStaticV4(describeTestAccessKey, describeTestSecretKey, "describe-test-session-token"),
+		Secure: true,
+	})
+	require.Nil(t, err)
+	customerKey := bytes.Repeat([]byte("k"), 32)
+	encryption, err := encrypt.NewSSEC(customerKey)
+	require.Nil(t, err)
+	store := NewMinioObjectStoreWithOptions(&MinioClient{Client: client}, "mlpipeline", "pipelines",
+		WithPartSize(32<<20),
+		WithRetry(RetryPolicy{MaxAttempts: 3, Backoff: time.Second}),
+		WithEncryption(encryption),
+		WithSoftDelete(true),
+		WithKeySharding(true),
+		WithUserAgent("kfp-apiserver/2.5.0"),
+	)
+	store.SetPrefixRewrites([]PrefixRewrite{{From: "old/", To: "new/"}})
+
+	summary := store.DescribeConfig()
+
+	assert.Equal(t, "https://minio.example:9000", summary.Endpoint)
+	assert.Equal(t, "mlpipeline", summary.Bucket)
+	assert.Equal(t, "pipelines", summary.BaseFolder)
+	assert.True(t, summary.Multipart)
+	assert.Equal(t, uint64(32<<20), summary.PartSize)
+	assert.Equal(t, 3, summary.RetryMaxAttempts)
+	assert.Equal(t, "1s", summary.RetryBackoff)
+	assert.Equal(t, "SSE-C", summary.Encryption)
+	assert.Equal(t, "kfp-apiserver/2.5.0", summary.UserAgent)
+	assert.Equal(t, map[string]string{"old/": "new/"}, summary.PrefixRewrites)
+	assert.True(t, summary.Features["soft_delete"])
+	assert.True(t, summary.Features["key_sharding"])
+	assert.False(t, summary.Features["gzip_variants"])
+
+	serialized, err := json.Marshal(summary)
+	require.Nil(t, err)
+	for _, secret := range []string{
+		describeTestAccessKey, describeTestSecretKey, "describe-test-session-token",
+		string(customerKey), base64.StdEncoding.EncodeToString(customerKey),
+	} {
+		assert.NotContains(t, string(serialized), secret)
+	}
+}
+
+func TestDescribeConfig_EndpointUserInfoRedacted(t *testing.T) {
+	client, err := minio.New("minio.example:9000", &minio.Options{
+		Creds: credentials.NewStaticV4(describeTestAccessKey, describeTestSecretKey, ""),
+	})
+	require.Nil(t, err)
+	store := NewMinioObjectStoreWithOptions(&MinioClient{Client: client}, "mlpipeline", "pipelines")
+
+	assert.Equal(t, "http://minio.example:9000", store.DescribeConfig().Endpoint)
+}
+
+func TestDescribeConfig_FakeClient(t *testing.T) {
+	store := NewMinioObjectStoreWithOptions(NewFakeMinioClient(), "mlpipeline", "pipelines", WithDisableMultipart(true))
+
+	summary := store.DescribeConfig()
+
+	assert.Empty(t, summary.Endpoint)
+	assert.Empty(t, summary.Encryption)
+	assert.False(t, summary.Multipart)
+	assert.True(t, summary.Features["key_normalization"])
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// DeltaOperation is the kind of change of a field between two specs.
+type DeltaOperation string
+
+const (
+	DeltaAdded   DeltaOperation = "added"
+	DeltaRemoved DeltaOperation = "removed"
+	DeltaChanged DeltaOperation = "changed"
+)
+
+// DeltaEntry is a field changed between two specs. Path is a selector like the ones
+// GetYamlField accepts, e.g. "$.spec.tasks[0].name", and "$" for the whole spec. Old is
+// nil for added fields and New is nil for removed fields.
+type DeltaEntry struct {
+	Path      string
+	Operation DeltaOperation
+	Old       interface{}
+	New       interface{}
+}
+
+// Delta is the field level difference between two specs, in path order.
+type Delta struct {
+	Entries []DeltaEntry
+}
+
+// Empty returns whether the specs are the same.
+func (d Delta) Empty() bool {
+	return len(d.Entries) == 0
+}
+
+// DiffYamlFiles returns the fields added, removed and changed from the yaml file at pathA
+// to the one at pathB. A missing file is diffed as a whole spec added or removed; both
+// missing is a NotFound error. Maps are diffed key by key and lists item by item.
+func (m *MinioObjectStore) DiffYamlFiles(ctx context.Context, pathA string, pathB string) (Delta, error) {
+	a, foundA, err := m.getYamlValue(ctx, pathA)
+	if err != nil {
+		return Delta{}, err
+	}
+	b, foundB, err := m.getYamlValue(ctx, pathB)
+	if err != nil {
+		return Delta{}, err
+	}
+	var delta Delta
+	switch {
+	case !foundA && !foundB:
+		return Delta{}, util.NewNotFoundError(
+			errors.Errorf("neither %v nor %v exist", pathA, pathB), "Failed to diff files %v and %v", pathA, pathB)
+	case !foundA:
+		delta.Entries = []DeltaEntry{{Path: "$", Operation: DeltaAdded, New: b}}
+	case !foundB:
+		delta.Entries = []DeltaEntry{{Path: "$", Operation: DeltaRemoved, Old: a}}
+	default:
+		delta.Entries = diffValues(nil, a, b, nil)
+	}
+	sort.SliceStable(delta.Entries, func(i, j int) bool { return delta.Entries[i].Path < delta.Entries[j].Path })
+	return delta, nil
+}
+
+// getYamlValue returns the generic value of the yaml file at filePath, and whether it exists.
+func (m *MinioObjectStore) getYamlValue(ctx context.Context, filePath string) (interface{}, bool, error) {
+	bytes, err := m.GetFile(ctx, filePath)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, false, nil
+		}
+		return nil, false, util.Wrap(err, "Failed to read from a yaml file")
+	}
+	var value interface{}
+	if err := yaml.Unmarshal(bytes, &value); err != nil {
+		return nil, false, util.NewInternalServerError(err, "Failed to unmarshal file %v: %v", filePath, err.Error())
+	}
+	return value, true, nil
+}
+
+// diffValues appends the differences between a and b, found at path, to entries.
+func diffValues(path []fieldPathSegment, a interface{}, b interface{}, entries []DeltaEntry) []DeltaEntry {
+	mapA, isMapA := a.(map[string]interface{})
+	mapB, isMapB := b.(map[string]interface{})
+	if isMapA && isMapB {
+		for key, valueA := range mapA {
+			fieldPath := appendFieldPath(path, fieldPathSegment{key: key})
+			if valueB, ok := mapB[key]; ok {
+				entries = diffValues(fieldPath, valueA, valueB, entries)
+			} else {
+				entries = append(entries, DeltaEntry{Path: formatFieldPath(fieldPath), Operation: DeltaRemoved, Old: valueA})
+			}
+		}
+		for key, valueB := range mapB {
+			if _, ok := mapA[key]; !ok {
+				fieldPath := appendFieldPath(path, fieldPathSegment{key: key})
+				entries = append(entries, DeltaEntry{Path: formatFieldPath(fieldPath), Operation: DeltaAdded, New: valueB})
+			}
+		}
+		return entries
+	}
+	listA, isListA := a.([]interface{})
+	listB, isListB := b.([]interface{})
+	if isListA && isListB {
+		for i := 0; i < len(listA) || i < len(listB); i++ {
+			itemPath := appendFieldPath(path, fieldPathSegment{index: i})
+			switch {
+			case i >= len(listB):
+				entries = append(entries, DeltaEntry{Path: formatFieldPath(itemPath), Operation: DeltaRemoved, Old: listA[i]})
+			case i >= len(listA):
+				entries = append(entries, DeltaEntry{Path: formatFieldPath(itemPath), Operation: DeltaAdded, New: listB[i]})
+			default:
+				entries = diffValues(itemPath, listA[i], listB[i], entries)
+			}
+		}
+		return entries
+	}
+	if !reflect.DeepEqual(a, b) {
+		entries = append(entries, DeltaEntry{Path: formatFieldPath(path), Operation: DeltaChanged, Old: a, New: b})
+	}
+	return entries
+}
+
+// appendFieldPath returns path followed by segment, without sharing the array of path.
+func appendFieldPath(path []fieldPathSegment, segment fieldPathSegment) []fieldPathSegment {
+	return append(append([]fieldPathSegment(nil), path...), segment)
+}
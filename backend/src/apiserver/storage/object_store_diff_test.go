@@ -0,0 +1,103 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+const diffTestSpecA = `
+pipelineInfo:
+  name: hello-world
+  description: A sample
+root:
+  dag:
+    tasks:
+    - name: first
+`
+
+const diffTestSpecB = `
+pipelineInfo:
+  name: hello-world-v2
+root:
+  dag:
+    tasks:
+    - name: first
+    - name: second
+  inputs:
+    count: 3
+`
+
+func newDiffTestStore(t *testing.T, specs map[string]string) *MinioObjectStore {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	for id, spec := range specs {
+		require.Nil(t, manager.AddFile(context.TODO(), []byte(spec), manager.GetPipelineKey(id)))
+	}
+	return manager
+}
+
+func TestDiffYamlFiles(t *testing.T) {
+	manager := newDiffTestStore(t, map[string]string{"a": diffTestSpecA, "b": diffTestSpecB})
+
+	delta, err := manager.DiffYamlFiles(context.TODO(), manager.GetPipelineKey("a"), manager.GetPipelineKey("b"))
+
+	require.Nil(t, err)
+	assert.Equal(t, []DeltaEntry{
+		{Path: "$.pipelineInfo.description", Operation: DeltaRemoved, Old: "A sample"},
+		{Path: "$.pipelineInfo.name", Operation: DeltaChanged, Old: "hello-world", New: "hello-world-v2"},
+		{Path: "$.root.dag.tasks[1]", Operation: DeltaAdded, New: map[string]interface{}{"name": "second"}},
+		{Path: "$.root.inputs", Operation: DeltaAdded, New: map[string]interface{}{"count": float64(3)}},
+	}, delta.Entries)
+}
+
+func TestDiffYamlFiles_SameSpec(t *testing.T) {
+	manager := newDiffTestStore(t, map[string]string{"a": diffTestSpecA, "b": diffTestSpecA})
+
+	delta, err := manager.DiffYamlFiles(context.TODO(), manager.GetPipelineKey("a"), manager.GetPipelineKey("b"))
+
+	require.Nil(t, err)
+	assert.True(t, delta.Empty())
+}
+
+func TestDiffYamlFiles_MissingSide(t *testing.T) {
+	manager := newDiffTestStore(t, map[string]string{"a": "name: a\n"})
+
+	delta, err := manager.DiffYamlFiles(context.TODO(), manager.GetPipelineKey("missing"), manager.GetPipelineKey("a"))
+	require.Nil(t, err)
+	assert.Equal(t, []DeltaEntry{
+		{Path: "$", Operation: DeltaAdded, New: map[string]interface{}{"name": "a"}},
+	}, delta.Entries)
+
+	delta, err = manager.DiffYamlFiles(context.TODO(), manager.GetPipelineKey("a"), manager.GetPipelineKey("missing"))
+	require.Nil(t, err)
+	assert.Equal(t, []DeltaEntry{
+		{Path: "$", Operation: DeltaRemoved, Old: map[string]interface{}{"name": "a"}},
+	}, delta.Entries)
+}
+
+func TestDiffYamlFiles_BothMissing(t *testing.T) {
+	manager := newDiffTestStore(t, nil)
+
+	_, err := manager.DiffYamlFiles(context.TODO(), manager.GetPipelineKey("a"), manager.GetPipelineKey("b"))
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// EmbeddedDefaultsObjectStore serves default specs bundled with the binary, such as the
+// built-in sample pipelines, for the files that were never uploaded. A stored file always
+// takes precedence over its default, so defaults can be overridden by uploading the file.
+type EmbeddedDefaultsObjectStore struct {
+	ObjectStoreInterface
+	defaults map[string][]byte
+}
+
+// NewEmbeddedDefaultsObjectStore serves defaults, mapping file paths to their default
+// content, when the files are not found in store.
+func NewEmbeddedDefaultsObjectStore(store ObjectStoreInterface, defaults map[string][]byte) *EmbeddedDefaultsObjectStore {
+	copied := make(map[string][]byte, len(defaults))
+	for filePath, content := range defaults {
+		copied[filePath] = append([]byte(nil), content...)
+	}
+	return &EmbeddedDefaultsObjectStore{ObjectStoreInterface: store, defaults: copied}
+}
+
+func (e *EmbeddedDefaultsObjectStore) GetFile(ctx context.Context, filePath string) ([]byte, error) {
+	data, err := e.ObjectStoreInterface.GetFile(ctx, filePath)
+	if err != nil && errors.Is(err, ErrNotFound) {
+		if content, ok := e.defaults[filePath]; ok {
+			return append([]byte(nil), content...), nil
+		}
+	}
+	return data, err
+}
+
+func (e *EmbeddedDefaultsObjectStore) GetFromYamlFile(ctx context.Context, o interface{}, filePath string) error {
+	bytes, err := readYamlFile(ctx, e, filePath)
+	return unmarshalYamlFile(ctx, bytes, err, o, filePath)
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func newEmbeddedDefaultsTestStore() *EmbeddedDefaultsObjectStore {
+	return NewEmbeddedDefaultsObjectStore(&MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"},
+		map[string][]byte{"pipeline/sample": []byte("id: 1")})
+}
+
+func TestEmbeddedDefaultsObjectStore_ServesDefaultOnNotFound(t *testing.T) {
+	store := newEmbeddedDefaultsTestStore()
+
+	data, err := store.GetFile(context.TODO(), "pipeline/sample")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("id: 1"), data)
+
+	var foo Foo
+	require.Nil(t, store.GetFromYamlFile(context.TODO(), &foo, "pipeline/sample"))
+	assert.Equal(t, Foo{ID: 1}, foo)
+}
+
+func TestEmbeddedDefaultsObjectStore_UploadedTakesPrecedence(t *testing.T) {
+	store := newEmbeddedDefaultsTestStore()
+	require.Nil(t, store.AddFile(context.TODO(), []byte("id: 2"), "pipeline/sample"))
+
+	data, err := store.GetFile(context.TODO(), "pipeline/sample")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("id: 2"), data)
+}
+
+func TestEmbeddedDefaultsObjectStore_UnknownFileNotFound(t *testing.T) {
+	store := newEmbeddedDefaultsTestStore()
+
+	_, err := store.GetFile(context.TODO(), "pipeline/other")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestEmbeddedDefaultsObjectStore_OtherErrorsNotMasked(t *testing.T) {
+	store := NewEmbeddedDefaultsObjectStore(&MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipeline"},
+		map[string][]byte{"pipeline/sample": []byte("id: 1")})
+
+	_, err := store.GetFile(context.TODO(), "pipeline/sample")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"strings"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+// ErrEnvironmentPrefixMismatch is the cause of errors returned for files outside of the
+// environment prefix.
+var ErrEnvironmentPrefixMismatch = errors.New("file is outside of the environment prefix")
+
+// SetEnvironmentPrefix restricts writes to files under prefix, as an interlock against
+// misconfigured deployments writing into another environment's files. Reads are restricted
+// too if restrictReads is set. An empty prefix lifts the restriction.
+func (m *MinioObjectStore) SetEnvironmentPrefix(prefix string, restrictReads bool) {
+	m.environmentPrefix = strings.Trim(prefix, "/")
+	m.restrictReadsToEnvironment = restrictReads
+}
+
+// checkEnvironment returns an error if the environment prefix forbids the access to filePath.
+func (m *MinioObjectStore) checkEnvironment(filePath string, write bool) error {
+	if m.environmentPrefix == "" || (!write && !m.restrictReadsToEnvironment) {
+		return nil
+	}
+	if filePath == m.environmentPrefix || strings.HasPrefix(filePath, m.environmentPrefix+"/") {
+		return nil
+	}
+	operation := "read"
+	if write {
+		operation = "write"
+	}
+	return util.NewFailedPreconditionError(ErrEnvironmentPrefixMismatch,
+		"Refusing to %v file %v outside of the environment prefix %v", operation, filePath, m.environmentPrefix)
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func newEnvironmentTestStore(restrictReads bool) (*MinioObjectStore, *countingMinioClient) {
+	minioClient := newCountingMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "prod/pipelines"}
+	manager.SetEnvironmentPrefix("prod/", restrictReads)
+	return manager, minioClient
+}
+
+func TestEnvironmentPrefix_PrefixedWrite(t *testing.T) {
+	manager, minioClient := newEnvironmentTestStore(false)
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), manager.GetPipelineKey("1")))
+	assert.Equal(t, 1, minioClient.putCount)
+}
+
+func TestEnvironmentPrefix_MismatchedWriteRejected(t *testing.T) {
+	manager, minioClient := newEnvironmentTestStore(false)
+
+	for _, filePath := range []string{"dev/pipelines/1", "production/pipelines/1", "pipelines/1"} {
+		err := manager.AddFile(context.TODO(), []byte("spec"), filePath)
+		require.NotNil(t, err, filePath)
+		assert.Equal(t, codes.FailedPrecondition, err.(*util.UserError).ExternalStatusCode(), filePath)
+		assert.True(t, errors.Is(err, ErrEnvironmentPrefixMismatch), filePath)
+	}
+	assert.NotNil(t, manager.MoveFile(context.TODO(), "dev/pipelines/1", "prod/pipelines/1"))
+	assert.Equal(t, 0, minioClient.putCount)
+	assert.Equal(t, 0, minioClient.statCount)
+}
+
+func TestEnvironmentPrefix_Reads(t *testing.T) {
+	manager, minioClient := newEnvironmentTestStore(false)
+	_, err := manager.GetFile(context.TODO(), "dev/pipelines/1")
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+	assert.Equal(t, 1, minioClient.getCount)
+
+	manager, minioClient = newEnvironmentTestStore(true)
+	_, err = manager.GetFile(context.TODO(), "dev/pipelines/1")
+	assert.Equal(t, codes.FailedPrecondition, err.(*util.UserError).ExternalStatusCode())
+	assert.Equal(t, 0, minioClient.getCount)
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+)
+
+// Classes of object store failures. Errors returned by the store wrap one of them when the
+// failure could be classified, so callers can test for them with errors.Is.
+var (
+	ErrAuth     = errors.New("object store access denied")
+	ErrNetwork  = errors.New("object store unreachable")
+	ErrQuota    = errors.New("object store quota exceeded")
+	ErrNotFound = errors.New("object store object not found")
+	ErrConflict = errors.New("object store precondition failed")
+)
+
+// minioErrorClasses maps minio.ErrorResponse codes to the class of failure they denote.
+var minioErrorClasses = map[string]error{
+	"NoSuchKey":                      ErrNotFound,
+	"NoSuchBucket":                   ErrNotFound,
+	"NoSuchVersion":                  ErrNotFound,
+	"NoSuchUpload":                   ErrNotFound,
+	"AccessDenied":                   ErrAuth,
+	"AllAccessDisabled":              ErrAuth,
+	"InvalidAccessKeyId":             ErrAuth,
+	"SignatureDoesNotMatch":          ErrAuth,
+	"ExpiredToken":                   ErrAuth,
+	"InvalidToken":                   ErrAuth,
+	"AuthorizationHeaderMalformed":   ErrAuth,
+	"QuotaExceeded":                  ErrQuota,
+	"XMinioAdminBucketQuotaExceeded": ErrQuota,
+	"XMinioStorageFull":              ErrQuota,
+	"PreconditionFailed":             ErrConflict,
+	"OperationAborted":               ErrConflict,
+	"ObjectLocked":                   ErrConflict,
+	"InternalError":                  ErrNetwork,
+	"ServiceUnavailable":             ErrNetwork,
+	"SlowDown":                       ErrNetwork,
+	"RequestTimeout":                 ErrNetwork,
+}
+
+// ClassifyError returns the class of object store failure err denotes, or nil if it
+// cannot be classified.
+func ClassifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, class := range []error{ErrAuth, ErrNetwork, ErrQuota, ErrNotFound, ErrConflict} {
+		if errors.Is(err, class) {
+			return class
+		}
+	}
+	var response minio.ErrorResponse
+	if errors.As(err, &response) {
+		if class, ok := minioErrorClasses[response.Code]; ok {
+			return class
+		}
+		switch {
+		case response.StatusCode == http.StatusNotFound:
+			return ErrNotFound
+		case response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden:
+			return ErrAuth
+		case response.StatusCode == http.StatusConflict || response.StatusCode == http.StatusPreconditionFailed:
+			return ErrConflict
+		case response.StatusCode >= http.StatusInternalServerError:
+			return ErrNetwork
+		}
+		return nil
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrNetwork
+	}
+	return nil
+}
+
+// newObjectStoreError wraps a failed object store call, tagging it with its class of failure.
+// Not found, access denied, quota and network failures map to the matching API status
+// codes, all other failures are internal errors.
+func newObjectStoreError(err error, format string, a ...interface{}) *util.UserError {
+	class := ClassifyError(err)
+	if class == nil {
+		return util.NewInternalServerError(err, format, a...)
+	}
+	if !errors.Is(err, class) {
+		err = fmt.Errorf("%w: %w", class, err)
+	}
+	switch class {
+	case ErrNotFound:
+		return util.NewNotFoundError(err, format, a...)
+	case ErrAuth:
+		return util.NewPermissionDeniedError(err, format, a...)
+	case ErrQuota:
+		return util.NewResourceExhaustedError(err, format, a...)
+	case ErrNetwork:
+		return util.NewUnavailableServerError(err, format, a...)
+	default:
+		return util.NewInternalServerError(err, format, a...)
+	}
+}
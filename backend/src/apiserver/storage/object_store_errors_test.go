@@ -0,0 +1,88 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "no such key", err: minio.ErrorResponse{Code: "NoSuchKey", StatusCode: 404}, want: ErrNotFound},
+		{name: "no such bucket", err: minio.ErrorResponse{Code: "NoSuchBucket", StatusCode: 404}, want: ErrNotFound},
+		{name: "access denied", err: minio.ErrorResponse{Code: "AccessDenied", StatusCode: 403}, want: ErrAuth},
+		{name: "invalid access key", err: minio.ErrorResponse{Code: "InvalidAccessKeyId", StatusCode: 403}, want: ErrAuth},
+		{name: "signature mismatch", err: minio.ErrorResponse{Code: "SignatureDoesNotMatch", StatusCode: 403}, want: ErrAuth},
+		{name: "quota exceeded", err: minio.ErrorResponse{Code: "XMinioAdminBucketQuotaExceeded", StatusCode: 400}, want: ErrQuota},
+		{name: "precondition failed", err: minio.ErrorResponse{Code: "PreconditionFailed", StatusCode: 412}, want: ErrConflict},
+		{name: "unknown conflict", err: minio.ErrorResponse{Code: "Unknown", StatusCode: 409}, want: ErrConflict},
+		{name: "slow down", err: minio.ErrorResponse{Code: "SlowDown", StatusCode: 503}, want: ErrNetwork},
+		{name: "unknown server error", err: minio.ErrorResponse{Code: "Unknown", StatusCode: 502}, want: ErrNetwork},
+		{name: "connection refused", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, want: ErrNetwork},
+		{name: "wrapped", err: errors.Wrap(minio.ErrorResponse{Code: "AccessDenied"}, "put"), want: ErrAuth},
+		{name: "unknown client error", err: minio.ErrorResponse{Code: "InvalidArgument", StatusCode: 400}, want: nil},
+		{name: "plain error", err: errors.New("some error"), want: nil},
+		{name: "nil", err: nil, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyError(tt.err))
+		})
+	}
+}
+
+func TestNewObjectStoreError(t *testing.T) {
+	tests := []struct {
+		err   error
+		class error
+		code  codes.Code
+	}{
+		{err: minio.ErrorResponse{Code: "NoSuchKey"}, class: ErrNotFound, code: codes.NotFound},
+		{err: minio.ErrorResponse{Code: "AccessDenied"}, class: ErrAuth, code: codes.PermissionDenied},
+		{err: minio.ErrorResponse{Code: "ServiceUnavailable"}, class: ErrNetwork, code: codes.Unavailable},
+		{err: minio.ErrorResponse{Code: "QuotaExceeded"}, class: ErrQuota, code: codes.ResourceExhausted},
+		{err: minio.ErrorResponse{Code: "PreconditionFailed"}, class: ErrConflict, code: codes.Internal},
+	}
+	for _, tt := range tests {
+		err := newObjectStoreError(tt.err, "Failed to get file %v", "pipeline/1")
+		assert.True(t, errors.Is(err, tt.class), tt.class.Error())
+		assert.Equal(t, tt.code, err.ExternalStatusCode(), tt.class.Error())
+		var response minio.ErrorResponse
+		assert.True(t, errors.As(err, &response))
+	}
+
+	err := newObjectStoreError(errors.New("some error"), "Failed to get file %v", "pipeline/1")
+	assert.Equal(t, codes.Internal, err.ExternalStatusCode())
+	assert.Nil(t, ClassifyError(err))
+}
+
+func TestGetFile_NotFoundError(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	_, err := manager.GetFile(context.TODO(), manager.GetPipelineKey("1"))
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
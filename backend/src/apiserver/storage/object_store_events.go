@@ -0,0 +1,67 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	eventReasonWriteFailed = "ObjectStoreWriteFailed"
+	eventReasonReadFailed  = "ObjectStoreReadFailed"
+)
+
+type eventObjectContextKey struct{}
+
+// WithEventObject ties the object store operations using ctx to a Kubernetes resource.
+// Failed operations are reported as warning events on it, if the store has an event recorder.
+func WithEventObject(ctx context.Context, object runtime.Object) context.Context {
+	return context.WithValue(ctx, eventObjectContextKey{}, object)
+}
+
+func eventObjectFromContext(ctx context.Context) runtime.Object {
+	object, _ := ctx.Value(eventObjectContextKey{}).(runtime.Object)
+	return object
+}
+
+// SetEventRecorder sets the recorder failed operations are reported to. A nil recorder
+// disables events.
+func (m *MinioObjectStore) SetEventRecorder(recorder record.EventRecorder) {
+	m.eventRecorder = recorder
+}
+
+// recordWarningEvent reports a failed operation on the resource tied to ctx, if any.
+// Failing to record the event never affects the operation.
+func (m *MinioObjectStore) recordWarningEvent(ctx context.Context, reason string, format string, a ...interface{}) {
+	if m.eventRecorder == nil {
+		return
+	}
+	object := eventObjectFromContext(ctx)
+	if object == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			glog.Errorf("Failed to record %v event: %v", reason, r)
+		}
+	}()
+	m.eventRecorder.Event(object, corev1.EventTypeWarning, reason, fmt.Sprintf(format, a...))
+}
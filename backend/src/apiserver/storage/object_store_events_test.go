@@ -0,0 +1,92 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+var eventTestObject = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "pipeline", Namespace: "kubeflow"}}
+
+func TestRecordWarningEvent_WriteFailure(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	manager := &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipeline"}
+	manager.SetEventRecorder(recorder)
+
+	err := manager.AddFile(WithEventObject(context.TODO(), eventTestObject), []byte("abc"), manager.GetPipelineKey("1"))
+	assert.NotNil(t, err)
+	require.Len(t, recorder.Events, 1)
+	event := <-recorder.Events
+	assert.Contains(t, event, corev1.EventTypeWarning)
+	assert.Contains(t, event, eventReasonWriteFailed)
+	assert.Contains(t, event, "pipeline/1")
+}
+
+func TestRecordWarningEvent_ReadFailure(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	manager.SetEventRecorder(recorder)
+
+	_, err := manager.GetFile(WithEventObject(context.TODO(), eventTestObject), manager.GetPipelineKey("1"))
+	assert.NotNil(t, err)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, eventReasonReadFailed)
+}
+
+func TestRecordWarningEvent_NoEventOnSuccess(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	manager.SetEventRecorder(recorder)
+	ctx := WithEventObject(context.TODO(), eventTestObject)
+
+	require.Nil(t, manager.AddFile(ctx, []byte("abc"), manager.GetPipelineKey("1")))
+	_, err := manager.GetFile(ctx, manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Len(t, recorder.Events, 0)
+}
+
+func TestRecordWarningEvent_NoResourceInContext(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	manager := &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipeline"}
+	manager.SetEventRecorder(recorder)
+
+	assert.NotNil(t, manager.AddFile(context.TODO(), []byte("abc"), manager.GetPipelineKey("1")))
+	assert.Len(t, recorder.Events, 0)
+}
+
+// panickingRecorder fails every attempt to record an event.
+type panickingRecorder struct {
+	record.FakeRecorder
+}
+
+func (r *panickingRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	panic("event sink unavailable")
+}
+
+func TestRecordWarningEvent_RecorderFailureIgnored(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipeline"}
+	manager.SetEventRecorder(&panickingRecorder{})
+
+	err := manager.AddFile(WithEventObject(context.TODO(), eventTestObject), []byte("abc"), manager.GetPipelineKey("1"))
+	assert.Contains(t, err.Error(), "Failed to store file")
+}
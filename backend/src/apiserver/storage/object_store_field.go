@@ -0,0 +1,141 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// fieldPathSegment is either a map key or, when key is empty, a list index.
+type fieldPathSegment struct {
+	key   string
+	index int
+}
+
+// GetYamlField extracts a single field from a yaml file without unmarshalling the rest of it
+// into typed structs. fieldPath is a jsonpath-like selector such as "$.metadata.name" or
+// "spec.tasks[0].name"; the leading "$." is optional. A path that does not exist in the file
+// returns a NotFound error.
+func (m *MinioObjectStore) GetYamlField(ctx context.Context, filePath string, fieldPath string) (json.RawMessage, error) {
+	segments, err := parseFieldPath(fieldPath)
+	if err != nil {
+		return nil, err
+	}
+	bytes, err := m.GetFile(ctx, filePath)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to read from a yaml file")
+	}
+	value, err := yaml.YAMLToJSON(bytes)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to convert file %v to json: %v", filePath, err.Error())
+	}
+
+	current := json.RawMessage(value)
+	for i, segment := range segments {
+		var found bool
+		current, found, err = selectField(current, segment)
+		if err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to read field %v of file %v", fieldPath, filePath)
+		}
+		if !found {
+			return nil, util.NewNotFoundError(
+				errors.Errorf("field %v not found in file %v", formatFieldPath(segments[:i+1]), filePath),
+				"Field %v not found", fieldPath)
+		}
+	}
+	return current, nil
+}
+
+// selectField decodes only the top level of value to look up segment in it.
+func selectField(value json.RawMessage, segment fieldPathSegment) (json.RawMessage, bool, error) {
+	trimmed := strings.TrimSpace(string(value))
+	if segment.key != "" {
+		if !strings.HasPrefix(trimmed, "{") {
+			return nil, false, nil
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(value, &fields); err != nil {
+			return nil, false, err
+		}
+		field, ok := fields[segment.key]
+		return field, ok, nil
+	}
+	if !strings.HasPrefix(trimmed, "[") {
+		return nil, false, nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(value, &items); err != nil {
+		return nil, false, err
+	}
+	if segment.index < 0 || segment.index >= len(items) {
+		return nil, false, nil
+	}
+	return items[segment.index], true, nil
+}
+
+// parseFieldPath parses selectors like "$.a.b[1].c" into segments.
+func parseFieldPath(fieldPath string) ([]fieldPathSegment, error) {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(fieldPath, "$"), ".")
+	if trimmed == "" {
+		return nil, util.NewInvalidInputError("Invalid field path %q: empty path", fieldPath)
+	}
+	var segments []fieldPathSegment
+	for _, part := range strings.Split(trimmed, ".") {
+		key := part
+		var indexes string
+		if open := strings.Index(part, "["); open >= 0 {
+			key, indexes = part[:open], part[open:]
+		}
+		if key == "" && indexes == "" {
+			return nil, util.NewInvalidInputError("Invalid field path %q: empty segment", fieldPath)
+		}
+		if key != "" {
+			segments = append(segments, fieldPathSegment{key: key})
+		}
+		for indexes != "" {
+			end := strings.Index(indexes, "]")
+			if !strings.HasPrefix(indexes, "[") || end < 0 {
+				return nil, util.NewInvalidInputError("Invalid field path %q: malformed index", fieldPath)
+			}
+			index, err := strconv.Atoi(indexes[1:end])
+			if err != nil {
+				return nil, util.NewInvalidInputError("Invalid field path %q: malformed index", fieldPath)
+			}
+			segments = append(segments, fieldPathSegment{index: index})
+			indexes = indexes[end+1:]
+		}
+	}
+	return segments, nil
+}
+
+func formatFieldPath(segments []fieldPathSegment) string {
+	var builder strings.Builder
+	builder.WriteString("$")
+	for _, segment := range segments {
+		if segment.key != "" {
+			builder.WriteString("." + segment.key)
+		} else {
+			builder.WriteString("[" + strconv.Itoa(segment.index) + "]")
+		}
+	}
+	return builder.String()
+}
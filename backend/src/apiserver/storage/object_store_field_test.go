@@ -0,0 +1,84 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+const fieldTestSpec = `
+pipelineInfo:
+  name: hello-world
+  description: A sample
+root:
+  dag:
+    tasks:
+    - name: first
+      inputs:
+        count: 3
+    - name: second
+`
+
+func newFieldTestStore(t *testing.T) *MinioObjectStore {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte(fieldTestSpec), manager.GetPipelineKey("1")))
+	return manager
+}
+
+func TestGetYamlField_NestedScalar(t *testing.T) {
+	manager := newFieldTestStore(t)
+
+	value, err := manager.GetYamlField(context.TODO(), manager.GetPipelineKey("1"), "$.pipelineInfo.name")
+	require.Nil(t, err)
+	assert.JSONEq(t, `"hello-world"`, string(value))
+
+	value, err = manager.GetYamlField(context.TODO(), manager.GetPipelineKey("1"), "root.dag.tasks[0].inputs.count")
+	require.Nil(t, err)
+	assert.JSONEq(t, `3`, string(value))
+}
+
+func TestGetYamlField_NestedObject(t *testing.T) {
+	manager := newFieldTestStore(t)
+
+	value, err := manager.GetYamlField(context.TODO(), manager.GetPipelineKey("1"), "root.dag.tasks[0]")
+	require.Nil(t, err)
+	assert.JSONEq(t, `{"name": "first", "inputs": {"count": 3}}`, string(value))
+}
+
+func TestGetYamlField_MissingPath(t *testing.T) {
+	manager := newFieldTestStore(t)
+
+	for _, fieldPath := range []string{"pipelineInfo.owner", "root.dag.tasks[5]", "pipelineInfo.name.first"} {
+		_, err := manager.GetYamlField(context.TODO(), manager.GetPipelineKey("1"), fieldPath)
+		require.NotNil(t, err, fieldPath)
+		assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode(), fieldPath)
+	}
+}
+
+func TestGetYamlField_InvalidPath(t *testing.T) {
+	manager := newFieldTestStore(t)
+
+	for _, fieldPath := range []string{"", "$", "a..b", "a[x]", "a[1"} {
+		_, err := manager.GetYamlField(context.TODO(), manager.GetPipelineKey("1"), fieldPath)
+		require.NotNil(t, err, fieldPath)
+		assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode(), fieldPath)
+	}
+}
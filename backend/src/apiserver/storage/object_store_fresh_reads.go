@@ -0,0 +1,64 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/golang/glog"
+)
+
+// forceFreshReads is the process-wide lever making the caching, memoizing and coalescing
+// decorators pass every call through to the store they wrap.
+var forceFreshReads atomic.Bool
+
+// SetForceFreshReads sets whether the caching, memoizing and coalescing decorators are
+// bypassed. It is meant to be flipped during incidents, to rule out stale reads while
+// debugging a degraded object store.
+func SetForceFreshReads(enabled bool) {
+	if forceFreshReads.Swap(enabled) != enabled {
+		glog.Infof("Forcing fresh object store reads: %v", enabled)
+	}
+}
+
+// ForceFreshReads returns whether the caching, memoizing and coalescing decorators are
+// bypassed.
+func ForceFreshReads() bool {
+	return forceFreshReads.Load()
+}
+
+// ForceFreshReadsHandler reports the fresh reads flag on GET, and sets it from the
+// "enabled" query parameter on PUT or POST.
+func ForceFreshReadsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		enabled, err := strconv.ParseBool(r.URL.Query().Get("enabled"))
+		if err != nil {
+			http.Error(w, "Invalid value of query parameter enabled", http.StatusBadRequest)
+			return
+		}
+		SetForceFreshReads(enabled)
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]bool{"force_fresh_reads": ForceFreshReads()})
+}
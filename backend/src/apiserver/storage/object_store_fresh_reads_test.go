@@ -0,0 +1,104 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setForceFreshReadsForTest(t *testing.T, enabled bool) {
+	SetForceFreshReads(enabled)
+	t.Cleanup(func() { SetForceFreshReads(false) })
+}
+
+func TestForceFreshReads_BypassesCache(t *testing.T) {
+	store, minioClient := newCachingTestStore(10)
+	require.Nil(t, store.AddFile(context.TODO(), []byte("id: 1"), store.GetPipelineKey("1")))
+	_, err := store.GetFile(context.TODO(), store.GetPipelineKey("1"))
+	require.Nil(t, err)
+	require.Equal(t, 1, minioClient.getCount)
+
+	setForceFreshReadsForTest(t, true)
+	for i := 0; i < 2; i++ {
+		_, err = store.GetFile(context.TODO(), store.GetPipelineKey("1"))
+		require.Nil(t, err)
+	}
+	assert.Equal(t, 3, minioClient.getCount)
+
+	SetForceFreshReads(false)
+	_, err = store.GetFile(context.TODO(), store.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, 3, minioClient.getCount)
+}
+
+func TestForceFreshReads_BypassesCoalescing(t *testing.T) {
+	minioClient := newCountingMinioClient()
+	store := NewCoalescingObjectStore(&MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}, time.Hour)
+
+	setForceFreshReadsForTest(t, true)
+	for i := 0; i < 2; i++ {
+		require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipeline/1"))
+	}
+	assert.Equal(t, 2, minioClient.putCount)
+
+	SetForceFreshReads(false)
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipeline/1"))
+	assert.Equal(t, 2, minioClient.putCount)
+}
+
+func TestForceFreshReads_BypassesMemo(t *testing.T) {
+	minioClient := newCountingMinioClient()
+	store := NewMemoizingObjectStore(&MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"})
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipeline/1"))
+	ctx := WithRequestMemo(context.TODO())
+
+	setForceFreshReadsForTest(t, true)
+	for i := 0; i < 2; i++ {
+		_, err := store.GetFile(ctx, "pipeline/1")
+		require.Nil(t, err)
+	}
+	assert.Equal(t, 2, minioClient.getCount)
+}
+
+func TestForceFreshReadsHandler(t *testing.T) {
+	t.Cleanup(func() { SetForceFreshReads(false) })
+
+	recorder := httptest.NewRecorder()
+	ForceFreshReadsHandler(recorder, httptest.NewRequest(http.MethodPut, "/?enabled=true", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"force_fresh_reads": true}`, recorder.Body.String())
+	assert.True(t, ForceFreshReads())
+
+	recorder = httptest.NewRecorder()
+	ForceFreshReadsHandler(recorder, httptest.NewRequest(http.MethodPut, "/?enabled=maybe", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.True(t, ForceFreshReads())
+
+	recorder = httptest.NewRecorder()
+	ForceFreshReadsHandler(recorder, httptest.NewRequest(http.MethodPost, "/?enabled=false", nil))
+	assert.JSONEq(t, `{"force_fresh_reads": false}`, recorder.Body.String())
+	assert.False(t, ForceFreshReads())
+
+	recorder = httptest.NewRecorder()
+	ForceFreshReadsHandler(recorder, httptest.NewRequest(http.MethodDelete, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
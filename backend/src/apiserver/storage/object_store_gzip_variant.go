@@ -0,0 +1,130 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"strconv"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+)
+
+// gzipVariantSuffix is appended to the path of a file to get the path of its gzip variant.
+const gzipVariantSuffix = ".gz"
+
+// gzipVariantSourceMetadata is the user metadata of a gzip variant recording the SHA-256 of
+// the content it was compressed from, so variants of overwritten files are not served.
+const gzipVariantSourceMetadata = "Kfp-Gzip-Source-Sha256"
+
+// SetGzipVariants makes AddAsYamlFile store a gzipped variant of each file next to it, at
+// its path followed by ".gz", so GetFileForEncoding serves clients accepting gzip without
+// compressing the file for each request.
+func (m *MinioObjectStore) SetGzipVariants(enabled bool) {
+	m.gzipVariants = enabled
+}
+
+// addGzipVariant stores the gzipped variant of the file at filePath, whose content is data.
+func (m *MinioObjectStore) addGzipVariant(ctx context.Context, data []byte, filePath string) error {
+	variantPath := filePath + gzipVariantSuffix
+	key := m.resolveKey(ctx, variantPath)
+	if err := m.checkKeyLength("store file", variantPath, key); err != nil {
+		return err
+	}
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	if _, err := gzipWriter.Write(data); err != nil {
+		return util.NewInternalServerError(err, "Failed to compress file %v", filePath)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return util.NewInternalServerError(err, "Failed to compress file %v", filePath)
+	}
+	opts := m.putObjectOptions(ctx)
+	opts.ContentEncoding = contentEncodingGzip
+	setUserMetadata(&opts, decompressedSizeMetadata, strconv.Itoa(len(data)))
+	setUserMetadata(&opts, gzipVariantSourceMetadata, contentSha256(data))
+	if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationWrite, Path: variantPath, Size: int64(compressed.Len())}); err != nil {
+		return err
+	}
+	err := m.retry(ctx, func(ctx context.Context) error {
+		_, err := m.minioClient.PutObject(ctx, m.bucketName, key, bytes.NewReader(compressed.Bytes()),
+			int64(compressed.Len()), opts)
+		return err
+	})
+	if err != nil {
+		m.recordWarningEvent(ctx, eventReasonWriteFailed, "Failed to store file %v: %v", variantPath, err)
+		return newObjectStoreError(err, "Failed to store file %v", variantPath)
+	}
+	return nil
+}
+
+// GetFileForEncoding returns the file at filePath along with its attributes. If acceptsGzip
+// is set and the file has an up to date gzip variant, the variant is returned instead,
+// with the gzip content encoding. Otherwise the file is returned as stored.
+func (m *MinioObjectStore) GetFileForEncoding(ctx context.Context, filePath string, acceptsGzip bool) ([]byte, *FileInfo, error) {
+	if err := m.checkOpen("get file", filePath); err != nil {
+		return nil, nil, err
+	}
+	if err := m.checkEnvironment(filePath, false); err != nil {
+		return nil, nil, err
+	}
+	var info minio.ObjectInfo
+	err := m.retry(ctx, func(ctx context.Context) error {
+		var err error
+		info, err = m.minioClient.StatObject(ctx, m.bucketName, m.resolveKey(ctx, filePath), m.getObjectOptions(ctx))
+		return err
+	})
+	if err != nil {
+		return nil, nil, newObjectStoreError(err, "Failed to stat file %v", filePath)
+	}
+	if sourceHash := userMetadataValue(info, contentSha256Metadata); acceptsGzip && sourceHash != "" {
+		if data, variantInfo, ok := m.getGzipVariant(ctx, filePath, sourceHash); ok {
+			return data, variantInfo, nil
+		}
+	}
+	data, err := m.GetFile(ctx, filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, newFileInfo(filePath, info), nil
+}
+
+// getGzipVariant returns the gzip variant of the file at filePath, if there is one
+// compressed from the content whose SHA-256 is sourceHash.
+func (m *MinioObjectStore) getGzipVariant(ctx context.Context, filePath string, sourceHash string) ([]byte, *FileInfo, bool) {
+	variantPath := filePath + gzipVariantSuffix
+	key := m.resolveKey(ctx, variantPath)
+	reader, err := m.minioClient.GetObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+	if err != nil {
+		return nil, nil, false
+	}
+	defer closeReader(reader)
+	var info minio.ObjectInfo
+	if object, ok := reader.(objectStater); ok {
+		info, err = object.Stat()
+	} else {
+		info, err = m.minioClient.StatObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+	}
+	if err != nil || userMetadataValue(info, gzipVariantSourceMetadata) != sourceHash {
+		return nil, nil, false
+	}
+	data, err := readObject(reader)
+	if err != nil {
+		return nil, nil, false
+	}
+	return data, newFileInfo(variantPath, info), true
+}
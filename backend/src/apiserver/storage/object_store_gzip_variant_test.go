@@ -0,0 +1,115 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+type gzipVariantTestSpec struct {
+	Name string `json:"name"`
+}
+
+func gunzip(t *testing.T, data []byte) []byte {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	require.Nil(t, err)
+	decompressed, err := io.ReadAll(reader)
+	require.Nil(t, err)
+	return decompressed
+}
+
+func newGzipVariantTestStore(t *testing.T) (*MinioObjectStore, *FakeMinioClient) {
+	minioClient := NewFakeMinioClient()
+	store := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines", WithGzipVariants(true))
+	require.Nil(t, store.AddAsYamlFile(context.TODO(), gzipVariantTestSpec{Name: "spec"}, "pipelines/1"))
+	return store, minioClient
+}
+
+func TestAddAsYamlFile_GzipVariants(t *testing.T) {
+	_, minioClient := newGzipVariantTestStore(t)
+
+	assert.True(t, minioClient.ExistObject("pipelines/1"))
+	assert.True(t, minioClient.ExistObject("pipelines/1.gz"))
+	assert.Equal(t, minioClient.minioClient["pipelines/1"], gunzip(t, minioClient.minioClient["pipelines/1.gz"]))
+}
+
+func TestAddAsYamlFile_GzipVariantsDisabled(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	store := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines")
+
+	require.Nil(t, store.AddAsYamlFile(context.TODO(), gzipVariantTestSpec{Name: "spec"}, "pipelines/1"))
+
+	assert.Equal(t, 1, minioClient.GetObjectCount())
+}
+
+func TestGetFileForEncoding(t *testing.T) {
+	store, _ := newGzipVariantTestStore(t)
+
+	plain, plainInfo, err := store.GetFileForEncoding(context.TODO(), "pipelines/1", false)
+	require.Nil(t, err)
+	assert.Equal(t, []byte("name: spec\n"), plain)
+	assert.Equal(t, "pipelines/1", plainInfo.Key)
+	assert.Empty(t, plainInfo.ContentEncoding)
+
+	compressed, gzipInfo, err := store.GetFileForEncoding(context.TODO(), "pipelines/1", true)
+	require.Nil(t, err)
+	assert.Equal(t, plain, gunzip(t, compressed))
+	assert.Equal(t, "pipelines/1.gz", gzipInfo.Key)
+	assert.Equal(t, "gzip", gzipInfo.ContentEncoding)
+	assert.Equal(t, int64(len(compressed)), gzipInfo.Size)
+	assert.Equal(t, int64(len(plain)), gzipInfo.LogicalSize)
+}
+
+func TestGetFileForEncoding_StaleVariantNotServed(t *testing.T) {
+	store, _ := newGzipVariantTestStore(t)
+	// Overwrites the file without updating its variant.
+	require.Nil(t, store.AddFile(context.TODO(), []byte("name: new spec\n"), "pipelines/1"))
+
+	data, info, err := store.GetFileForEncoding(context.TODO(), "pipelines/1", true)
+
+	require.Nil(t, err)
+	assert.Equal(t, []byte("name: new spec\n"), data)
+	assert.Empty(t, info.ContentEncoding)
+}
+
+func TestGetFileForEncoding_NoVariant(t *testing.T) {
+	store := NewMinioObjectStoreWithOptions(NewFakeMinioClient(), "mlpipeline", "pipelines")
+	require.Nil(t, store.AddAsYamlFile(context.TODO(), gzipVariantTestSpec{Name: "spec"}, "pipelines/1"))
+
+	data, info, err := store.GetFileForEncoding(context.TODO(), "pipelines/1", true)
+
+	require.Nil(t, err)
+	assert.Equal(t, []byte("name: spec\n"), data)
+	assert.Equal(t, "pipelines/1", info.Key)
+}
+
+func TestGetFileForEncoding_NotFound(t *testing.T) {
+	store, minioClient := newGzipVariantTestStore(t)
+	require.Nil(t, minioClient.DeleteObject(context.TODO(), "mlpipeline", "pipelines/1"))
+
+	_, _, err := store.GetFileForEncoding(context.TODO(), "pipelines/1", true)
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	minio "github.com/minio/minio-go/v7"
+)
+
+// contentSha256Metadata is the user metadata recording the hex encoded SHA-256 of the
+// content written by AddFile.
+const contentSha256Metadata = "Kfp-Content-Sha256"
+
+// objectStater is implemented by the readers minio.Client.GetObject returns. Their info
+// comes with the response to the read, so stat-ing them costs no extra request.
+type objectStater interface {
+	Stat() (minio.ObjectInfo, error)
+}
+
+// GetFileWithHash returns the content of the file together with its hex encoded SHA-256.
+// The hash recorded when the file was written is returned when available; otherwise it is
+// computed from the content.
+func (m *MinioObjectStore) GetFileWithHash(ctx context.Context, filePath string) ([]byte, string, error) {
+	if err := m.checkOpen("get file", filePath); err != nil {
+		return nil, "", err
+	}
+	if err := m.checkEnvironment(filePath, false); err != nil {
+		return nil, "", err
+	}
+	reader, err := m.minioClient.GetObject(ctx, m.bucketName, m.resolveKey(ctx, filePath), m.getObjectOptions(ctx))
+	if err != nil {
+		m.recordWarningEvent(ctx, eventReasonReadFailed, "Failed to get file %v: %v", filePath, err)
+		return nil, "", newObjectStoreError(err, "Failed to get file %v", filePath)
+	}
+	defer closeReader(reader)
+
+	storedHash := ""
+	if object, ok := reader.(objectStater); ok {
+		info, err := object.Stat()
+		if err != nil {
+			return nil, "", newObjectStoreError(err, "Failed to get file %v", filePath)
+		}
+		storedHash = userMetadataValue(info, contentSha256Metadata)
+	}
+	data, err := readObject(reader)
+	if err != nil {
+		return nil, "", newObjectStoreError(err, "Failed to read file %v", filePath)
+	}
+	data = m.removeChunkSignatures(data)
+	if isSha256Hex(storedHash) {
+		return data, storedHash, nil
+	}
+	return data, contentSha256(data), nil
+}
+
+func contentSha256(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func isSha256Hex(value string) bool {
+	decoded, err := hex.DecodeString(value)
+	return err == nil && len(decoded) == sha256.Size
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestGetFileWithHash(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), manager.GetPipelineKey("1")))
+
+	data, hash, err := manager.GetFileWithHash(context.TODO(), manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+	assert.Equal(t, sha256Hex([]byte("spec")), hash)
+}
+
+func TestGetFileWithHash_UsesStoredHash(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	// A stored hash that does not match the content shows it was not recomputed.
+	storedHash := sha256Hex([]byte("recorded"))
+	_, err := minioClient.PutObject(context.TODO(), "", "pipeline/1", bytes.NewReader([]byte("spec")), 4,
+		minio.PutObjectOptions{UserMetadata: map[string]string{"kfp-content-sha256": storedHash}})
+	require.Nil(t, err)
+
+	data, hash, err := manager.GetFileWithHash(context.TODO(), "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+	assert.Equal(t, storedHash, hash)
+}
+
+func TestGetFileWithHash_ComputesHashWithoutMetadata(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	_, err := minioClient.PutObject(context.TODO(), "", "pipeline/1", bytes.NewReader([]byte("spec")), 4,
+		minio.PutObjectOptions{UserMetadata: map[string]string{contentSha256Metadata: "not-a-hash"}})
+	require.Nil(t, err)
+
+	_, hash, err := manager.GetFileWithHash(context.TODO(), "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, sha256Hex([]byte("spec")), hash)
+}
+
+func TestGetFileWithHash_NotFound(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+
+	_, _, err := manager.GetFileWithHash(context.TODO(), "pipeline/1")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"path"
+	"sync"
+	"time"
+)
+
+// HealthStatus is the health of the object store as seen by the operations of the store.
+type HealthStatus string
+
+const (
+	// HealthHealthy is reported when recent operations succeed.
+	HealthHealthy HealthStatus = "healthy"
+	// HealthDegraded is reported when some recent operations fail, e.g. during a blip of
+	// the backend. Readiness probes should tolerate it.
+	HealthDegraded HealthStatus = "degraded"
+	// HealthDown is reported when most recent operations fail.
+	HealthDown HealthStatus = "down"
+)
+
+// healthOutcomes is the number of the most recent operation outcomes the store keeps.
+const healthOutcomes = 256
+
+// HealthPolicy sets how the success rate of recent operations maps to a HealthStatus.
+// Only failures to reach the backend, those classified as ErrNetwork, count as failures.
+type HealthPolicy struct {
+	// Window is how far back operations are considered. Zero is one minute.
+	Window time.Duration
+	// DegradedBelow is the success rate below which the store is degraded. Zero is 0.95.
+	DegradedBelow float64
+	// DownBelow is the success rate below which the store is down. Zero is 0.5.
+	DownBelow float64
+}
+
+func (p HealthPolicy) withDefaults() HealthPolicy {
+	if p.Window <= 0 {
+		p.Window = time.Minute
+	}
+	if p.DegradedBelow <= 0 {
+		p.DegradedBelow = 0.95
+	}
+	if p.DownBelow <= 0 {
+		p.DownBelow = 0.5
+	}
+	return p
+}
+
+// status returns the status of a success rate.
+func (p HealthPolicy) status(successRate float64) HealthStatus {
+	switch {
+	case successRate < p.DownBelow:
+		return HealthDown
+	case successRate < p.DegradedBelow:
+		return HealthDegraded
+	default:
+		return HealthHealthy
+	}
+}
+
+type healthOutcome struct {
+	time   time.Time
+	failed bool
+}
+
+// healthTracker keeps the outcomes of the latest operations in a ring.
+type healthTracker struct {
+	mutex    sync.Mutex
+	outcomes [healthOutcomes]healthOutcome
+	next     int
+	count    int
+}
+
+func (t *healthTracker) record(now time.Time, failed bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.outcomes[t.next] = healthOutcome{time: now, failed: failed}
+	t.next = (t.next + 1) % healthOutcomes
+	t.count = min(t.count+1, healthOutcomes)
+}
+
+// successRate returns the share of the operations since the given time that succeeded, and
+// their number.
+func (t *healthTracker) successRate(since time.Time) (float64, int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	var operations, failures int
+	for i := 0; i < t.count; i++ {
+		outcome := t.outcomes[i]
+		if outcome.time.Before(since) {
+			continue
+		}
+		operations++
+		if outcome.failed {
+			failures++
+		}
+	}
+	if operations == 0 {
+		return 1, 0
+	}
+	return float64(operations-failures) / float64(operations), operations
+}
+
+// SetHealthPolicy sets how HealthCheck judges the success rate of recent operations.
+func (m *MinioObjectStore) SetHealthPolicy(policy HealthPolicy) {
+	m.healthPolicy = policy
+}
+
+// recordOutcome records the outcome of an operation for HealthCheck.
+func (m *MinioObjectStore) recordOutcome(err error) {
+	m.health.record(m.now(), ClassifyError(err) == ErrNetwork)
+}
+
+// HealthCheck reports the health of the object store from the success rate of the
+// operations of the window of the health policy, so readiness probes can ride out brief
+// blips and only fail on a sustained outage. If no operation ran in the window, the
+// backend is probed with the stat of a missing key.
+func (m *MinioObjectStore) HealthCheck(ctx context.Context) HealthStatus {
+	policy := m.healthPolicy.withDefaults()
+	since := m.now().Add(-policy.Window)
+	if _, operations := m.health.successRate(since); operations == 0 {
+		m.retry(ctx, func(ctx context.Context) error {
+			_, err := m.minioClient.StatObject(ctx, m.bucketName, path.Join(m.baseFolder, canaryFolder), m.getObjectOptions(ctx))
+			return err
+		})
+	}
+	successRate, _ := m.health.successRate(since)
+	return policy.status(successRate)
+}
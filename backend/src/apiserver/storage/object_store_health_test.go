@@ -0,0 +1,126 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var errServiceUnavailable = minio.ErrorResponse{
+	Code:       "ServiceUnavailable",
+	Message:    "service unavailable",
+	StatusCode: http.StatusServiceUnavailable,
+}
+
+// statFiles stats as many files with the store as there are results, the mock answering
+// each stat with the next result.
+func statFiles(store *MinioObjectStore, minioClient *MockMinioClient, results ...error) {
+	for _, result := range results {
+		minioClient.EXPECT().StatObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any()).
+			Return(minio.ObjectInfo{}, result)
+		store.GetFileInfo(context.TODO(), "pipelines/1")
+	}
+}
+
+func repeat(err error, times int) []error {
+	results := make([]error, times)
+	for i := range results {
+		results[i] = err
+	}
+	return results
+}
+
+func newHealthTestStore(t *testing.T) (*MinioObjectStore, *MockMinioClient, *FakeClock) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	clock := NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines", WithClock(clock))
+	return store, minioClient, clock
+}
+
+func TestHealthCheck_Healthy(t *testing.T) {
+	store, minioClient, _ := newHealthTestStore(t)
+	statFiles(store, minioClient, repeat(nil, 19)...)
+	// Not found files are answered by a reachable backend.
+	statFiles(store, minioClient, newFakeNoSuchKeyError("pipelines/1"))
+
+	assert.Equal(t, HealthHealthy, store.HealthCheck(context.TODO()))
+}
+
+func TestHealthCheck_Degraded(t *testing.T) {
+	store, minioClient, _ := newHealthTestStore(t)
+	statFiles(store, minioClient, repeat(nil, 8)...)
+	statFiles(store, minioClient, repeat(errServiceUnavailable, 2)...)
+
+	assert.Equal(t, HealthDegraded, store.HealthCheck(context.TODO()))
+}
+
+func TestHealthCheck_Down(t *testing.T) {
+	store, minioClient, _ := newHealthTestStore(t)
+	statFiles(store, minioClient, repeat(nil, 2)...)
+	statFiles(store, minioClient, repeat(errServiceUnavailable, 8)...)
+
+	assert.Equal(t, HealthDown, store.HealthCheck(context.TODO()))
+}
+
+func TestHealthCheck_RecoversAfterWindow(t *testing.T) {
+	store, minioClient, clock := newHealthTestStore(t)
+	statFiles(store, minioClient, repeat(errServiceUnavailable, 10)...)
+	assert.Equal(t, HealthDown, store.HealthCheck(context.TODO()))
+
+	clock.Advance(2 * time.Minute)
+	statFiles(store, minioClient, nil)
+
+	assert.Equal(t, HealthHealthy, store.HealthCheck(context.TODO()))
+}
+
+func TestHealthCheck_ProbesWithoutRecentOperations(t *testing.T) {
+	store, minioClient, _ := newHealthTestStore(t)
+	minioClient.EXPECT().StatObject(gomock.Any(), "mlpipeline", "pipelines/.canary", gomock.Any()).
+		Return(minio.ObjectInfo{}, errServiceUnavailable)
+
+	assert.Equal(t, HealthDown, store.HealthCheck(context.TODO()))
+}
+
+func TestHealthCheck_Policy(t *testing.T) {
+	store, minioClient, _ := newHealthTestStore(t)
+	store.SetHealthPolicy(HealthPolicy{DegradedBelow: 0.5, DownBelow: 0.1})
+	statFiles(store, minioClient, repeat(nil, 6)...)
+	statFiles(store, minioClient, repeat(errServiceUnavailable, 4)...)
+
+	assert.Equal(t, HealthHealthy, store.HealthCheck(context.TODO()))
+}
+
+func TestHealthTracker_KeepsLatestOutcomes(t *testing.T) {
+	var tracker healthTracker
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < healthOutcomes; i++ {
+		tracker.record(now, true)
+	}
+	for i := 0; i < healthOutcomes/2; i++ {
+		tracker.record(now, false)
+	}
+
+	successRate, operations := tracker.successRate(now)
+
+	assert.Equal(t, healthOutcomes, operations)
+	assert.Equal(t, 0.5, successRate)
+}
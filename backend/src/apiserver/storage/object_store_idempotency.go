@@ -0,0 +1,71 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/golang/glog"
+	minio "github.com/minio/minio-go/v7"
+)
+
+// idempotencyKeyMetadata is the user metadata recording the idempotency key of the last write.
+const idempotencyKeyMetadata = "Kfp-Idempotency-Key"
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey tags the writes using ctx with an idempotency key. A retried write
+// carrying the key that produced the currently stored object is skipped.
+func WithIdempotencyKey(ctx context.Context, idempotencyKey string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, idempotencyKey)
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	idempotencyKey, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return idempotencyKey
+}
+
+// isDuplicateWrite reports whether the object at key was already written with idempotencyKey.
+func (m *MinioObjectStore) isDuplicateWrite(ctx context.Context, key string, idempotencyKey string) bool {
+	info, err := m.minioClient.StatObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+	if err != nil {
+		return false
+	}
+	if userMetadataValue(info, idempotencyKeyMetadata) != idempotencyKey {
+		return false
+	}
+	glog.Infof("Skipping write of %v: already written with idempotency key %v", key, idempotencyKey)
+	return true
+}
+
+// setUserMetadata adds a user metadata entry to the put options.
+func setUserMetadata(opts *minio.PutObjectOptions, key string, value string) {
+	if opts.UserMetadata == nil {
+		opts.UserMetadata = make(map[string]string)
+	}
+	opts.UserMetadata[key] = value
+}
+
+// userMetadataValue looks up a user metadata entry regardless of how the backend cased its key.
+func userMetadataValue(info minio.ObjectInfo, key string) string {
+	canonicalKey := http.CanonicalHeaderKey(key)
+	for k, v := range info.UserMetadata {
+		if http.CanonicalHeaderKey(k) == canonicalKey {
+			return v
+		}
+	}
+	return ""
+}
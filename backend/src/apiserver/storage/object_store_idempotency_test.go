@@ -0,0 +1,60 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddFile_SameIdempotencyKeySkipped(t *testing.T) {
+	minioClient := newCountingMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	ctx := WithIdempotencyKey(context.TODO(), "request-1")
+
+	require.Nil(t, manager.AddFile(ctx, []byte("abc"), manager.GetPipelineKey("1")))
+	require.Nil(t, manager.AddFile(ctx, []byte("abc"), manager.GetPipelineKey("1")))
+	assert.Equal(t, 1, minioClient.putCount)
+
+	file, err := manager.GetFile(context.TODO(), manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("abc"), file)
+}
+
+func TestAddFile_DifferentIdempotencyKeyWritten(t *testing.T) {
+	minioClient := newCountingMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+
+	require.Nil(t, manager.AddFile(WithIdempotencyKey(context.TODO(), "request-1"), []byte("abc"), manager.GetPipelineKey("1")))
+	require.Nil(t, manager.AddFile(WithIdempotencyKey(context.TODO(), "request-2"), []byte("def"), manager.GetPipelineKey("1")))
+	assert.Equal(t, 2, minioClient.putCount)
+
+	file, err := manager.GetFile(context.TODO(), manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("def"), file)
+}
+
+func TestAddFile_WithoutIdempotencyKeyAlwaysWritten(t *testing.T) {
+	minioClient := newCountingMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("abc"), manager.GetPipelineKey("1")))
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("abc"), manager.GetPipelineKey("1")))
+	assert.Equal(t, 2, minioClient.putCount)
+	assert.Equal(t, 0, minioClient.statCount)
+}
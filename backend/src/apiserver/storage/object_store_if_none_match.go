@@ -0,0 +1,65 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net/http"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+)
+
+// GetFileIfNoneMatch gets the file at filePath unless it is still at the given ETag, in
+// which case the backend does not transfer it and nil and false are returned. Otherwise
+// the content of the file is returned with true, and its new ETag can be read with
+// GetFileInfo. An empty ETag matches no file.
+func (m *MinioObjectStore) GetFileIfNoneMatch(ctx context.Context, filePath string, etag string) ([]byte, bool, error) {
+	if err := m.checkOpen("get file", filePath); err != nil {
+		return nil, false, err
+	}
+	if err := m.checkEnvironment(filePath, false); err != nil {
+		return nil, false, err
+	}
+	opts := m.getObjectOptions(ctx)
+	if etag != "" {
+		opts.SetMatchETagExcept(etag)
+	}
+	var data []byte
+	err := m.retry(ctx, func(ctx context.Context) error {
+		reader, err := m.minioClient.GetObject(ctx, m.bucketName, m.resolveKey(ctx, filePath), opts)
+		if err != nil {
+			return err
+		}
+		defer closeReader(reader)
+		data, err = readObject(reader)
+		return err
+	})
+	if isNotModified(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		m.recordWarningEvent(ctx, eventReasonReadFailed, "Failed to get file %v: %v", filePath, err)
+		return nil, false, newObjectStoreError(err, "Failed to get file %v", filePath)
+	}
+	return m.removeChunkSignatures(data), true, nil
+}
+
+// isNotModified returns whether err is the response of the backend to a conditional read
+// of an object still at the ETag it was conditioned on.
+func isNotModified(err error) bool {
+	var response minio.ErrorResponse
+	return errors.As(err, &response) && response.StatusCode == http.StatusNotModified
+}
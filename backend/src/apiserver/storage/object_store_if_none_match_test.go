@@ -0,0 +1,79 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestGetFileIfNoneMatch_NotModified(t *testing.T) {
+	manager := NewMinioObjectStore(NewFakeMinioClient(), "mlpipeline", "pipelines", false)
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+	info, err := manager.GetFileInfo(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+
+	data, modified, err := manager.GetFileIfNoneMatch(context.TODO(), "pipelines/1", info.ETag)
+
+	require.Nil(t, err)
+	assert.False(t, modified)
+	assert.Nil(t, data)
+}
+
+func TestGetFileIfNoneMatch_Modified(t *testing.T) {
+	manager := NewMinioObjectStore(NewFakeMinioClient(), "mlpipeline", "pipelines", false)
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+	info, err := manager.GetFileInfo(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("new spec"), "pipelines/1"))
+
+	data, modified, err := manager.GetFileIfNoneMatch(context.TODO(), "pipelines/1", info.ETag)
+
+	require.Nil(t, err)
+	assert.True(t, modified)
+	assert.Equal(t, []byte("new spec"), data)
+	newInfo, err := manager.GetFileInfo(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	assert.NotEqual(t, info.ETag, newInfo.ETag)
+	_, modified, err = manager.GetFileIfNoneMatch(context.TODO(), "pipelines/1", newInfo.ETag)
+	require.Nil(t, err)
+	assert.False(t, modified)
+}
+
+func TestGetFileIfNoneMatch_EmptyETag(t *testing.T) {
+	manager := NewMinioObjectStore(NewFakeMinioClient(), "mlpipeline", "pipelines", false)
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+
+	data, modified, err := manager.GetFileIfNoneMatch(context.TODO(), "pipelines/1", "")
+
+	require.Nil(t, err)
+	assert.True(t, modified)
+	assert.Equal(t, []byte("spec"), data)
+}
+
+func TestGetFileIfNoneMatch_NotFound(t *testing.T) {
+	manager := NewMinioObjectStore(NewFakeMinioClient(), "mlpipeline", "pipelines", false)
+
+	_, modified, err := manager.GetFileIfNoneMatch(context.TODO(), "pipelines/1", "etag")
+
+	require.NotNil(t, err)
+	assert.False(t, modified)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
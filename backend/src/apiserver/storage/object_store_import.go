@@ -0,0 +1,162 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// quarantineFolder holds specs rejected during imports, under the base folder.
+	quarantineFolder = "quarantine"
+	// quarantineErrorMetadata is the user metadata recording why a spec was quarantined.
+	quarantineErrorMetadata = "Kfp-Quarantine-Error"
+	// maxQuarantineErrorLength keeps the error annotation well within backend metadata limits.
+	maxQuarantineErrorLength = 512
+)
+
+// SpecValidator checks the content of a spec before it is imported.
+type SpecValidator func(content []byte) error
+
+// ImportFile is a spec to import and the path to store it at.
+type ImportFile struct {
+	FilePath string
+	Content  []byte
+}
+
+// QuarantinedFile is a spec that failed validation during an import.
+type QuarantinedFile struct {
+	FilePath      string
+	QuarantineKey string
+	Error         string
+}
+
+// ImportSummary describes the outcome of an import.
+type ImportSummary struct {
+	Imported    []string
+	Quarantined []QuarantinedFile
+}
+
+// ImportFiles stores a batch of specs. A spec that is not valid YAML, or that validate
+// rejects, is stored under the quarantine folder annotated with the validation error
+// instead, and the import carries on with the rest of the batch. validate may be nil.
+// Only backend failures abort the import.
+func (m *MinioObjectStore) ImportFiles(ctx context.Context, files []ImportFile, validate SpecValidator) (*ImportSummary, error) {
+	summary := &ImportSummary{}
+	for _, file := range files {
+		validationErr := validateSpec(file.Content, validate)
+		if validationErr == nil {
+			if err := m.addBatchFile(ctx, file.Content, file.FilePath); err != nil {
+				return summary, util.Wrapf(err, "Failed to import file %v", file.FilePath)
+			}
+			summary.Imported = append(summary.Imported, file.FilePath)
+			continue
+		}
+
+		quarantined, err := m.quarantineBatchFile(ctx, file, validationErr)
+		if err != nil {
+			return summary, util.Wrapf(err, "Failed to quarantine file %v", file.FilePath)
+		}
+		glog.Warningf("Quarantined file %v as %v: %v", file.FilePath, quarantined.QuarantineKey, quarantined.Error)
+		summary.Quarantined = append(summary.Quarantined, *quarantined)
+	}
+	return summary, nil
+}
+
+// GetQuarantineKey returns the key a spec stored at filePath is quarantined under: its path
+// relative to the base folder, under the quarantine folder of the base folder.
+func (m *MinioObjectStore) GetQuarantineKey(filePath string) string {
+	if m.baseFolder != "" {
+		filePath = strings.TrimPrefix(filePath, strings.TrimSuffix(m.baseFolder, "/")+"/")
+	}
+	return path.Join(m.baseFolder, quarantineFolder, filePath)
+}
+
+// quarantineBatchFile quarantines a file of a batch, within the budget left to the batch.
+func (m *MinioObjectStore) quarantineBatchFile(ctx context.Context, file ImportFile, validationErr error) (*QuarantinedFile, error) {
+	ctx, cancel, err := m.batchOperationContext(ctx, "quarantine file", file.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	return m.quarantineFile(ctx, file, validationErr)
+}
+
+func (m *MinioObjectStore) quarantineFile(ctx context.Context, file ImportFile, validationErr error) (*QuarantinedFile, error) {
+	if err := m.checkOpen("quarantine file", file.FilePath); err != nil {
+		return nil, err
+	}
+	if err := m.checkMaintenance("quarantine file", file.FilePath); err != nil {
+		return nil, err
+	}
+	quarantined := &QuarantinedFile{
+		FilePath:      file.FilePath,
+		QuarantineKey: m.GetQuarantineKey(file.FilePath),
+		Error:         sanitizeMetadataValue(validationErr.Error(), maxQuarantineErrorLength),
+	}
+	if err := m.checkEnvironment(quarantined.QuarantineKey, true); err != nil {
+		return nil, err
+	}
+	key := m.resolveKey(ctx, quarantined.QuarantineKey)
+	if err := m.checkKeyLength("quarantine file", quarantined.QuarantineKey, key); err != nil {
+		return nil, err
+	}
+	if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationWrite, Path: quarantined.QuarantineKey, Size: int64(len(file.Content))}); err != nil {
+		return nil, err
+	}
+	opts := m.putObjectOptions(ctx)
+	setUserMetadata(&opts, quarantineErrorMetadata, quarantined.Error)
+	_, err := m.minioClient.PutObject(
+		ctx,
+		m.bucketName, key, bytes.NewReader(file.Content),
+		int64(len(file.Content)), opts)
+	if err != nil {
+		return nil, newObjectStoreError(err, "Failed to store file %v", quarantined.QuarantineKey)
+	}
+	return quarantined, nil
+}
+
+func validateSpec(content []byte, validate SpecValidator) error {
+	var spec interface{}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		return util.NewInvalidInputError("Invalid YAML: %v", err.Error())
+	}
+	if validate != nil {
+		return validate(content)
+	}
+	return nil
+}
+
+// sanitizeMetadataValue makes value safe to store as object metadata, which only allows
+// printable US-ASCII, and truncates it to maxLength.
+func sanitizeMetadataValue(value string, maxLength int) string {
+	value = strings.Map(func(r rune) rune {
+		if r < ' ' || r > '~' {
+			return ' '
+		}
+		return r
+	}, value)
+	if len(value) > maxLength {
+		value = value[:maxLength]
+	}
+	return value
+}
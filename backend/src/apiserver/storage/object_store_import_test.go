@@ -0,0 +1,91 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func requireFooID(content []byte) error {
+	var foo Foo
+	if err := yaml.Unmarshal(content, &foo); err != nil {
+		return err
+	}
+	if foo.ID == 0 {
+		return errors.New("id is required")
+	}
+	return nil
+}
+
+func TestImportFiles(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+
+	summary, err := manager.ImportFiles(context.TODO(), []ImportFile{
+		{FilePath: manager.GetPipelineKey("1"), Content: []byte("id: 1")},
+		{FilePath: manager.GetPipelineKey("2"), Content: []byte("id: [1")},
+		{FilePath: manager.GetPipelineKey("3"), Content: []byte("name: no-id")},
+		{FilePath: manager.GetPipelineKey("4"), Content: []byte("id: 4")},
+	}, requireFooID)
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"pipeline/1", "pipeline/4"}, summary.Imported)
+	require.Len(t, summary.Quarantined, 2)
+	assert.Equal(t, "pipeline/2", summary.Quarantined[0].FilePath)
+	assert.Equal(t, "pipeline/quarantine/2", summary.Quarantined[0].QuarantineKey)
+	assert.Contains(t, summary.Quarantined[0].Error, "Invalid YAML")
+	assert.Equal(t, "pipeline/3", summary.Quarantined[1].FilePath)
+	assert.Contains(t, summary.Quarantined[1].Error, "id is required")
+
+	assert.True(t, minioClient.ExistObject("pipeline/1"))
+	assert.True(t, minioClient.ExistObject("pipeline/4"))
+	assert.False(t, minioClient.ExistObject("pipeline/2"))
+	assert.False(t, minioClient.ExistObject("pipeline/3"))
+	for _, quarantined := range summary.Quarantined {
+		info, err := minioClient.StatObject(context.TODO(), "", quarantined.QuarantineKey, minio.StatObjectOptions{})
+		require.Nil(t, err)
+		assert.Equal(t, quarantined.Error, userMetadataValue(info, quarantineErrorMetadata))
+	}
+}
+
+func TestImportFilesError(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipeline"}
+	summary, err := manager.ImportFiles(context.TODO(), []ImportFile{
+		{FilePath: manager.GetPipelineKey("1"), Content: []byte("id: 1")},
+	}, nil)
+	assert.NotNil(t, err)
+	assert.Empty(t, summary.Imported)
+}
+
+func TestSanitizeMetadataValue(t *testing.T) {
+	assert.Equal(t, "line one line two caf  ", sanitizeMetadataValue("line one\nline two café\t", 100))
+	assert.Equal(t, "abc", sanitizeMetadataValue("abcdef", 3))
+}
+
+func TestGetQuarantineKey(t *testing.T) {
+	manager := &MinioObjectStore{baseFolder: "pipelines"}
+	assert.Equal(t, "pipelines/quarantine/x", manager.GetQuarantineKey("pipelines/x"))
+	assert.Equal(t, "pipelines/quarantine/x", manager.GetQuarantineKey("x"))
+	assert.Equal(t, "pipelines/quarantine/pipelines-old/x", manager.GetQuarantineKey("pipelines-old/x"))
+	manager = &MinioObjectStore{}
+	assert.Equal(t, "quarantine/pipelines/x", manager.GetQuarantineKey("pipelines/x"))
+}
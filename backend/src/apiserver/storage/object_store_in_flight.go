@@ -0,0 +1,62 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// SetMaxInFlightBytes caps the bytes the files stored with AddFile and read with GetFile
+// take up at once, protecting the memory and network of the node during bursts. Once the
+// cap is reached, operations wait for enough earlier ones to complete, or for their
+// context to be done, and are admitted by the priority set with WithPriority. Files
+// larger than the cap wait for every other operation to complete. Reads stat the file
+// first to learn its size. Zero or less disables the cap.
+func (m *MinioObjectStore) SetMaxInFlightBytes(maxBytes int64) {
+	m.maxInFlightBytes = maxBytes
+	m.inFlightBytes = nil
+	if maxBytes > 0 {
+		m.inFlightBytes = newPrioritySemaphore(maxBytes)
+	}
+}
+
+// reserveInFlightBytes waits until size more bytes may be in flight, and returns the
+// function releasing them once the operation completes.
+func (m *MinioObjectStore) reserveInFlightBytes(ctx context.Context, operation string, filePath string, size int64) (func(), error) {
+	if m.inFlightBytes == nil || size <= 0 {
+		return func() {}, nil
+	}
+	size = min(size, m.maxInFlightBytes)
+	if err := m.inFlightBytes.Acquire(ctx, size, priorityFromContext(ctx)); err != nil {
+		return nil, util.NewUnavailableServerError(err,
+			"Failed to %v %v: gave up waiting for other transfers to complete", operation, filePath)
+	}
+	return func() { m.inFlightBytes.Release(size) }, nil
+}
+
+// reserveReadInFlightBytes is reserveInFlightBytes for reading the file at filePath. If
+// the file cannot be stat-ed, nothing is reserved and the read reports the failure.
+func (m *MinioObjectStore) reserveReadInFlightBytes(ctx context.Context, filePath string) (func(), error) {
+	if m.inFlightBytes == nil {
+		return func() {}, nil
+	}
+	info, err := m.minioClient.StatObject(ctx, m.bucketName, m.resolveKey(ctx, filePath), m.getObjectOptions(ctx))
+	if err != nil {
+		return func() {}, nil
+	}
+	return m.reserveInFlightBytes(ctx, "get file", filePath, info.Size)
+}
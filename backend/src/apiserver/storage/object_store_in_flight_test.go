@@ -0,0 +1,159 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// blockingPutMinioClient holds the puts of the files in blocked until their channel is
+// closed, reporting on started that they reached the client.
+type blockingPutMinioClient struct {
+	*FakeMinioClient
+	blocked map[string]chan struct{}
+	started chan string
+}
+
+func (c *blockingPutMinioClient) PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader,
+	objectSize int64, opts minio.PutObjectOptions,
+) (int64, error) {
+	c.started <- objectName
+	if unblock, ok := c.blocked[objectName]; ok {
+		<-unblock
+	}
+	return c.FakeMinioClient.PutObject(ctx, bucketName, objectName, reader, objectSize, opts)
+}
+
+func newBlockingPutStore(maxInFlightBytes int64, blocked ...string) (*MinioObjectStore, *blockingPutMinioClient) {
+	minioClient := &blockingPutMinioClient{
+		FakeMinioClient: NewFakeMinioClient(),
+		blocked:         make(map[string]chan struct{}),
+		started:         make(chan string, 10),
+	}
+	for _, key := range blocked {
+		minioClient.blocked[key] = make(chan struct{})
+	}
+	store := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines", WithMaxInFlightBytes(maxInFlightBytes))
+	return store, minioClient
+}
+
+func assertNotStarted(t *testing.T, minioClient *blockingPutMinioClient) {
+	select {
+	case key := <-minioClient.started:
+		t.Fatalf("put of %v started while the in-flight bytes were at the cap", key)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestMaxInFlightBytes_BlocksUntilCompletion(t *testing.T) {
+	store, minioClient := newBlockingPutStore(100, "pipelines/large")
+	largeDone := make(chan error)
+	go func() {
+		largeDone <- store.AddFile(context.TODO(), bytes.Repeat([]byte("a"), 80), "pipelines/large")
+	}()
+	require.Equal(t, "pipelines/large", <-minioClient.started)
+
+	smallDone := make(chan error)
+	go func() {
+		smallDone <- store.AddFile(context.TODO(), bytes.Repeat([]byte("b"), 40), "pipelines/small")
+	}()
+	assertNotStarted(t, minioClient)
+
+	close(minioClient.blocked["pipelines/large"])
+	require.Nil(t, <-largeDone)
+	assert.Equal(t, "pipelines/small", <-minioClient.started)
+	require.Nil(t, <-smallDone)
+}
+
+func TestMaxInFlightBytes_UnderCapRunsConcurrently(t *testing.T) {
+	store, minioClient := newBlockingPutStore(100, "pipelines/1")
+	done := make(chan error)
+	go func() {
+		done <- store.AddFile(context.TODO(), bytes.Repeat([]byte("a"), 50), "pipelines/1")
+	}()
+	require.Equal(t, "pipelines/1", <-minioClient.started)
+
+	require.Nil(t, store.AddFile(context.TODO(), bytes.Repeat([]byte("b"), 50), "pipelines/2"))
+
+	close(minioClient.blocked["pipelines/1"])
+	require.Nil(t, <-done)
+}
+
+func TestMaxInFlightBytes_CancellationUnblocksWaiter(t *testing.T) {
+	store, minioClient := newBlockingPutStore(100, "pipelines/large")
+	largeDone := make(chan error)
+	go func() {
+		largeDone <- store.AddFile(context.TODO(), bytes.Repeat([]byte("a"), 100), "pipelines/large")
+	}()
+	require.Equal(t, "pipelines/large", <-minioClient.started)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	waiterDone := make(chan error)
+	go func() {
+		waiterDone <- store.AddFile(ctx, []byte("b"), "pipelines/small")
+	}()
+	assertNotStarted(t, minioClient)
+	cancel()
+
+	err := <-waiterDone
+	require.NotNil(t, err)
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+	assert.False(t, minioClient.ExistObject("pipelines/small"))
+	close(minioClient.blocked["pipelines/large"])
+	require.Nil(t, <-largeDone)
+}
+
+func TestMaxInFlightBytes_LargerThanCap(t *testing.T) {
+	store, _ := newBlockingPutStore(10)
+
+	require.Nil(t, store.AddFile(context.TODO(), bytes.Repeat([]byte("a"), 100), "pipelines/1"))
+	data, err := store.GetFile(context.TODO(), "pipelines/1")
+
+	require.Nil(t, err)
+	assert.Len(t, data, 100)
+}
+
+func TestMaxInFlightBytes_ReadsWaitForWrites(t *testing.T) {
+	store, minioClient := newBlockingPutStore(100, "pipelines/large")
+	require.Nil(t, store.AddFile(context.TODO(), bytes.Repeat([]byte("a"), 50), "pipelines/1"))
+	<-minioClient.started
+	largeDone := make(chan error)
+	go func() {
+		largeDone <- store.AddFile(context.TODO(), bytes.Repeat([]byte("b"), 80), "pipelines/large")
+	}()
+	require.Equal(t, "pipelines/large", <-minioClient.started)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+	defer cancel()
+	_, err := store.GetFile(ctx, "pipelines/1")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+
+	close(minioClient.blocked["pipelines/large"])
+	require.Nil(t, <-largeDone)
+	data, err := store.GetFile(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	assert.Len(t, data, 50)
+}
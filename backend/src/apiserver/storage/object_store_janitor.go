@@ -0,0 +1,190 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Cleanups run by the janitor, as reported in the janitor metrics.
+const (
+	janitorCleanupRecycleBin       = "recycle_bin"
+	janitorCleanupCanaries         = "canaries"
+	janitorCleanupIncompleteUpload = "incomplete_uploads"
+)
+
+var objectStoreJanitorRemoved = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "object_store_janitor_removed_total",
+	Help: "The number of expired objects removed by the object store janitor, by cleanup",
+}, []string{"cleanup"})
+
+var objectStoreJanitorFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "object_store_janitor_failures_total",
+	Help: "The number of failed object store janitor cleanups, by cleanup",
+}, []string{"cleanup"})
+
+// PurgeIncompleteUploads aborts the multipart uploads of the store initiated more than
+// olderThan ago and never completed, whose parts the backend otherwise keeps, and returns
+// the number of objects whose uploads were aborted. Every incomplete upload of such an
+// object is aborted, as the backend cannot abort them one at a time.
+func (m *MinioObjectStore) PurgeIncompleteUploads(ctx context.Context, olderThan time.Duration) (int, error) {
+	prefix := m.transformPrefix("")
+	if err := m.checkOpen("purge incomplete uploads under", prefix); err != nil {
+		return 0, err
+	}
+	if err := m.checkMaintenance("purge incomplete uploads under", prefix); err != nil {
+		return 0, err
+	}
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	cutoff := m.now().Add(-olderThan)
+	var expired, expiredPaths []string
+	seen := make(map[string]bool)
+	for upload := range m.minioClient.ListIncompleteUploads(listCtx, m.bucketName, prefix, true) {
+		if upload.Err != nil {
+			return 0, newObjectStoreError(upload.Err, "Failed to list incomplete uploads")
+		}
+		// Skips the uploads of other applications sharing the bucket.
+		filePath, ok := m.logicalKey(upload.Key)
+		if !ok || seen[upload.Key] || !upload.Initiated.Before(cutoff) {
+			continue
+		}
+		seen[upload.Key] = true
+		expired = append(expired, upload.Key)
+		expiredPaths = append(expiredPaths, filePath)
+	}
+	for i, key := range expired {
+		if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationDelete, Path: expiredPaths[i], Size: -1}); err != nil {
+			return i, err
+		}
+		if err := m.minioClient.RemoveIncompleteUpload(ctx, m.bucketName, key); err != nil {
+			return i, newObjectStoreError(err, "Failed to abort incomplete uploads of %v", key)
+		}
+	}
+	return len(expired), nil
+}
+
+// JanitorIntervals sets how often the janitor runs, and how old the objects each cleanup
+// removes must be. A zero age skips the cleanup.
+type JanitorIntervals struct {
+	// Interval is the time between two passes of the janitor.
+	Interval time.Duration
+	// RecycleBinTTL is how long soft-deleted files stay in the recycle bin.
+	RecycleBinTTL time.Duration
+	// CanaryTTL is the age past which canary objects are deemed left behind by a probe.
+	CanaryTTL time.Duration
+	// IncompleteUploadTTL is the age past which multipart uploads are deemed abandoned.
+	IncompleteUploadTTL time.Duration
+}
+
+// JanitorReport counts the objects removed by a janitor pass, by cleanup.
+type JanitorReport struct {
+	RecycleBinPurged         int
+	CanariesPurged           int
+	IncompleteUploadsAborted int
+}
+
+// Janitor periodically removes the expired objects left behind by the features of the
+// store: soft-deleted files, canaries of interrupted probes and abandoned multipart uploads.
+type Janitor struct {
+	store     *MinioObjectStore
+	intervals JanitorIntervals
+
+	mutex  sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewJanitor creates a janitor cleaning up store according to intervals.
+func NewJanitor(store *MinioObjectStore, intervals JanitorIntervals) *Janitor {
+	return &Janitor{store: store, intervals: intervals}
+}
+
+// Start runs a janitor pass every interval in the background, until Stop is called or ctx
+// is cancelled. Starting a started janitor is a no-op.
+func (j *Janitor) Start(ctx context.Context) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	if j.cancel != nil {
+		return
+	}
+	ctx, j.cancel = context.WithCancel(ctx)
+	j.done = make(chan struct{})
+	go j.run(ctx, j.done)
+}
+
+// Stop stops the janitor, waiting for the pass in progress to be abandoned.
+func (j *Janitor) Stop() {
+	j.mutex.Lock()
+	cancel, done := j.cancel, j.done
+	j.cancel, j.done = nil, nil
+	j.mutex.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+func (j *Janitor) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	ticks, stop := j.store.newTicker(j.intervals.Interval)
+	defer stop()
+	for {
+		report := j.Clean(ctx)
+		glog.Infof("Cleaned up the object store: %v files purged from the recycle bin, %v canaries purged, "+
+			"incomplete uploads of %v files aborted", report.RecycleBinPurged, report.CanariesPurged, report.IncompleteUploadsAborted)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticks:
+		}
+	}
+}
+
+// Clean runs a single janitor pass. A failed cleanup is logged and does not stop the
+// pass, the other cleanups still run.
+func (j *Janitor) Clean(ctx context.Context) JanitorReport {
+	var report JanitorReport
+	report.RecycleBinPurged = j.cleanup(ctx, janitorCleanupRecycleBin, j.intervals.RecycleBinTTL, j.store.PurgeRecycleBin)
+	report.CanariesPurged = j.cleanup(ctx, janitorCleanupCanaries, j.intervals.CanaryTTL, j.store.PurgeStaleCanaries)
+	report.IncompleteUploadsAborted = j.cleanup(ctx, janitorCleanupIncompleteUpload, j.intervals.IncompleteUploadTTL,
+		j.store.PurgeIncompleteUploads)
+	return report
+}
+
+// cleanup runs purge for the objects older than ttl, unless ttl is zero, and returns the
+// number of objects it removed.
+func (j *Janitor) cleanup(ctx context.Context, cleanup string, ttl time.Duration,
+	purge func(ctx context.Context, olderThan time.Duration) (int, error),
+) int {
+	if ttl <= 0 {
+		return 0
+	}
+	removed, err := purge(ctx, ttl)
+	objectStoreJanitorRemoved.WithLabelValues(cleanup).Add(float64(removed))
+	if err != nil {
+		objectStoreJanitorFailures.WithLabelValues(cleanup).Inc()
+		glog.Warningf("Failed to run the %v cleanup of the object store: %v", cleanup, err)
+	}
+	return removed
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var janitorTestIntervals = JanitorIntervals{
+	Interval:            time.Hour,
+	RecycleBinTTL:       7 * 24 * time.Hour,
+	CanaryTTL:           time.Hour,
+	IncompleteUploadTTL: 24 * time.Hour,
+}
+
+func newJanitorTestStore() (*MinioObjectStore, *FakeMinioClient, *FakeClock) {
+	clock := NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	minioClient := NewFakeMinioClient()
+	minioClient.SetClock(clock)
+	store := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines",
+		WithSoftDelete(true), WithClock(clock))
+	return store, minioClient, clock
+}
+
+// leaveCanary stores a canary object, as a probe interrupted before deleting it would.
+func leaveCanary(t *testing.T, store *MinioObjectStore, name string) {
+	require.Nil(t, store.AddFile(context.TODO(), canaryContent, path.Join("pipelines", canaryFolder, name)))
+}
+
+func TestJanitor_Clean(t *testing.T) {
+	store, minioClient, clock := newJanitorTestStore()
+	// Expired objects of every kind.
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/deleted-long-ago"))
+	require.Nil(t, store.DeleteFile(context.TODO(), "pipelines/deleted-long-ago"))
+	minioClient.StartIncompleteUpload("pipelines/abandoned")
+	leaveCanary(t, store, "stale")
+	clock.Advance(8 * 24 * time.Hour)
+	// Recent objects of every kind.
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/deleted-recently"))
+	require.Nil(t, store.DeleteFile(context.TODO(), "pipelines/deleted-recently"))
+	minioClient.StartIncompleteUpload("pipelines/in-progress")
+	leaveCanary(t, store, "fresh")
+
+	report := NewJanitor(store, janitorTestIntervals).Clean(context.TODO())
+
+	assert.Equal(t, JanitorReport{RecycleBinPurged: 1, CanariesPurged: 1, IncompleteUploadsAborted: 1}, report)
+	assert.False(t, minioClient.ExistObject("pipelines/.recyclebin/pipelines/deleted-long-ago"))
+	assert.True(t, minioClient.ExistObject("pipelines/.recyclebin/pipelines/deleted-recently"))
+	assert.False(t, minioClient.ExistObject("pipelines/.canary/stale"))
+	assert.True(t, minioClient.ExistObject("pipelines/.canary/fresh"))
+	// Canaries are not moved to the recycle bin.
+	assert.False(t, minioClient.ExistObject("pipelines/.recyclebin/pipelines/.canary/stale"))
+	assert.Equal(t, 1, minioClient.IncompleteUploadCount())
+}
+
+func TestJanitor_CleanSkipsDisabledCleanups(t *testing.T) {
+	store, minioClient, clock := newJanitorTestStore()
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+	require.Nil(t, store.DeleteFile(context.TODO(), "pipelines/1"))
+	minioClient.StartIncompleteUpload("pipelines/2")
+	leaveCanary(t, store, "stale")
+	clock.Advance(30 * 24 * time.Hour)
+
+	report := NewJanitor(store, JanitorIntervals{Interval: time.Hour, CanaryTTL: time.Hour}).Clean(context.TODO())
+
+	assert.Equal(t, JanitorReport{CanariesPurged: 1}, report)
+	assert.True(t, minioClient.ExistObject("pipelines/.recyclebin/pipelines/1"))
+	assert.Equal(t, 1, minioClient.IncompleteUploadCount())
+}
+
+func TestJanitor_CleanContinuesPastFailures(t *testing.T) {
+	clock := NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := NewMinioObjectStoreWithOptions(&FakeBadMinioClient{}, "mlpipeline", "pipelines", WithClock(clock))
+
+	report := NewJanitor(store, janitorTestIntervals).Clean(context.TODO())
+
+	assert.Equal(t, JanitorReport{}, report)
+}
+
+func TestPurgeIncompleteUploads_SkipsOtherApplications(t *testing.T) {
+	clock := NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	minioClient := NewFakeMinioClient()
+	minioClient.SetClock(clock)
+	store := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines",
+		WithClock(clock), WithKeyTransform(NewAffixKeyTransform("kfp/", ".kfp")))
+	minioClient.StartIncompleteUpload("kfp/pipelines/1.kfp")
+	minioClient.StartIncompleteUpload("kfp/pipelines/1.kfp")
+	minioClient.StartIncompleteUpload("kfp/other")
+	clock.Advance(48 * time.Hour)
+
+	aborted, err := store.PurgeIncompleteUploads(context.TODO(), 24*time.Hour)
+
+	require.Nil(t, err)
+	assert.Equal(t, 1, aborted)
+	assert.Equal(t, 1, minioClient.IncompleteUploadCount())
+}
+
+func TestJanitor_StartStop(t *testing.T) {
+	store, _, clock := newJanitorTestStore()
+	leaveCanary(t, store, "stale")
+	clock.Advance(2 * time.Hour)
+	janitor := NewJanitor(store, janitorTestIntervals)
+
+	janitor.Start(context.TODO())
+	janitor.Start(context.TODO())
+	assert.Eventually(t, func() bool {
+		_, err := store.GetFileInfo(context.TODO(), "pipelines/.canary/stale")
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	janitor.Stop()
+	janitor.Stop()
+}
+
+func TestJanitor_PassesTimedByClock(t *testing.T) {
+	store, _, clock := newJanitorTestStore()
+	janitor := NewJanitor(store, janitorTestIntervals)
+	leaveCanary(t, store, "first")
+	clock.Advance(2 * time.Hour)
+	janitor.Start(context.TODO())
+	defer janitor.Stop()
+	assert.Eventually(t, func() bool {
+		_, err := store.GetFileInfo(context.TODO(), "pipelines/.canary/first")
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The next pass only runs once the clock reaches it.
+	leaveCanary(t, store, "second")
+	clock.Advance(2 * time.Hour)
+	assert.Eventually(t, func() bool {
+		_, err := store.GetFileInfo(context.TODO(), "pipelines/.canary/second")
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
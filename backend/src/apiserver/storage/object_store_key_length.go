@@ -0,0 +1,49 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+// defaultMaxKeyLength is the S3 limit on the length of object keys, in bytes.
+const defaultMaxKeyLength = 1024
+
+// ErrKeyTooLong is the cause of errors returned for writes to keys longer than the limit.
+var ErrKeyTooLong = errors.New("object key too long")
+
+// SetMaxKeyLength sets the maximum length, in bytes, of the keys files are written to.
+// Writes to longer keys are rejected before reaching the backend. Zero restores the
+// default, the S3 limit of 1024 bytes.
+func (m *MinioObjectStore) SetMaxKeyLength(maxKeyLength int) {
+	m.maxKeyLength = maxKeyLength
+}
+
+// checkKeyLength returns an error if key, the backend key filePath resolves to, is too long
+// to be written.
+func (m *MinioObjectStore) checkKeyLength(operation string, filePath string, key string) error {
+	maxKeyLength := m.maxKeyLength
+	if maxKeyLength <= 0 {
+		maxKeyLength = defaultMaxKeyLength
+	}
+	if len(key) > maxKeyLength {
+		return util.NewInvalidInputErrorWithDetails(ErrKeyTooLong,
+			fmt.Sprintf("Failed to %v %v: key is %v bytes long, the limit is %v bytes", operation, filePath, len(key), maxKeyLength))
+	}
+	return nil
+}
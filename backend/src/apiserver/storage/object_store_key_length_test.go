@@ -0,0 +1,87 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+)
+
+func assertKeyTooLongError(t *testing.T, err error) {
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrKeyTooLong))
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestAddFile_KeyLength(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	filePath := strings.Repeat("a", defaultMaxKeyLength)
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), filePath))
+	data, err := manager.GetFile(context.TODO(), filePath)
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+}
+
+func TestAddFile_KeyTooLong(t *testing.T) {
+	// No calls are expected, the key is rejected before reaching the backend.
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	filePath := strings.Repeat("a", defaultMaxKeyLength+1)
+
+	err := manager.AddFile(context.TODO(), []byte("spec"), filePath)
+	assertKeyTooLongError(t, err)
+	assert.Contains(t, err.Error(), "1025 bytes long, the limit is 1024 bytes")
+	assertKeyTooLongError(t, manager.AddFileFromReader(context.TODO(), bytes.NewReader([]byte("spec")), filePath, false))
+	assertKeyTooLongError(t, manager.MoveFile(context.TODO(), "pipelines/1", filePath))
+	_, err = manager.IncrementCounter(context.TODO(), filePath)
+	assertKeyTooLongError(t, err)
+}
+
+func TestAddFile_KeyTooLong_Configured(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines", WithMaxKeyLength(12))
+	assert.Equal(t, 12, manager.Config().MaxKeyLength)
+
+	assertKeyTooLongError(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines/123"))
+}
+
+func TestAddFile_KeyTooLong_Namespaced(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	manager.SetMaxKeyLength(12)
+	manager.SetPrefixRewrites([]PrefixRewrite{{From: "pipelines/", To: "archive/pipelines/"}})
+
+	// The limit applies to the key after rewrites.
+	assertKeyTooLongError(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+}
+
+func TestDeleteFile_SoftDelete_KeyTooLong(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	manager.SetSoftDelete(true)
+
+	assertKeyTooLongError(t, manager.DeleteFile(context.TODO(), strings.Repeat("a", defaultMaxKeyLength)))
+}
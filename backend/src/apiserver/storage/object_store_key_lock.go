@@ -0,0 +1,72 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"hash/fnv"
+	"slices"
+	"sync"
+)
+
+// keyLockShards is the number of mutexes keys are spread over. Keys sharing a shard are
+// serialized with each other, which only costs concurrency.
+const keyLockShards = 64
+
+// keyLocks is a sharded set of per-key mutexes. Its zero value is ready to use.
+type keyLocks struct {
+	shards [keyLockShards]sync.Mutex
+}
+
+func (l *keyLocks) shard(key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % keyLockShards)
+}
+
+type heldKeyLocksContextKey struct{}
+
+// WithKeyLock runs fn while holding the in-process lock of the file, so read-modify-write
+// flows on the same file never interleave within the apiserver. Flows on different files
+// run concurrently. AddFile, AddFileFromReader, DeleteFile and MoveFile take the locks of
+// the files they change, so fn may call them on the file, and may nest WithKeyLock calls
+// for it, but must not lock other files, which could deadlock. The lock does not
+// coordinate separate processes.
+func (m *MinioObjectStore) WithKeyLock(ctx context.Context, filePath string, fn func(ctx context.Context) error) error {
+	return m.withKeyLocks(ctx, []string{filePath}, fn)
+}
+
+// withKeyLocks runs fn while holding the locks of all the files. The locks are taken in
+// shard order, so callers locking overlapping files never deadlock each other. Locks
+// already held by ctx are not taken again.
+func (m *MinioObjectStore) withKeyLocks(ctx context.Context, filePaths []string, fn func(ctx context.Context) error) error {
+	held, _ := ctx.Value(heldKeyLocksContextKey{}).([]int)
+	var shards []int
+	for _, filePath := range filePaths {
+		shard := m.keyLocks.shard(m.resolveKey(ctx, filePath))
+		if !slices.Contains(held, shard) && !slices.Contains(shards, shard) {
+			shards = append(shards, shard)
+		}
+	}
+	if len(shards) == 0 {
+		return fn(ctx)
+	}
+	slices.Sort(shards)
+	for _, shard := range shards {
+		m.keyLocks.shards[shard].Lock()
+		defer m.keyLocks.shards[shard].Unlock()
+	}
+	return fn(context.WithValue(ctx, heldKeyLocksContextKey{}, append(slices.Clip(held), shards...)))
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import "strings"
+
+// KeyTransform maps the logical keys of the store to the keys its objects are stored
+// under, e.g. to confine KFP to a layout agreed with another application sharing the
+// bucket.
+type KeyTransform interface {
+	// Key returns the key the object at logicalKey is stored under.
+	Key(logicalKey string) string
+	// Prefix returns the prefix of the keys of all the objects whose logical key starts
+	// with logicalPrefix, which listings list under.
+	Prefix(logicalPrefix string) string
+	// LogicalKey returns the logical key of the object stored under key, or false if key
+	// is not the key of a logical key, e.g. because another application owns the object.
+	LogicalKey(key string) (string, bool)
+}
+
+// affixKeyTransform stores every object under a key made of its logical key between a
+// fixed prefix and suffix.
+type affixKeyTransform struct {
+	prefix string
+	suffix string
+}
+
+// NewAffixKeyTransform returns the transform storing every object under prefix, with a
+// key ending with suffix, e.g. "kfp/" and ".kfp".
+func NewAffixKeyTransform(prefix string, suffix string) KeyTransform {
+	return affixKeyTransform{prefix: prefix, suffix: suffix}
+}
+
+func (t affixKeyTransform) Key(logicalKey string) string {
+	return t.prefix + logicalKey + t.suffix
+}
+
+func (t affixKeyTransform) Prefix(logicalPrefix string) string {
+	return t.prefix + logicalPrefix
+}
+
+func (t affixKeyTransform) LogicalKey(key string) (string, bool) {
+	if len(key) < len(t.prefix)+len(t.suffix) || !strings.HasPrefix(key, t.prefix) || !strings.HasSuffix(key, t.suffix) {
+		return "", false
+	}
+	return key[len(t.prefix) : len(key)-len(t.suffix)], true
+}
+
+// SetKeyTransform maps every key the store reads and writes through transform, and the
+// keys listed back through its inverse, skipping the objects it did not store. The recycle
+// bin, canaries, counters and every other object the store writes are transformed too, so
+// the store never writes outside the layout of the transform. A nil transform, the
+// default, stores objects under their logical key.
+func (m *MinioObjectStore) SetKeyTransform(transform KeyTransform) {
+	m.keyTransform = transform
+}
+
+// transformKey returns the key the object at the logical key is stored under.
+func (m *MinioObjectStore) transformKey(key string) string {
+	if m.keyTransform == nil {
+		return key
+	}
+	return m.keyTransform.Key(key)
+}
+
+// transformPrefix returns the prefix to list the objects under the logical prefix with.
+func (m *MinioObjectStore) transformPrefix(prefix string) string {
+	if m.keyTransform == nil {
+		return prefix
+	}
+	return m.keyTransform.Prefix(prefix)
+}
+
+// logicalKey returns the logical key of the object listed under key, or false if it was
+// not stored by the store.
+func (m *MinioObjectStore) logicalKey(key string) (string, bool) {
+	if m.keyTransform == nil {
+		return key, true
+	}
+	return m.keyTransform.LogicalKey(key)
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newKeyTransformTestStore() (*MinioObjectStore, *FakeMinioClient) {
+	minioClient := NewFakeMinioClient()
+	store := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines",
+		WithKeyTransform(NewAffixKeyTransform("kfp/", ".kfp")))
+	return store, minioClient
+}
+
+// putOtherAppObject stores an object like the application sharing the bucket would.
+func putOtherAppObject(t *testing.T, minioClient *FakeMinioClient, key string) {
+	_, err := minioClient.PutObject(context.TODO(), "mlpipeline", key, bytes.NewReader([]byte("other")), 5,
+		minio.PutObjectOptions{})
+	require.Nil(t, err)
+}
+
+func TestAffixKeyTransform(t *testing.T) {
+	transform := NewAffixKeyTransform("kfp/", ".kfp")
+
+	assert.Equal(t, "kfp/pipelines/1.kfp", transform.Key("pipelines/1"))
+	assert.Equal(t, "kfp/pipelines/", transform.Prefix("pipelines/"))
+	key, ok := transform.LogicalKey("kfp/pipelines/1.kfp")
+	assert.True(t, ok)
+	assert.Equal(t, "pipelines/1", key)
+	for _, key := range []string{"pipelines/1.kfp", "kfp/pipelines/1", "other/1", "kfp/.kf", ""} {
+		_, ok := transform.LogicalKey(key)
+		assert.False(t, ok, key)
+	}
+}
+
+func TestKeyTransform_WriteAndRead(t *testing.T) {
+	store, minioClient := newKeyTransformTestStore()
+
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+
+	assert.True(t, minioClient.ExistObject("kfp/pipelines/1.kfp"))
+	assert.False(t, minioClient.ExistObject("pipelines/1"))
+	data, err := store.GetFile(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+	info, err := store.GetFileInfo(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	assert.Equal(t, "pipelines/1", info.Key)
+
+	require.Nil(t, store.DeleteFile(context.TODO(), "pipelines/1"))
+	assert.Equal(t, 0, minioClient.GetObjectCount())
+}
+
+func TestKeyTransform_OtherAppObjectsUntouched(t *testing.T) {
+	store, minioClient := newKeyTransformTestStore()
+	putOtherAppObject(t, minioClient, "pipelines/1")
+
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+	data, err := store.GetFile(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	require.Nil(t, store.DeleteFile(context.TODO(), "pipelines/1"))
+
+	assert.Equal(t, []byte("spec"), data)
+	assert.True(t, minioClient.ExistObject("pipelines/1"))
+}
+
+func TestKeyTransform_ListingRecoversLogicalKeys(t *testing.T) {
+	store, minioClient := newKeyTransformTestStore()
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/sub/2"))
+	// Objects of the other application, inside and outside the layout of KFP.
+	putOtherAppObject(t, minioClient, "pipelines/3")
+	putOtherAppObject(t, minioClient, "kfp/pipelines/4")
+
+	files, err := store.ListFilesModifiedSince(context.TODO(), "pipelines/", time.Time{})
+
+	require.Nil(t, err)
+	var keys []string
+	for _, file := range files {
+		keys = append(keys, file.Key)
+	}
+	assert.Equal(t, []string{"pipelines/1", "pipelines/sub/2"}, keys)
+	for _, key := range keys {
+		_, err := store.GetFile(context.TODO(), key)
+		assert.Nil(t, err, key)
+	}
+}
+
+func TestKeyTransform_FindOrphans(t *testing.T) {
+	store, minioClient := newKeyTransformTestStore()
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/2"))
+	putOtherAppObject(t, minioClient, "kfp/pipelines/3")
+
+	orphans, err := store.ReapOrphans(context.TODO(), func(pipelineID string) bool { return pipelineID == "1" })
+
+	require.Nil(t, err)
+	assert.Equal(t, []string{"pipelines/2"}, orphans)
+	assert.True(t, minioClient.ExistObject("kfp/pipelines/1.kfp"))
+	assert.False(t, minioClient.ExistObject("kfp/pipelines/2.kfp"))
+	assert.True(t, minioClient.ExistObject("kfp/pipelines/3"))
+}
+
+// writeRecordingMinioClient records the keys of every object written, deleted or updated.
+type writeRecordingMinioClient struct {
+	*FakeMinioClient
+	mutex   sync.Mutex
+	written []string
+}
+
+func (c *writeRecordingMinioClient) record(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.written = append(c.written, key)
+}
+
+func (c *writeRecordingMinioClient) PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader,
+	objectSize int64, opts minio.PutObjectOptions,
+) (int64, error) {
+	c.record(objectName)
+	return c.FakeMinioClient.PutObject(ctx, bucketName, objectName, reader, objectSize, opts)
+}
+
+func (c *writeRecordingMinioClient) CopyObject(ctx context.Context, dst minio.CopyDestOptions,
+	src minio.CopySrcOptions,
+) (minio.UploadInfo, error) {
+	c.record(dst.Object)
+	return c.FakeMinioClient.CopyObject(ctx, dst, src)
+}
+
+func (c *writeRecordingMinioClient) DeleteObject(ctx context.Context, bucketName, objectName string) error {
+	c.record(objectName)
+	return c.FakeMinioClient.DeleteObject(ctx, bucketName, objectName)
+}
+
+func (c *writeRecordingMinioClient) PutObjectTagging(ctx context.Context, bucketName, objectName string,
+	otags *tags.Tags, opts minio.PutObjectTaggingOptions,
+) error {
+	c.record(objectName)
+	return c.FakeMinioClient.PutObjectTagging(ctx, bucketName, objectName, otags, opts)
+}
+
+func (c *writeRecordingMinioClient) PutObjectRetention(ctx context.Context, bucketName, objectName string,
+	opts minio.PutObjectRetentionOptions,
+) error {
+	c.record(objectName)
+	return c.FakeMinioClient.PutObjectRetention(ctx, bucketName, objectName, opts)
+}
+
+func TestKeyTransform_NoWritesOutsideTransform(t *testing.T) {
+	minioClient := &writeRecordingMinioClient{FakeMinioClient: NewFakeMinioClient()}
+	store := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines",
+		WithKeyTransform(NewAffixKeyTransform("kfp/", ".kfp")),
+		WithSoftDelete(true),
+		WithGzipVariants(true),
+		WithAccessTracking(time.Hour))
+	ctx := context.TODO()
+
+	require.Nil(t, store.AddFile(ctx, []byte("spec"), "pipelines/1"))
+	require.Nil(t, store.AddAsYamlFile(ctx, map[string]string{"name": "hello"}, "pipelines/2"))
+	require.Nil(t, store.AddFileFromReader(ctx, bytes.NewReader([]byte("spec")), "pipelines/3", true))
+	_, err := store.GetFile(ctx, "pipelines/1")
+	require.Nil(t, err)
+	require.Nil(t, store.MoveFile(ctx, "pipelines/3", "pipelines/4"))
+	require.Nil(t, store.SetStorageClass(ctx, "pipelines/4", StorageClassGlacier))
+	require.Nil(t, store.SetRetention(ctx, "pipelines/4",
+		ObjectRetention{Mode: minio.Governance, RetainUntil: time.Now().Add(time.Hour)}))
+	require.Nil(t, store.AddPointerFile(ctx, "pipelines/5", "pipelines/1"))
+	_, err = store.IncrementCounter(ctx, "counters/runs")
+	require.Nil(t, err)
+	_, err = store.ImportFiles(ctx, []ImportFile{{FilePath: "pipelines/6", Content: []byte(":")}}, nil)
+	require.Nil(t, err)
+	require.Nil(t, store.DeleteFile(ctx, "pipelines/1"))
+	require.Nil(t, store.RestoreFile(ctx, "pipelines/1"))
+	require.Nil(t, store.DeleteFile(ctx, "pipelines/1"))
+	_, err = store.PurgeRecycleBin(ctx, 0)
+	require.Nil(t, err)
+	_, err = store.MeasureLatency(ctx)
+	require.Nil(t, err)
+	require.Nil(t, store.CheckPrefixAccess(ctx, "pipelines"))
+	_, err = store.CheckPermissions(ctx, "pipelines")
+	require.Nil(t, err)
+	_, err = store.PurgeStaleCanaries(ctx, 0)
+	require.Nil(t, err)
+	elected, err := NewLeaderElection(store, "leases/sync").Campaign(ctx, "apiserver-0", time.Minute)
+	require.Nil(t, err)
+	require.True(t, elected)
+
+	require.NotEmpty(t, minioClient.written)
+	for _, key := range minioClient.written {
+		assert.True(t, strings.HasPrefix(key, "kfp/") && strings.HasSuffix(key, ".kfp"), key)
+	}
+}
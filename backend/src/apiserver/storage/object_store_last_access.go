@@ -0,0 +1,147 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+)
+
+// lastAccessedTag is the object tag recording when a file was last read, in RFC 3339. Tags
+// are used rather than metadata since they can be updated without rewriting the object,
+// which would change its modification time.
+const lastAccessedTag = "kfp-last-accessed"
+
+// maxTrackedAccesses bounds the files whose last recorded access is remembered before the
+// accesses older than the tracking interval are forgotten.
+const maxTrackedAccesses = 10000
+
+// accessTimes remembers when the accesses of the files were last recorded, to throttle the
+// updates of their tags.
+type accessTimes struct {
+	mutex    sync.Mutex
+	recorded map[string]time.Time
+}
+
+// claim returns whether the access of key at now is to be recorded, that is whether none
+// was recorded in the interval before now, and reserves it if so.
+func (a *accessTimes) claim(key string, now time.Time, interval time.Duration) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if last, ok := a.recorded[key]; ok && now.Sub(last) < interval {
+		return false
+	}
+	if a.recorded == nil {
+		a.recorded = make(map[string]time.Time)
+	}
+	if len(a.recorded) >= maxTrackedAccesses {
+		for k, last := range a.recorded {
+			if now.Sub(last) >= interval {
+				delete(a.recorded, k)
+			}
+		}
+	}
+	a.recorded[key] = now
+	return true
+}
+
+// release forgets the access of key recorded at at, so the next access is recorded again.
+func (a *accessTimes) release(key string, at time.Time) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.recorded[key].Equal(at) {
+		delete(a.recorded, key)
+	}
+}
+
+// SetAccessTracking makes GetFile record when each file was last read in its
+// "kfp-last-accessed" object tag, for tiering jobs moving cold files to cheaper storage
+// classes. The tag of a file is updated at most once per interval, so reads do not each
+// cost a write. Zero does not track accesses.
+func (m *MinioObjectStore) SetAccessTracking(interval time.Duration) {
+	m.accessTrackingInterval = interval
+}
+
+// recordAccess records in the tags of the file at filePath that it was read now, unless an
+// access was recorded in the tracking interval. Failures are logged rather than returned,
+// since they must not fail the read. The tags belong to the read, audited as such, so they
+// are not audited as a mutation.
+func (m *MinioObjectStore) recordAccess(ctx context.Context, filePath string) {
+	if m.accessTrackingInterval <= 0 {
+		return
+	}
+	key := m.resolveKey(ctx, filePath)
+	now := m.now()
+	if !m.accessTimes.claim(key, now, m.accessTrackingInterval) {
+		return
+	}
+	err := m.retry(ctx, func(ctx context.Context) error {
+		objectTags, err := m.minioClient.GetObjectTagging(ctx, m.bucketName, key, minio.GetObjectTaggingOptions{})
+		if err != nil {
+			return err
+		}
+		tagMap := objectTags.ToMap()
+		tagMap[lastAccessedTag] = now.UTC().Format(time.RFC3339)
+		updated, err := tags.MapToObjectTags(tagMap)
+		if err != nil {
+			return err
+		}
+		return m.minioClient.PutObjectTagging(ctx, m.bucketName, key, updated, minio.PutObjectTaggingOptions{})
+	})
+	if err != nil {
+		m.accessTimes.release(key, now)
+		glog.Warningf("Failed to record the access of file %v: %v", filePath, err)
+	}
+}
+
+// LastAccessed returns when the file at filePath was last read according to its tags, and
+// false if no access was recorded, e.g. since it was written before accesses were tracked.
+// Accesses are recorded at most once per tracking interval, so the file may have been read
+// up to an interval later.
+func (m *MinioObjectStore) LastAccessed(ctx context.Context, filePath string) (time.Time, bool, error) {
+	objectTags, err := m.minioClient.GetObjectTagging(ctx, m.bucketName, m.resolveKey(ctx, filePath), minio.GetObjectTaggingOptions{})
+	if err != nil {
+		return time.Time{}, false, newObjectStoreError(err, "Failed to get the tags of file %v", filePath)
+	}
+	lastAccessed, ok := parseLastAccessed(objectTags.ToMap())
+	return lastAccessed, ok, nil
+}
+
+// ListFilesNotAccessedSince returns the files under prefix, in key order, that were not
+// read since cutoff, for tiering jobs to move to a colder storage class with
+// SetStorageClass. Files without a recorded access count as last accessed when they were
+// last modified.
+func (m *MinioObjectStore) ListFilesNotAccessedSince(ctx context.Context, prefix string, cutoff time.Time) ([]string, error) {
+	return m.listFilesMatchingTags(ctx, prefix, func(file FileInfo, tagMap map[string]string) bool {
+		lastAccessed, ok := parseLastAccessed(tagMap)
+		if !ok {
+			lastAccessed = file.LastModified
+		}
+		return lastAccessed.Before(cutoff)
+	})
+}
+
+func parseLastAccessed(tagMap map[string]string) (time.Time, bool) {
+	lastAccessed, err := time.Parse(time.RFC3339, tagMap[lastAccessedTag])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return lastAccessed, true
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// taggingMinioClient counts the tag updates of the objects, failing them while fail is set.
+type taggingMinioClient struct {
+	*FakeMinioClient
+	updates atomic.Int32
+	fail    atomic.Bool
+}
+
+func (c *taggingMinioClient) PutObjectTagging(ctx context.Context, bucketName, objectName string,
+	otags *tags.Tags, opts minio.PutObjectTaggingOptions,
+) error {
+	if c.fail.Load() {
+		return errors.New("tagging unavailable")
+	}
+	c.updates.Add(1)
+	return c.FakeMinioClient.PutObjectTagging(ctx, bucketName, objectName, otags, opts)
+}
+
+func newAccessTrackingStore(t *testing.T) (*MinioObjectStore, *taggingMinioClient, *FakeClock) {
+	clock := NewFakeClock(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	minioClient := &taggingMinioClient{FakeMinioClient: NewFakeMinioClient()}
+	minioClient.SetClock(clock)
+	store := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines",
+		WithClock(clock), WithAccessTracking(time.Hour))
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+	return store, minioClient, clock
+}
+
+func TestAccessTracking_RecordsReads(t *testing.T) {
+	store, _, clock := newAccessTrackingStore(t)
+
+	_, ok, err := store.LastAccessed(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	assert.False(t, ok)
+
+	clock.Advance(5 * time.Minute)
+	_, err = store.GetFile(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+
+	lastAccessed, ok, err := store.LastAccessed(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	assert.True(t, ok)
+	assert.True(t, clock.Now().Equal(lastAccessed))
+}
+
+func TestAccessTracking_ThrottledWithinInterval(t *testing.T) {
+	store, minioClient, clock := newAccessTrackingStore(t)
+	_, err := store.GetFile(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	firstAccess := clock.Now()
+
+	for i := 0; i < 5; i++ {
+		clock.Advance(10 * time.Minute)
+		_, err = store.GetFile(context.TODO(), "pipelines/1")
+		require.Nil(t, err)
+	}
+
+	assert.Equal(t, int32(1), minioClient.updates.Load())
+	lastAccessed, _, err := store.LastAccessed(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	assert.True(t, firstAccess.Equal(lastAccessed))
+}
+
+func TestAccessTracking_UpdatedAfterInterval(t *testing.T) {
+	store, minioClient, clock := newAccessTrackingStore(t)
+	_, err := store.GetFile(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+
+	clock.Advance(time.Hour)
+	_, err = store.GetFile(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+
+	assert.Equal(t, int32(2), minioClient.updates.Load())
+	lastAccessed, _, err := store.LastAccessed(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	assert.True(t, clock.Now().Equal(lastAccessed))
+}
+
+func TestAccessTracking_KeepsOtherTags(t *testing.T) {
+	store, minioClient, _ := newAccessTrackingStore(t)
+	_, err := minioClient.PutObject(context.TODO(), "mlpipeline", "pipelines/2", bytes.NewReader([]byte("spec")), 4,
+		minio.PutObjectOptions{UserTags: map[string]string{"owner": "team-a"}})
+	require.Nil(t, err)
+
+	_, err = store.GetFile(context.TODO(), "pipelines/2")
+	require.Nil(t, err)
+
+	objectTags, err := minioClient.GetObjectTagging(context.TODO(), "mlpipeline", "pipelines/2", minio.GetObjectTaggingOptions{})
+	require.Nil(t, err)
+	assert.Equal(t, "team-a", objectTags.ToMap()["owner"])
+	assert.Contains(t, objectTags.ToMap(), lastAccessedTag)
+}
+
+func TestAccessTracking_FailureDoesNotFailRead(t *testing.T) {
+	store, minioClient, _ := newAccessTrackingStore(t)
+	minioClient.fail.Store(true)
+
+	data, err := store.GetFile(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+
+	// The failed update is not throttled, so the next read records the access.
+	minioClient.fail.Store(false)
+	_, err = store.GetFile(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	_, ok, err := store.LastAccessed(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestAccessTracking_Disabled(t *testing.T) {
+	minioClient := &taggingMinioClient{FakeMinioClient: NewFakeMinioClient()}
+	store := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines")
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+
+	_, err := store.GetFile(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+
+	assert.Equal(t, int32(0), minioClient.updates.Load())
+	_, ok, err := store.LastAccessed(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestListFilesNotAccessedSince(t *testing.T) {
+	store, _, clock := newAccessTrackingStore(t)
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/2"))
+	clock.Advance(24 * time.Hour)
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipelines/3"))
+	_, err := store.GetFile(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+
+	files, err := store.ListFilesNotAccessedSince(context.TODO(), "pipelines/", clock.Now().Add(-time.Hour))
+	require.Nil(t, err)
+	// pipelines/2 was never read and was written a day ago; pipelines/1 was just read and
+	// pipelines/3 was just written.
+	assert.Equal(t, []string{"pipelines/2"}, files)
+}
@@ -0,0 +1,252 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+)
+
+// lease is the content of a lease object: who holds it, and until when.
+type lease struct {
+	Holder  string    `json:"holder"`
+	Expires time.Time `json:"expires"`
+}
+
+// acquireLease takes, or extends, the lease stored at filePath for holder, for ttl. It
+// returns false if another holder has an unexpired lease, or won the race to take it.
+// Leases are written with a conditional put on the ETag of the lease read, so at most one
+// holder acquires a lease at a time. Expiry is judged by the clock of the store, so ttl
+// must be well above the clock skew between the holders.
+func (m *MinioObjectStore) acquireLease(ctx context.Context, filePath string, holder string, ttl time.Duration) (bool, time.Time, error) {
+	if err := m.checkOpen("acquire lease", filePath); err != nil {
+		return false, time.Time{}, err
+	}
+	key := m.resolveKey(ctx, filePath)
+	now := m.now()
+	current, info, err := m.readLease(ctx, key, filePath)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	opts := m.putObjectOptions(ctx)
+	if info == nil {
+		// Only creates the lease if nobody else did.
+		opts.SetMatchETagExcept("*")
+	} else {
+		if current.Holder != holder && now.Before(current.Expires) {
+			return false, time.Time{}, nil
+		}
+		opts.SetMatchETag(info.ETag)
+	}
+	expires := now.Add(ttl)
+	acquired, err := m.putLease(ctx, key, filePath, lease{Holder: holder, Expires: expires}, opts)
+	return acquired, expires, err
+}
+
+// releaseLease expires the lease stored at filePath if holder holds it.
+func (m *MinioObjectStore) releaseLease(ctx context.Context, filePath string, holder string) error {
+	if err := m.checkOpen("release lease", filePath); err != nil {
+		return err
+	}
+	key := m.resolveKey(ctx, filePath)
+	current, info, err := m.readLease(ctx, key, filePath)
+	if err != nil || info == nil || current.Holder != holder {
+		return err
+	}
+	opts := m.putObjectOptions(ctx)
+	opts.SetMatchETag(info.ETag)
+	_, err = m.putLease(ctx, key, filePath, lease{}, opts)
+	return err
+}
+
+// readLease returns the lease stored at key, with the info of its object, nil if there is
+// none. The ETag is read before the lease, so a lease newer than the ETag fails the put.
+func (m *MinioObjectStore) readLease(ctx context.Context, key string, filePath string) (lease, *minio.ObjectInfo, error) {
+	var current lease
+	info, err := m.minioClient.StatObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+	if ClassifyError(err) == ErrNotFound {
+		return current, nil, nil
+	}
+	if err != nil {
+		return current, nil, newObjectStoreError(err, "Failed to stat lease %v", filePath)
+	}
+	data, err := m.getFile(ctx, filePath)
+	if err != nil {
+		return current, nil, err
+	}
+	if err := json.Unmarshal(data, &current); err != nil {
+		return current, nil, util.NewFailedPreconditionError(err, "Failed to read lease %v: invalid content %q", filePath, data)
+	}
+	return current, &info, nil
+}
+
+// putLease stores the lease at key with the conditions of opts, returning false if they
+// do not hold because someone else wrote the lease in the meantime.
+func (m *MinioObjectStore) putLease(ctx context.Context, key string, filePath string, current lease, opts minio.PutObjectOptions) (bool, error) {
+	content, err := json.Marshal(current)
+	if err != nil {
+		return false, util.NewInternalServerError(err, "Failed to marshal lease %v", filePath)
+	}
+	if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationWrite, Path: filePath, Size: int64(len(content))}); err != nil {
+		return false, err
+	}
+	_, err = m.minioClient.PutObject(ctx, m.bucketName, key, bytes.NewReader(content), int64(len(content)), opts)
+	if ClassifyError(err) == ErrConflict {
+		return false, nil
+	}
+	if err != nil {
+		return false, newObjectStoreError(err, "Failed to store lease %v", filePath)
+	}
+	return true, nil
+}
+
+// LeaderElection elects a single leader among the replicas of a component, e.g. the
+// persistence agent, through a lease stored in the object store. Each replica campaigns
+// with its own LeaderElection. The leader renews its lease in the background, and a
+// replica that stops renewing, e.g. because it crashed, loses leadership once the lease
+// expires.
+type LeaderElection struct {
+	store    *MinioObjectStore
+	filePath string
+
+	mutex sync.Mutex
+	id    string
+	// expires is the end of the lease held, zero if not the leader.
+	expires time.Time
+	renewal *leaseRenewal
+}
+
+// leaseRenewal is the background renewal of a held lease.
+type leaseRenewal struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewLeaderElection returns an election through the lease stored at filePath.
+func NewLeaderElection(store *MinioObjectStore, filePath string) *LeaderElection {
+	return &LeaderElection{store: store, filePath: filePath}
+}
+
+// Campaign makes the candidate id the leader for ttl if the lease is free, expired, or
+// already held by id, and reports whether it is the leader. Once leader, the lease is
+// renewed every third of ttl until ctx is done or Resign is called. Campaign does not
+// wait for the lease to be free: candidates call it periodically.
+func (e *LeaderElection) Campaign(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	if ttl <= 0 {
+		return false, util.NewInvalidInputError("Invalid lease duration %v of election %v", ttl, e.filePath)
+	}
+	acquired, expires, err := e.store.acquireLease(ctx, e.filePath, id, ttl)
+	if err != nil {
+		return false, util.Wrapf(err, "Failed to campaign in election %v", e.filePath)
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if !acquired {
+		e.expires = time.Time{}
+		return false, nil
+	}
+	e.id = id
+	e.expires = expires
+	if e.renewal == nil {
+		renewCtx, cancel := context.WithCancel(ctx)
+		e.renewal = &leaseRenewal{cancel: cancel, done: make(chan struct{})}
+		ticks, stop := e.store.newTicker(ttl / 3)
+		go e.renew(renewCtx, e.renewal, id, ttl, ticks, stop)
+	}
+	return true, nil
+}
+
+// renew extends the lease on every tick of ticks, until ctx is done or the lease is lost,
+// then stops the ticker.
+func (e *LeaderElection) renew(ctx context.Context, renewal *leaseRenewal, id string, ttl time.Duration,
+	ticks <-chan time.Time, stop func(),
+) {
+	defer close(renewal.done)
+	defer stop()
+	for {
+		select {
+		case <-ctx.Done():
+		case <-ticks:
+		}
+		if ctx.Err() != nil {
+			e.endRenewal(renewal, false)
+			return
+		}
+		acquired, expires, err := e.store.acquireLease(ctx, e.filePath, id, ttl)
+		if err != nil {
+			// The lease holds until it expires, so a later renewal may still keep it.
+			glog.Warningf("Failed to renew lease %v of %v: %v", e.filePath, id, err)
+			continue
+		}
+		if !acquired {
+			e.endRenewal(renewal, true)
+			return
+		}
+		e.mutex.Lock()
+		e.expires = expires
+		e.mutex.Unlock()
+	}
+}
+
+// endRenewal forgets renewal if it is still the current one, and the lease too if lost.
+func (e *LeaderElection) endRenewal(renewal *leaseRenewal, lost bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.renewal != renewal {
+		return
+	}
+	e.renewal = nil
+	if lost {
+		e.expires = time.Time{}
+	}
+}
+
+// IsLeader returns whether the candidate holds an unexpired lease.
+func (e *LeaderElection) IsLeader() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return !e.expires.IsZero() && e.store.now().Before(e.expires)
+}
+
+// Resign stops renewing the lease and releases it, so another candidate can become the
+// leader without waiting for the lease to expire. It is a no-op for a candidate that is
+// not the leader.
+func (e *LeaderElection) Resign(ctx context.Context) error {
+	e.mutex.Lock()
+	renewal := e.renewal
+	id := e.id
+	wasLeader := !e.expires.IsZero()
+	e.renewal = nil
+	e.expires = time.Time{}
+	e.mutex.Unlock()
+	if renewal != nil {
+		renewal.cancel()
+		<-renewal.done
+	}
+	if !wasLeader {
+		return nil
+	}
+	if err := e.store.releaseLease(ctx, e.filePath, id); err != nil {
+		return util.Wrapf(err, "Failed to resign from election %v", e.filePath)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+const leaderTestLease = "pipelines/.leases/persistence-agent"
+
+func newLeaderTestStore() (*MinioObjectStore, *FakeClock) {
+	clock := NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	return NewMinioObjectStoreWithOptions(NewFakeMinioClient(), "mlpipeline", "pipelines", WithClock(clock)), clock
+}
+
+func TestLeaderElection_SingleLeader(t *testing.T) {
+	store, _ := newLeaderTestStore()
+	a := NewLeaderElection(store, leaderTestLease)
+	b := NewLeaderElection(store, leaderTestLease)
+	defer a.Resign(context.TODO())
+
+	leader, err := a.Campaign(context.TODO(), "a", time.Minute)
+	require.Nil(t, err)
+	assert.True(t, leader)
+	leader, err = b.Campaign(context.TODO(), "b", time.Minute)
+	require.Nil(t, err)
+	assert.False(t, leader)
+
+	assert.True(t, a.IsLeader())
+	assert.False(t, b.IsLeader())
+	// The leader campaigning again renews its lease.
+	leader, err = a.Campaign(context.TODO(), "a", time.Minute)
+	require.Nil(t, err)
+	assert.True(t, leader)
+}
+
+func TestLeaderElection_PassesWhenLeaderStopsRenewing(t *testing.T) {
+	store, clock := newLeaderTestStore()
+	a := NewLeaderElection(store, leaderTestLease)
+	b := NewLeaderElection(store, leaderTestLease)
+	ctx, stopRenewing := context.WithCancel(context.TODO())
+	leader, err := a.Campaign(ctx, "a", time.Minute)
+	require.Nil(t, err)
+	require.True(t, leader)
+
+	stopRenewing()
+	clock.Advance(30 * time.Second)
+	leader, err = b.Campaign(context.TODO(), "b", time.Minute)
+	require.Nil(t, err)
+	assert.False(t, leader)
+
+	clock.Advance(31 * time.Second)
+	assert.False(t, a.IsLeader())
+	leader, err = b.Campaign(context.TODO(), "b", time.Minute)
+	require.Nil(t, err)
+	assert.True(t, leader)
+	defer b.Resign(context.TODO())
+	leader, err = a.Campaign(context.TODO(), "a", time.Minute)
+	require.Nil(t, err)
+	assert.False(t, leader)
+}
+
+func TestLeaderElection_ResignFreesLease(t *testing.T) {
+	store, _ := newLeaderTestStore()
+	a := NewLeaderElection(store, leaderTestLease)
+	b := NewLeaderElection(store, leaderTestLease)
+	leader, err := a.Campaign(context.TODO(), "a", time.Minute)
+	require.Nil(t, err)
+	require.True(t, leader)
+
+	require.Nil(t, a.Resign(context.TODO()))
+
+	assert.False(t, a.IsLeader())
+	leader, err = b.Campaign(context.TODO(), "b", time.Minute)
+	require.Nil(t, err)
+	assert.True(t, leader)
+	defer b.Resign(context.TODO())
+	// Resigning when not the leader does not release the lease of the leader.
+	require.Nil(t, a.Resign(context.TODO()))
+	assert.True(t, b.IsLeader())
+	leader, err = a.Campaign(context.TODO(), "a", time.Minute)
+	require.Nil(t, err)
+	assert.False(t, leader)
+}
+
+func TestLeaderElection_RenewsLease(t *testing.T) {
+	store := NewMinioObjectStore(NewFakeMinioClient(), "mlpipeline", "pipelines", false)
+	a := NewLeaderElection(store, leaderTestLease)
+	b := NewLeaderElection(store, leaderTestLease)
+	defer a.Resign(context.TODO())
+	ttl := 300 * time.Millisecond
+	leader, err := a.Campaign(context.TODO(), "a", ttl)
+	require.Nil(t, err)
+	require.True(t, leader)
+
+	time.Sleep(3 * ttl)
+
+	assert.True(t, a.IsLeader())
+	leader, err = b.Campaign(context.TODO(), "b", ttl)
+	require.Nil(t, err)
+	assert.False(t, leader)
+}
+
+func TestLeaderElection_RenewalTimedByClock(t *testing.T) {
+	store, clock := newLeaderTestStore()
+	a := NewLeaderElection(store, leaderTestLease)
+	defer a.Resign(context.TODO())
+	start := clock.Now()
+	leader, err := a.Campaign(context.TODO(), "a", time.Minute)
+	require.Nil(t, err)
+	require.True(t, leader)
+
+	clock.Advance(20 * time.Second)
+	assert.Eventually(t, func() bool {
+		a.mutex.Lock()
+		defer a.mutex.Unlock()
+		return a.expires.Equal(start.Add(80 * time.Second))
+	}, 5*time.Second, time.Millisecond)
+	clock.Set(start.Add(70 * time.Second))
+	assert.True(t, a.IsLeader())
+}
+
+func TestLeaderElection_InvalidTTL(t *testing.T) {
+	store, _ := newLeaderTestStore()
+
+	_, err := NewLeaderElection(store, leaderTestLease).Campaign(context.TODO(), "a", 0)
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+}
+
+// barrierStatClient holds the stats reporting an object missing until every candidate
+// has stat-ed it, so concurrent callers all see it missing before any of them writes it.
+type barrierStatClient struct {
+	*FakeMinioClient
+	missing sync.WaitGroup
+}
+
+func (c *barrierStatClient) StatObject(ctx context.Context, bucketName, objectName string,
+	opts minio.StatObjectOptions,
+) (minio.ObjectInfo, error) {
+	info, err := c.FakeMinioClient.StatObject(ctx, bucketName, objectName, opts)
+	if err != nil {
+		c.missing.Done()
+		c.missing.Wait()
+	}
+	return info, err
+}
+
+func TestAcquireLease_AbsentLeaseRace(t *testing.T) {
+	const candidates = 2
+	minioClient := &barrierStatClient{FakeMinioClient: NewFakeMinioClient()}
+	minioClient.missing.Add(candidates)
+	store := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines",
+		WithClock(NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))))
+
+	acquired := make([]bool, candidates)
+	var wg sync.WaitGroup
+	for i := 0; i < candidates; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			acquired[i], _, err = store.acquireLease(context.TODO(), leaderTestLease, fmt.Sprintf("candidate-%d", i), time.Minute)
+			assert.Nil(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.ElementsMatch(t, []bool{true, false}, acquired)
+}
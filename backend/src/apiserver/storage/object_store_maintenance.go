@@ -0,0 +1,47 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+// ErrMaintenance is the cause of errors returned for writes attempted during a maintenance window.
+var ErrMaintenance = errors.New("object store maintenance in progress")
+
+// SetMaintenance starts or ends a maintenance window, e.g. while the backend is backed up.
+// During the window, writes are rejected with an Unavailable error caused by
+// ErrMaintenance, without reaching the backend, while reads carry on. It is safe to call
+// while the store is in use.
+func (m *MinioObjectStore) SetMaintenance(enabled bool) {
+	if m.maintenance.Swap(enabled) != enabled {
+		glog.Infof("Object store maintenance in progress: %v", enabled)
+	}
+}
+
+// InMaintenance returns whether a maintenance window is in progress.
+func (m *MinioObjectStore) InMaintenance() bool {
+	return m.maintenance.Load()
+}
+
+// checkMaintenance returns an error if a maintenance window forbids writing filePath.
+func (m *MinioObjectStore) checkMaintenance(operation string, filePath string) error {
+	if m.maintenance.Load() {
+		return util.NewUnavailableServerError(ErrMaintenance, "Failed to %v %v", operation, filePath)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func assertMaintenanceError(t *testing.T, err error) {
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrMaintenance))
+	assert.False(t, errors.Is(err, ErrObjectStoreClosed))
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestMaintenance(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	ctx := context.TODO()
+	require.Nil(t, manager.AddFile(ctx, []byte("spec"), "pipeline/1"))
+
+	manager.SetMaintenance(true)
+	assert.True(t, manager.InMaintenance())
+	assertMaintenanceError(t, manager.AddFile(ctx, []byte("new spec"), "pipeline/1"))
+	assertMaintenanceError(t, manager.AddAsYamlFile(ctx, map[string]string{"name": "spec"}, "pipeline/2"))
+	assertMaintenanceError(t, manager.DeleteFile(ctx, "pipeline/1"))
+	assertMaintenanceError(t, manager.MoveFile(ctx, "pipeline/1", "pipeline/3"))
+	assertMaintenanceError(t, manager.AddFileFromReader(ctx, bytes.NewReader([]byte("spec")), "pipeline/4", false))
+
+	data, err := manager.GetFile(ctx, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+	_, err = manager.GetFileInfo(ctx, "pipeline/1")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, minioClient.GetObjectCount())
+
+	manager.SetMaintenance(false)
+	assert.False(t, manager.InMaintenance())
+	require.Nil(t, manager.AddFile(ctx, []byte("new spec"), "pipeline/1"))
+	data, err = manager.GetFile(ctx, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("new spec"), data)
+}
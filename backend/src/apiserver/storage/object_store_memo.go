@@ -0,0 +1,111 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sync"
+)
+
+// requestMemo holds the files read during a request.
+type requestMemo struct {
+	mutex sync.Mutex
+	files map[string][]byte
+}
+
+type requestMemoContextKey struct{}
+
+// WithRequestMemo attaches a memo to ctx, the context of an API request, in which a
+// MemoizingObjectStore keeps the files read with it. The memo lives as long as ctx, so
+// nothing read during a request is ever served to another one.
+func WithRequestMemo(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestMemoContextKey{}, &requestMemo{files: make(map[string][]byte)})
+}
+
+func requestMemoFromContext(ctx context.Context) *requestMemo {
+	memo, _ := ctx.Value(requestMemoContextKey{}).(*requestMemo)
+	return memo
+}
+
+// MemoizingObjectStore serves the files read again during a request from the memo of the
+// request, set with WithRequestMemo, rather than the backend. Failed reads are not
+// memoized, and writes through the store invalidate the memoized file. Operations using a
+// context without a memo, or made while fresh reads are forced, go to the wrapped store.
+type MemoizingObjectStore struct {
+	ObjectStoreInterface
+}
+
+func NewMemoizingObjectStore(store ObjectStoreInterface) *MemoizingObjectStore {
+	return &MemoizingObjectStore{ObjectStoreInterface: store}
+}
+
+func (s *MemoizingObjectStore) GetFile(ctx context.Context, filePath string) ([]byte, error) {
+	memo := requestMemoFromContext(ctx)
+	if memo == nil || ForceFreshReads() {
+		return s.ObjectStoreInterface.GetFile(ctx, filePath)
+	}
+	key := cacheKey(ctx, filePath)
+	memo.mutex.Lock()
+	data, ok := memo.files[key]
+	memo.mutex.Unlock()
+	if ok {
+		return append([]byte(nil), data...), nil
+	}
+	data, err := s.ObjectStoreInterface.GetFile(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	memo.mutex.Lock()
+	memo.files[key] = append([]byte(nil), data...)
+	memo.mutex.Unlock()
+	return data, nil
+}
+
+func (s *MemoizingObjectStore) GetFromYamlFile(ctx context.Context, o interface{}, filePath string) error {
+	bytes, err := readYamlFile(ctx, s, filePath)
+	return unmarshalYamlFile(ctx, bytes, err, o, filePath)
+}
+
+func (s *MemoizingObjectStore) AddFile(ctx context.Context, file []byte, filePath string) error {
+	forget(ctx, filePath)
+	return s.ObjectStoreInterface.AddFile(ctx, file, filePath)
+}
+
+func (s *MemoizingObjectStore) AddAsYamlFile(ctx context.Context, o interface{}, filePath string) error {
+	forget(ctx, filePath)
+	return s.ObjectStoreInterface.AddAsYamlFile(ctx, o, filePath)
+}
+
+func (s *MemoizingObjectStore) DeleteFile(ctx context.Context, filePath string) error {
+	forget(ctx, filePath)
+	return s.ObjectStoreInterface.DeleteFile(ctx, filePath)
+}
+
+func (s *MemoizingObjectStore) MoveFile(ctx context.Context, srcPath string, dstPath string) error {
+	forget(ctx, srcPath)
+	forget(ctx, dstPath)
+	return s.ObjectStoreInterface.MoveFile(ctx, srcPath, dstPath)
+}
+
+// forget removes the file from the memo of the request of ctx, if any.
+func forget(ctx context.Context, filePath string) {
+	memo := requestMemoFromContext(ctx)
+	if memo == nil {
+		return
+	}
+	memo.mutex.Lock()
+	defer memo.mutex.Unlock()
+	delete(memo.files, cacheKey(ctx, filePath))
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMemoizingTestStore(t *testing.T) (*MemoizingObjectStore, *countingMinioClient) {
+	minioClient := newCountingMinioClient()
+	store := NewMemoizingObjectStore(&MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"})
+	require.Nil(t, store.AddFile(context.TODO(), []byte("id: 1"), "pipeline/1"))
+	return store, minioClient
+}
+
+func TestMemoizingObjectStore_RepeatedFetchesHitMemo(t *testing.T) {
+	store, minioClient := newMemoizingTestStore(t)
+	ctx := WithRequestMemo(context.TODO())
+
+	data, err := store.GetFile(ctx, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("id: 1"), data)
+	var foo Foo
+	require.Nil(t, store.GetFromYamlFile(ctx, &foo, "pipeline/1"))
+	assert.Equal(t, Foo{ID: 1}, foo)
+
+	assert.Equal(t, 1, minioClient.getCount)
+}
+
+func TestMemoizingObjectStore_NewContextFetchesAgain(t *testing.T) {
+	store, minioClient := newMemoizingTestStore(t)
+
+	_, err := store.GetFile(WithRequestMemo(context.TODO()), "pipeline/1")
+	require.Nil(t, err)
+	_, err = store.GetFile(WithRequestMemo(context.TODO()), "pipeline/1")
+	require.Nil(t, err)
+	// Without a memo, every read goes to the backend.
+	_, err = store.GetFile(context.TODO(), "pipeline/1")
+	require.Nil(t, err)
+
+	assert.Equal(t, 3, minioClient.getCount)
+}
+
+func TestMemoizingObjectStore_WriteInvalidates(t *testing.T) {
+	store, minioClient := newMemoizingTestStore(t)
+	ctx := WithRequestMemo(context.TODO())
+	_, err := store.GetFile(ctx, "pipeline/1")
+	require.Nil(t, err)
+
+	require.Nil(t, store.AddFile(ctx, []byte("id: 2"), "pipeline/1"))
+	data, err := store.GetFile(ctx, "pipeline/1")
+
+	require.Nil(t, err)
+	assert.Equal(t, []byte("id: 2"), data)
+	assert.Equal(t, 2, minioClient.getCount)
+}
+
+func TestMemoizingObjectStore_ErrorsNotMemoized(t *testing.T) {
+	store, minioClient := newMemoizingTestStore(t)
+	ctx := WithRequestMemo(context.TODO())
+
+	_, err := store.GetFile(ctx, "pipeline/missing")
+	require.NotNil(t, err)
+	_, err = store.GetFile(ctx, "pipeline/missing")
+	require.NotNil(t, err)
+
+	assert.Equal(t, 2, minioClient.getCount)
+}
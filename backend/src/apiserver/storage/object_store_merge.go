@@ -0,0 +1,79 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// GetMergedYamlFile reads the yaml file at basePath, layers the yaml file at overlayPath
+// on top of it, and unmarshals the result into o. If there is no overlay, the base is
+// unmarshalled as is. The files are merged like a JSON merge patch (RFC 7386) applies:
+//   - maps are merged key by key, recursively, the values of the overlay winning;
+//   - a null in the overlay removes the key from the base;
+//   - lists, like scalars, are not merged: a list of the overlay replaces the list of
+//     the base as a whole.
+func (m *MinioObjectStore) GetMergedYamlFile(ctx context.Context, basePath string, overlayPath string, o interface{}) error {
+	baseBytes, err := readYamlFile(ctx, m, basePath)
+	if err != nil {
+		return util.Wrap(err, "Failed to read from a yaml file")
+	}
+	overlayBytes, err := readYamlFile(ctx, m, overlayPath)
+	if errors.Is(err, ErrNotFound) {
+		return unmarshalYamlFile(ctx, baseBytes, nil, o, basePath)
+	}
+	if err != nil {
+		return util.Wrap(err, "Failed to read from a yaml overlay")
+	}
+
+	var base, overlay interface{}
+	if err := yaml.Unmarshal(baseBytes, &base); err != nil {
+		return util.NewInternalServerError(err, "Failed to unmarshal file %v: %v", basePath, err.Error())
+	}
+	if err := yaml.Unmarshal(overlayBytes, &overlay); err != nil {
+		return util.NewInternalServerError(err, "Failed to unmarshal file %v: %v", overlayPath, err.Error())
+	}
+	merged, err := yaml.Marshal(mergeYaml(base, overlay))
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to merge file %v into %v: %v", overlayPath, basePath, err.Error())
+	}
+	return unmarshalYamlFile(ctx, merged, nil, o, basePath)
+}
+
+// mergeYaml returns overlay merged into base, as documented by GetMergedYamlFile. Neither
+// is modified.
+func mergeYaml(base interface{}, overlay interface{}) interface{} {
+	overlayMap, ok := overlay.(map[string]interface{})
+	if !ok {
+		return overlay
+	}
+	baseMap, _ := base.(map[string]interface{})
+	merged := make(map[string]interface{}, len(baseMap)+len(overlayMap))
+	for key, value := range baseMap {
+		merged[key] = value
+	}
+	for key, value := range overlayMap {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = mergeYaml(merged[key], value)
+	}
+	return merged
+}
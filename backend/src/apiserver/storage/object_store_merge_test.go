@@ -0,0 +1,100 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+const mergeTestBase = `name: train
+image: trainer:1.0
+resources:
+  cpu: "1"
+  memory: 1Gi
+args: [--epochs, "10"]
+`
+
+func newMergeTestStore(t *testing.T, files map[string]string) *MinioObjectStore {
+	store := NewMinioObjectStore(NewFakeMinioClient(), "mlpipeline", "pipelines", false)
+	for filePath, content := range files {
+		require.Nil(t, store.AddFile(context.TODO(), []byte(content), filePath))
+	}
+	return store
+}
+
+func TestGetMergedYamlFile_ScalarOverride(t *testing.T) {
+	store := newMergeTestStore(t, map[string]string{
+		"pipelines/base":    mergeTestBase,
+		"pipelines/overlay": "image: trainer:2.0\n",
+	})
+
+	var merged map[string]interface{}
+	require.Nil(t, store.GetMergedYamlFile(context.TODO(), "pipelines/base", "pipelines/overlay", &merged))
+
+	assert.Equal(t, "trainer:2.0", merged["image"])
+	assert.Equal(t, "train", merged["name"])
+}
+
+func TestGetMergedYamlFile_NestedMapMerge(t *testing.T) {
+	store := newMergeTestStore(t, map[string]string{
+		"pipelines/base":    mergeTestBase,
+		"pipelines/overlay": "resources:\n  memory: 4Gi\n  gpu: \"1\"\n",
+	})
+
+	var merged map[string]interface{}
+	require.Nil(t, store.GetMergedYamlFile(context.TODO(), "pipelines/base", "pipelines/overlay", &merged))
+
+	assert.Equal(t, map[string]interface{}{"cpu": "1", "memory": "4Gi", "gpu": "1"}, merged["resources"])
+}
+
+func TestGetMergedYamlFile_ListsReplacedAndNullsRemoved(t *testing.T) {
+	store := newMergeTestStore(t, map[string]string{
+		"pipelines/base":    mergeTestBase,
+		"pipelines/overlay": "args: [--epochs, \"1\"]\nimage: null\nresources:\n  cpu: null\n",
+	})
+
+	var merged map[string]interface{}
+	require.Nil(t, store.GetMergedYamlFile(context.TODO(), "pipelines/base", "pipelines/overlay", &merged))
+
+	assert.Equal(t, []interface{}{"--epochs", "1"}, merged["args"])
+	assert.NotContains(t, merged, "image")
+	assert.Equal(t, map[string]interface{}{"memory": "1Gi"}, merged["resources"])
+}
+
+func TestGetMergedYamlFile_MissingOverlay(t *testing.T) {
+	store := newMergeTestStore(t, map[string]string{"pipelines/base": mergeTestBase})
+
+	var merged, base map[string]interface{}
+	require.Nil(t, store.GetMergedYamlFile(context.TODO(), "pipelines/base", "pipelines/overlay", &merged))
+	require.Nil(t, store.GetFromYamlFile(context.TODO(), &base, "pipelines/base"))
+
+	assert.Equal(t, base, merged)
+}
+
+func TestGetMergedYamlFile_MissingBase(t *testing.T) {
+	store := newMergeTestStore(t, map[string]string{"pipelines/overlay": "image: trainer:2.0\n"})
+
+	var merged map[string]interface{}
+	err := store.GetMergedYamlFile(context.TODO(), "pipelines/base", "pipelines/overlay", &merged)
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+const objectStoreMetricsTable = "object_store_metrics"
+
+type ObjectStoreMetricStoreInterface interface {
+	// AddObjectStoreMetric adds the counters of delta to those of the same file.
+	AddObjectStoreMetric(delta *model.ObjectStoreMetric) error
+	// GetObjectStoreMetric returns the counters of a file, all zero if none were recorded.
+	GetObjectStoreMetric(filePath string) (*model.ObjectStoreMetric, error)
+}
+
+// Implementation of a ObjectStoreMetricStoreInterface. This stores the object store
+// operation counters of each file.
+type ObjectStoreMetricStore struct {
+	db *DB
+}
+
+func (s *ObjectStoreMetricStore) AddObjectStoreMetric(delta *model.ObjectStoreMetric) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create a new transaction to add object store metrics")
+	}
+	// Creates the row of the file if it does not exist yet, then increments it.
+	insertSql, insertArgs, err := sq.
+		Insert(objectStoreMetricsTable).
+		SetMap(sq.Eq{"FilePath": delta.FilePath, "Reads": 0, "Writes": 0, "BytesRead": 0, "BytesWritten": 0}).
+		ToSql()
+	if err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Error creating query to add object store metrics of file %v", delta.FilePath)
+	}
+	insertSql = s.db.Upsert(insertSql, "FilePath", false, "FilePath")
+	if _, err = tx.Exec(insertSql, insertArgs...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Error adding object store metrics of file %v", delta.FilePath)
+	}
+	updateSql, updateArgs, err := sq.
+		Update(objectStoreMetricsTable).
+		Set("Reads", sq.Expr("Reads + ?", delta.Reads)).
+		Set("Writes", sq.Expr("Writes + ?", delta.Writes)).
+		Set("BytesRead", sq.Expr("BytesRead + ?", delta.BytesRead)).
+		Set("BytesWritten", sq.Expr("BytesWritten + ?", delta.BytesWritten)).
+		Where(sq.Eq{"FilePath": delta.FilePath}).
+		ToSql()
+	if err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Error creating query to add object store metrics of file %v", delta.FilePath)
+	}
+	if _, err = tx.Exec(updateSql, updateArgs...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Error adding object store metrics of file %v", delta.FilePath)
+	}
+	if err = tx.Commit(); err != nil {
+		return util.NewInternalServerError(err, "Failed to commit the object store metrics of file %v", delta.FilePath)
+	}
+	return nil
+}
+
+func (s *ObjectStoreMetricStore) GetObjectStoreMetric(filePath string) (*model.ObjectStoreMetric, error) {
+	sql, args, err := sq.
+		Select("FilePath", "Reads", "Writes", "BytesRead", "BytesWritten").
+		From(objectStoreMetricsTable).
+		Where(sq.Eq{"FilePath": filePath}).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Error creating query to get object store metrics of file %v", filePath)
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Error getting object store metrics of file %v", filePath)
+	}
+	defer rows.Close()
+	metric := &model.ObjectStoreMetric{FilePath: filePath}
+	if rows.Next() {
+		if err := rows.Scan(&metric.FilePath, &metric.Reads, &metric.Writes, &metric.BytesRead, &metric.BytesWritten); err != nil {
+			return nil, util.NewInternalServerError(err, "Error scanning object store metrics of file %v", filePath)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.NewInternalServerError(err, "Error getting object store metrics of file %v", filePath)
+	}
+	return metric, nil
+}
+
+// factory function for object store metric store.
+func NewObjectStoreMetricStore(db *DB) *ObjectStoreMetricStore {
+	return &ObjectStoreMetricStore{db: db}
+}
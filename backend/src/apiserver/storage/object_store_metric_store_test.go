@@ -0,0 +1,60 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectStoreMetricStore(t *testing.T) {
+	db := NewFakeDBOrFatal()
+	defer db.Close()
+	metricStore := NewObjectStoreMetricStore(db)
+
+	require.Nil(t, metricStore.AddObjectStoreMetric(&model.ObjectStoreMetric{FilePath: "pipelines/1", Writes: 1, BytesWritten: 10}))
+	require.Nil(t, metricStore.AddObjectStoreMetric(&model.ObjectStoreMetric{FilePath: "pipelines/1", Reads: 2, BytesRead: 20}))
+	require.Nil(t, metricStore.AddObjectStoreMetric(&model.ObjectStoreMetric{FilePath: "pipelines/2", Reads: 1, BytesRead: 5}))
+
+	metric, err := metricStore.GetObjectStoreMetric("pipelines/1")
+	require.Nil(t, err)
+	assert.Equal(t, &model.ObjectStoreMetric{FilePath: "pipelines/1", Reads: 2, Writes: 1, BytesRead: 20, BytesWritten: 10}, metric)
+	metric, err = metricStore.GetObjectStoreMetric("pipelines/2")
+	require.Nil(t, err)
+	assert.Equal(t, &model.ObjectStoreMetric{FilePath: "pipelines/2", Reads: 1, BytesRead: 5}, metric)
+}
+
+func TestObjectStoreMetricStore_NotRecorded(t *testing.T) {
+	db := NewFakeDBOrFatal()
+	defer db.Close()
+	metricStore := NewObjectStoreMetricStore(db)
+
+	metric, err := metricStore.GetObjectStoreMetric("pipelines/1")
+	require.Nil(t, err)
+	assert.Equal(t, &model.ObjectStoreMetric{FilePath: "pipelines/1"}, metric)
+}
+
+func TestObjectStoreMetricStore_DBError(t *testing.T) {
+	db := NewFakeDBOrFatal()
+	metricStore := NewObjectStoreMetricStore(db)
+	db.Close()
+
+	assert.NotNil(t, metricStore.AddObjectStoreMetric(&model.ObjectStoreMetric{FilePath: "pipelines/1", Writes: 1}))
+	_, err := metricStore.GetObjectStoreMetric("pipelines/1")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Upload modes, as reported in the upload metrics.
+const (
+	uploadModeSinglePart = "single_part"
+	uploadModeMultipart  = "multipart"
+)
+
+// Operations transferring files, as reported in the operation size metrics.
+const (
+	sizedOperationGet = "get"
+	sizedOperationPut = "put"
+)
+
+var objectStoreUploads = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "object_store_uploads_total",
+	Help: "The number of files uploaded to the object store, by upload mode",
+}, []string{"mode"})
+
+var objectStoreUploadSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name: "object_store_upload_size_bytes",
+	Help: "The size of the files uploaded to the object store, by upload mode",
+	// 1KiB to 256MiB.
+	Buckets: prometheus.ExponentialBuckets(1024, 4, 10),
+}, []string{"mode"})
+
+var objectStoreOperationSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name: "object_store_operation_size_bytes",
+	Help: "The size of the files read from and written to the object store, by operation",
+	// 1KiB to 4GiB.
+	Buckets: prometheus.ExponentialBuckets(1024, 4, 12),
+}, []string{"operation"})
+
+// recordOperationSize records the size of a file successfully read or written.
+func recordOperationSize(operation string, size int64) {
+	objectStoreOperationSize.WithLabelValues(operation).Observe(float64(size))
+}
+
+// recordUpload records a successful upload in the upload metrics.
+func (m *MinioObjectStore) recordUpload(size int) {
+	mode := uploadModeMultipart
+	if m.disableMultipart {
+		mode = uploadModeSinglePart
+	}
+	objectStoreUploads.WithLabelValues(mode).Inc()
+	objectStoreUploadSize.WithLabelValues(mode).Observe(float64(size))
+	recordOperationSize(sizedOperationPut, int64(size))
+}
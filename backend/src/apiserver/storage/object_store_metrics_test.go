@@ -0,0 +1,99 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func uploadSizeSamples(t *testing.T, mode string) (uint64, float64) {
+	metric := &dto.Metric{}
+	require.Nil(t, objectStoreUploadSize.WithLabelValues(mode).(prometheus.Metric).Write(metric))
+	return metric.GetHistogram().GetSampleCount(), metric.GetHistogram().GetSampleSum()
+}
+
+func TestRecordUpload(t *testing.T) {
+	singlePart := util.GetMetricValue(objectStoreUploads.WithLabelValues(uploadModeSinglePart))
+	multipart := util.GetMetricValue(objectStoreUploads.WithLabelValues(uploadModeMultipart))
+	singlePartSizes, singlePartBytes := uploadSizeSamples(t, uploadModeSinglePart)
+	multipartSizes, multipartBytes := uploadSizeSamples(t, uploadModeMultipart)
+
+	singlePartStore := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline", disableMultipart: true}
+	multipartStore := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	require.Nil(t, singlePartStore.AddFile(context.TODO(), []byte("abc"), "pipeline/1"))
+	require.Nil(t, multipartStore.AddFile(context.TODO(), []byte("abcdef"), "pipeline/1"))
+	require.Nil(t, multipartStore.AddFile(context.TODO(), []byte("abcdefgh"), "pipeline/2"))
+	// Failed uploads are not recorded.
+	badStore := &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipeline"}
+	require.NotNil(t, badStore.AddFile(context.TODO(), []byte("abc"), "pipeline/1"))
+
+	assert.Equal(t, singlePart+1, util.GetMetricValue(objectStoreUploads.WithLabelValues(uploadModeSinglePart)))
+	assert.Equal(t, multipart+2, util.GetMetricValue(objectStoreUploads.WithLabelValues(uploadModeMultipart)))
+	count, sum := uploadSizeSamples(t, uploadModeSinglePart)
+	assert.Equal(t, singlePartSizes+1, count)
+	assert.Equal(t, singlePartBytes+3, sum)
+	count, sum = uploadSizeSamples(t, uploadModeMultipart)
+	assert.Equal(t, multipartSizes+2, count)
+	assert.Equal(t, multipartBytes+14, sum)
+}
+
+// operationSizeBuckets returns the number of sizes recorded for operation in each bucket
+// of the size histogram, by upper bound.
+func operationSizeBuckets(t *testing.T, operation string) map[float64]uint64 {
+	metric := &dto.Metric{}
+	require.Nil(t, objectStoreOperationSize.WithLabelValues(operation).(prometheus.Metric).Write(metric))
+	buckets := make(map[float64]uint64)
+	var below uint64
+	for _, bucket := range metric.GetHistogram().GetBucket() {
+		// Prometheus buckets are cumulative.
+		buckets[bucket.GetUpperBound()] = bucket.GetCumulativeCount() - below
+		below = bucket.GetCumulativeCount()
+	}
+	return buckets
+}
+
+func TestRecordOperationSize(t *testing.T) {
+	putBefore := operationSizeBuckets(t, sizedOperationPut)
+	getBefore := operationSizeBuckets(t, sizedOperationGet)
+	store := NewMinioObjectStore(NewFakeMinioClient(), "mlpipeline", "pipelines", false)
+	sizes := map[string]int{"pipelines/small": 500, "pipelines/medium": 3 << 10, "pipelines/large": 2 << 20}
+
+	for filePath, size := range sizes {
+		require.Nil(t, store.AddFile(context.TODO(), make([]byte, size), filePath))
+		_, err := store.GetFile(context.TODO(), filePath)
+		require.Nil(t, err)
+	}
+	// Failed reads are not recorded.
+	_, err := store.GetFile(context.TODO(), "pipelines/missing")
+	require.NotNil(t, err)
+
+	for operation, before := range map[string]map[float64]uint64{sizedOperationPut: putBefore, sizedOperationGet: getBefore} {
+		after := operationSizeBuckets(t, operation)
+		recorded := make(map[float64]uint64)
+		for upperBound, count := range after {
+			if count != before[upperBound] {
+				recorded[upperBound] = count - before[upperBound]
+			}
+		}
+		assert.Equal(t, map[float64]uint64{1 << 10: 1, 4 << 10: 1, 4 << 20: 1}, recorded, operation)
+	}
+}
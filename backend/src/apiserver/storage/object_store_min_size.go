@@ -0,0 +1,66 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Actions taken on yaml files written below the minimum size, as reported in the metrics.
+const (
+	smallYamlFileFlagged  = "flagged"
+	smallYamlFileRejected = "rejected"
+)
+
+// ErrYamlFileTooSmall is the cause of errors returned for rejected yaml files below the
+// minimum size.
+var ErrYamlFileTooSmall = errors.New("yaml file too small")
+
+var objectStoreSmallYamlFiles = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "object_store_small_yaml_files_total",
+	Help: "The number of yaml files written below the minimum plausible size, by action taken",
+}, []string{"action"})
+
+// SetMinYamlFileSize sets the minimum plausible size, in bytes, of the serialized yaml
+// files written by AddAsYamlFile. A valid spec is never a handful of bytes, so smaller
+// files, e.g. empty specs written because of an upstream bug, are counted and logged, and
+// rejected if reject is set. Zero, the default, disables the check.
+func (m *MinioObjectStore) SetMinYamlFileSize(minSize int, reject bool) {
+	m.minYamlFileSize = minSize
+	m.rejectSmallYamlFiles = reject
+}
+
+// checkYamlFileSize flags the serialized yaml file about to be written to filePath if it is
+// below the minimum size, and returns an error if such files are rejected.
+func (m *MinioObjectStore) checkYamlFileSize(filePath string, content []byte) error {
+	if m.minYamlFileSize <= 0 || len(content) >= m.minYamlFileSize {
+		return nil
+	}
+	if !m.rejectSmallYamlFiles {
+		objectStoreSmallYamlFiles.WithLabelValues(smallYamlFileFlagged).Inc()
+		glog.Warningf("Yaml file %v is only %v bytes long, below the minimum of %v bytes", filePath, len(content), m.minYamlFileSize)
+		return nil
+	}
+	objectStoreSmallYamlFiles.WithLabelValues(smallYamlFileRejected).Inc()
+	glog.Warningf("Rejected yaml file %v: %v bytes long, below the minimum of %v bytes", filePath, len(content), m.minYamlFileSize)
+	return util.NewInvalidInputErrorWithDetails(ErrYamlFileTooSmall,
+		fmt.Sprintf("Failed to add yaml file %v: %v bytes long, below the minimum of %v bytes", filePath, len(content), m.minYamlFileSize))
+}
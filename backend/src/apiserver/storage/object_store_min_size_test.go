@@ -0,0 +1,71 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+type minSizeTestSpec struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+var plausibleSpec = minSizeTestSpec{Name: "hello-world", Description: "A spec of a plausible size"}
+
+func TestAddAsYamlFile_SmallFileRejected(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := NewMinioObjectStoreWithOptions(minioClient, "", "pipelines", WithMinYamlFileSize(32, true))
+	rejected := util.GetMetricValue(objectStoreSmallYamlFiles.WithLabelValues(smallYamlFileRejected))
+
+	err := manager.AddAsYamlFile(context.TODO(), minSizeTestSpec{}, "pipelines/1")
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+	assert.True(t, errors.Is(err, ErrYamlFileTooSmall))
+	assert.Equal(t, 0, minioClient.GetObjectCount())
+	assert.Equal(t, rejected+1, util.GetMetricValue(objectStoreSmallYamlFiles.WithLabelValues(smallYamlFileRejected)))
+}
+
+func TestAddAsYamlFile_SmallFileFlagged(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := NewMinioObjectStoreWithOptions(minioClient, "", "pipelines", WithMinYamlFileSize(32, false))
+	flagged := util.GetMetricValue(objectStoreSmallYamlFiles.WithLabelValues(smallYamlFileFlagged))
+
+	require.Nil(t, manager.AddAsYamlFile(context.TODO(), minSizeTestSpec{}, "pipelines/1"))
+
+	assert.Equal(t, 1, minioClient.GetObjectCount())
+	assert.Equal(t, flagged+1, util.GetMetricValue(objectStoreSmallYamlFiles.WithLabelValues(smallYamlFileFlagged)))
+}
+
+func TestAddAsYamlFile_PlausibleSizePasses(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := NewMinioObjectStoreWithOptions(minioClient, "", "pipelines", WithMinYamlFileSize(32, true))
+	flagged := util.GetMetricValue(objectStoreSmallYamlFiles.WithLabelValues(smallYamlFileFlagged))
+	rejected := util.GetMetricValue(objectStoreSmallYamlFiles.WithLabelValues(smallYamlFileRejected))
+
+	require.Nil(t, manager.AddAsYamlFile(context.TODO(), plausibleSpec, "pipelines/1"))
+
+	assert.Equal(t, 1, minioClient.GetObjectCount())
+	assert.Equal(t, flagged, util.GetMetricValue(objectStoreSmallYamlFiles.WithLabelValues(smallYamlFileFlagged)))
+	assert.Equal(t, rejected, util.GetMetricValue(objectStoreSmallYamlFiles.WithLabelValues(smallYamlFileRejected)))
+}
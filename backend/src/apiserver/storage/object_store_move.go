@@ -0,0 +1,118 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/pkg/errors"
+)
+
+// MoveFile moves the file at srcPath to dstPath. S3 has no rename, so the file is copied
+// server-side, and the source is only deleted once the copy has been verified, so a failed
+// move never loses the file. The move is not atomic: readers may see both files meanwhile.
+// The locks of both files are held during the move.
+func (m *MinioObjectStore) MoveFile(ctx context.Context, srcPath string, dstPath string) error {
+	return m.withKeyLocks(ctx, []string{srcPath, dstPath}, func(ctx context.Context) error {
+		return m.moveFile(ctx, srcPath, dstPath)
+	})
+}
+
+func (m *MinioObjectStore) moveFile(ctx context.Context, srcPath string, dstPath string) error {
+	if err := m.checkOpen("move file", srcPath); err != nil {
+		return err
+	}
+	if err := m.checkMaintenance("move file", srcPath); err != nil {
+		return err
+	}
+	for _, filePath := range []string{srcPath, dstPath} {
+		if err := m.checkEnvironment(filePath, true); err != nil {
+			return err
+		}
+	}
+	dstKey := m.resolveKey(ctx, dstPath)
+	if err := m.checkKeyLength("move file", dstPath, dstKey); err != nil {
+		return err
+	}
+	if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationMove, Path: srcPath, TargetPath: dstPath, Size: -1}); err != nil {
+		return err
+	}
+	if err := m.moveObject(ctx, m.resolveKey(ctx, srcPath), dstKey); err != nil {
+		return newObjectStoreError(err, "Failed to move file %v to %v", srcPath, dstPath)
+	}
+	return nil
+}
+
+// moveObject moves the object at srcKey to dstKey, copying it and deleting the original
+// once the copy is verified.
+func (m *MinioObjectStore) moveObject(ctx context.Context, srcKey string, dstKey string) error {
+	return m.moveObjectEditingMetadata(ctx, srcKey, dstKey, nil)
+}
+
+// moveObjectEditingMetadata moves the object at srcKey to dstKey like moveObject. If edit is
+// set, the copy gets the user metadata of the original as changed by edit.
+func (m *MinioObjectStore) moveObjectEditingMetadata(ctx context.Context, srcKey string, dstKey string,
+	edit func(userMetadata map[string]string),
+) error {
+	srcInfo, err := m.minioClient.StatObject(ctx, m.bucketName, srcKey, m.getObjectOptions(ctx))
+	if err != nil {
+		return err
+	}
+	dst := minio.CopyDestOptions{Bucket: m.bucketName, Object: dstKey, Encryption: m.serverSideEncryption(ctx)}
+	if edit != nil {
+		// Replacing the metadata drops the attributes of the original that are not carried
+		// over explicitly.
+		dst.UserMetadata = make(map[string]string, len(srcInfo.UserMetadata)+1)
+		for k, v := range srcInfo.UserMetadata {
+			dst.UserMetadata[k] = v
+		}
+		edit(dst.UserMetadata)
+		dst.ReplaceMetadata = true
+		dst.ContentType = srcInfo.ContentType
+		dst.ContentEncoding = srcInfo.Metadata.Get(contentEncodingHeader)
+	}
+	_, err = m.minioClient.CopyObject(ctx, dst,
+		minio.CopySrcOptions{Bucket: m.bucketName, Object: srcKey, MatchETag: srcInfo.ETag, Encryption: m.copySourceEncryption(ctx)})
+	if err != nil {
+		return err
+	}
+	dstInfo, err := m.minioClient.StatObject(ctx, m.bucketName, dstKey, m.getObjectOptions(ctx))
+	if err != nil {
+		return errors.Wrapf(err, "failed to verify the copy of %v", srcKey)
+	}
+	if !isVerifiedCopy(srcInfo, dstInfo, m.hasOpaqueETags(ctx)) {
+		return errors.Errorf("copy of %v does not match the original, keeping the original", srcKey)
+	}
+	return m.minioClient.DeleteObject(ctx, m.bucketName, srcKey)
+}
+
+// isVerifiedCopy returns whether dst holds the same content as src. With opaqueETags set,
+// e.g. as the objects are encrypted by the store, ETags are not compared.
+func isVerifiedCopy(src minio.ObjectInfo, dst minio.ObjectInfo, opaqueETags bool) bool {
+	srcFile, dstFile := newFileInfo(src.Key, src), newFileInfo(dst.Key, dst)
+	srcFile.OpaqueETag = srcFile.OpaqueETag || opaqueETags
+	dstFile.OpaqueETag = dstFile.OpaqueETag || opaqueETags
+	return isSameFile(*srcFile, *dstFile)
+}
+
+// hasOpaqueETags returns whether the objects written with ctx are encrypted with KMS or
+// customer keys, so their ETags are not hashes of their content. Backends do not always
+// report the encryption of an object when it is stat-ed.
+func (m *MinioObjectStore) hasOpaqueETags(ctx context.Context) bool {
+	sse := m.serverSideEncryption(ctx)
+	return sse != nil && sse.Type() != encrypt.S3
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// corruptingCopyMinioClient copies objects with truncated content.
+type corruptingCopyMinioClient struct {
+	*FakeMinioClient
+}
+
+func (c *corruptingCopyMinioClient) CopyObject(ctx context.Context, dst minio.CopyDestOptions,
+	src minio.CopySrcOptions,
+) (minio.UploadInfo, error) {
+	_, err := c.FakeMinioClient.PutObject(ctx, dst.Bucket, dst.Object, bytes.NewReader([]byte("sp")), 2, minio.PutObjectOptions{})
+	return minio.UploadInfo{}, err
+}
+
+// reEncryptingCopyMinioClient gives copies a new ETag, as backends do for objects encrypted
+// with KMS or customer keys.
+type reEncryptingCopyMinioClient struct {
+	*FakeMinioClient
+}
+
+func (c *reEncryptingCopyMinioClient) CopyObject(ctx context.Context, dst minio.CopyDestOptions,
+	src minio.CopySrcOptions,
+) (minio.UploadInfo, error) {
+	info, err := c.FakeMinioClient.CopyObject(ctx, dst, src)
+	if err != nil {
+		return info, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	copied := c.objectInfo[dst.Object]
+	copied.ETag = "reencrypted-" + copied.ETag
+	c.objectInfo[dst.Object] = copied
+	return info, nil
+}
+
+func TestMoveFile(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "drafts/1"))
+
+	require.Nil(t, manager.MoveFile(context.TODO(), "drafts/1", "pipeline/1"))
+	assert.False(t, minioClient.ExistObject("drafts/1"))
+	data, err := manager.GetFile(context.TODO(), "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+}
+
+func TestMoveFile_SourceNotFound(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+
+	err := manager.MoveFile(context.TODO(), "drafts/1", "pipeline/1")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+	assert.Equal(t, 0, minioClient.GetObjectCount())
+}
+
+func TestMoveFile_UnverifiedCopyKeepsSource(t *testing.T) {
+	minioClient := &corruptingCopyMinioClient{FakeMinioClient: NewFakeMinioClient()}
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "drafts/1"))
+
+	err := manager.MoveFile(context.TODO(), "drafts/1", "pipeline/1")
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "does not match the original")
+	assert.True(t, minioClient.ExistObject("drafts/1"))
+}
+
+func TestMoveFile_EncryptedCopyWithNewETag(t *testing.T) {
+	minioClient := &reEncryptingCopyMinioClient{FakeMinioClient: NewFakeMinioClient()}
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	ctx := withTestCustomerKey(t, context.TODO())
+	require.Nil(t, manager.AddFile(ctx, []byte("spec"), "drafts/1"))
+	// Without a recorded SHA-256, only the sizes can be compared.
+	putRawObject(t, minioClient, "drafts/2", []byte("spec"))
+
+	require.Nil(t, manager.MoveFile(ctx, "drafts/1", "pipeline/1"))
+	require.Nil(t, manager.MoveFile(ctx, "drafts/2", "pipeline/2"))
+	assert.False(t, minioClient.ExistObject("drafts/1"))
+	assert.False(t, minioClient.ExistObject("drafts/2"))
+}
+
+func TestIsSameFile(t *testing.T) {
+	assert.True(t, isSameFile(FileInfo{Size: 4, ETag: "a"}, FileInfo{Size: 4, ETag: "a"}))
+	assert.False(t, isSameFile(FileInfo{Size: 4, ETag: "a"}, FileInfo{Size: 4, ETag: "b"}))
+	assert.False(t, isSameFile(FileInfo{Size: 4, ETag: "a"}, FileInfo{Size: 5, ETag: "a"}))
+	assert.True(t, isSameFile(FileInfo{Size: 4, ETag: "a", OpaqueETag: true}, FileInfo{Size: 4, ETag: "b", OpaqueETag: true}))
+	assert.True(t, isSameFile(FileInfo{Size: 4, ETag: "a-2"}, FileInfo{Size: 4, ETag: "b-2"}))
+	assert.True(t, isSameFile(FileInfo{Size: 4, ETag: "a", ContentSha256: "s"}, FileInfo{Size: 4, ETag: "b", ContentSha256: "s"}))
+	assert.False(t, isSameFile(FileInfo{Size: 4, ETag: "a", ContentSha256: "s"}, FileInfo{Size: 4, ETag: "a", ContentSha256: "t"}))
+}
+
+func TestHasOpaqueETag(t *testing.T) {
+	info := minio.ObjectInfo{Metadata: http.Header{}}
+	assert.False(t, hasOpaqueETag(info))
+	info.Metadata.Set(encrypt.SseGenericHeader, "AES256")
+	assert.False(t, hasOpaqueETag(info))
+	info.Metadata.Set(encrypt.SseGenericHeader, "aws:kms")
+	assert.True(t, hasOpaqueETag(info))
+	info = minio.ObjectInfo{Metadata: http.Header{}}
+	info.Metadata.Set(encrypt.SseCustomerAlgorithm, "AES256")
+	assert.True(t, hasOpaqueETag(info))
+}
+
+func TestMoveFile_InvalidatesCache(t *testing.T) {
+	store, _ := newCachingTestStore(10)
+	ctx := context.TODO()
+	require.Nil(t, store.AddFile(ctx, []byte("old"), "pipeline/1"))
+	require.Nil(t, store.AddFile(ctx, []byte("new"), "drafts/1"))
+	_, err := store.GetFile(ctx, "pipeline/1")
+	require.Nil(t, err)
+
+	require.Nil(t, store.MoveFile(ctx, "drafts/1", "pipeline/1"))
+	data, err := store.GetFile(ctx, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("new"), data)
+	_, err = store.GetFile(ctx, "drafts/1")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"path"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+// KeyNamespace is a logical category of objects sharing the object store.
+type KeyNamespace string
+
+const (
+	KeyNamespaceSpec     KeyNamespace = "spec"
+	KeyNamespaceArtifact KeyNamespace = "artifact"
+	KeyNamespaceBundle   KeyNamespace = "bundle"
+	// KeyNamespaceNone holds the objects the apiserver reads but does not write, e.g. the
+	// artifacts and logs of runs written by Argo and the launchers. Their keys are used as
+	// is, whatever the namespacer.
+	KeyNamespaceNone KeyNamespace = ""
+)
+
+// KeyNamespacer maps a namespace to the key prefix its objects are stored under.
+// Distinct namespaces must map to distinct, non-nested prefixes.
+type KeyNamespacer func(namespace KeyNamespace) string
+
+// DefaultKeyNamespacer stores every namespace under a prefix named after it.
+func DefaultKeyNamespacer(namespace KeyNamespace) string {
+	return string(namespace)
+}
+
+type keyNamespaceContextKey struct{}
+
+// WithKeyNamespace scopes the object store operations using ctx to the given namespace.
+// Operations without a namespace are treated as pipeline spec operations.
+func WithKeyNamespace(ctx context.Context, namespace KeyNamespace) context.Context {
+	return context.WithValue(ctx, keyNamespaceContextKey{}, namespace)
+}
+
+func keyNamespaceFromContext(ctx context.Context) KeyNamespace {
+	if namespace, ok := ctx.Value(keyNamespaceContextKey{}).(KeyNamespace); ok {
+		return namespace
+	}
+	return KeyNamespaceSpec
+}
+
+// SetKeyNamespacer enables namespacing of object keys: the operations store the files at
+// the paths they are given under the prefix of their namespace. Paths, e.g. as returned by
+// GetPipelineKey, never carry the prefix. Files stored before namespacing was enabled are
+// moved under their namespace by MigrateKeyNamespace. A nil namespacer disables it.
+func (m *MinioObjectStore) SetKeyNamespacer(namespacer KeyNamespacer) {
+	m.keyNamespacer = namespacer
+}
+
+// namespaceKey prefixes key with the namespace prefix.
+func (m *MinioObjectStore) namespaceKey(namespace KeyNamespace, key string) string {
+	if m.keyNamespacer == nil || namespace == KeyNamespaceNone {
+		return key
+	}
+	prefix := m.keyNamespacer(namespace)
+	if prefix == "" {
+		return key
+	}
+	return path.Join(prefix, key)
+}
+
+// resolveKey maps the file path an operation was called with to the key of the stored object.
+func (m *MinioObjectStore) resolveKey(ctx context.Context, filePath string) string {
+	return m.transformKey(m.untransformedKey(ctx, filePath))
+}
+
+// untransformedKey returns the logical key of the file at filePath, which the key transform
+// maps to the key of the stored object.
+func (m *MinioObjectStore) untransformedKey(ctx context.Context, filePath string) string {
+	return m.namespaceKey(keyNamespaceFromContext(ctx), m.shardKey(m.rewritePrefix(m.normalizeKey(filePath))))
+}
+
+// MigrateKeyNamespace moves the files under prefix stored without the prefix of the
+// namespace of ctx, because they were stored before key namespacing was enabled, to their
+// namespaced key, copying up to concurrency files at once, like Reshard. Files already
+// moved are not listed again, so re-running it only moves the remaining files. It returns
+// the number of files moved, and the first failure, if any, once every file has been
+// tried.
+func (m *MinioObjectStore) MigrateKeyNamespace(ctx context.Context, prefix string, concurrency int) (int, error) {
+	if err := m.checkOpen("migrate files under", prefix); err != nil {
+		return 0, err
+	}
+	if err := m.checkMaintenance("migrate files under", prefix); err != nil {
+		return 0, err
+	}
+	namespace := keyNamespaceFromContext(ctx)
+	if m.namespaceKey(namespace, "") == "" {
+		return 0, util.NewFailedPreconditionError(errors.New("key namespacing is disabled"),
+			"Failed to migrate files under %v: key namespacing is disabled", prefix)
+	}
+	legacyCtx := WithKeyNamespace(ctx, KeyNamespaceNone)
+	var toMove []string
+	err := m.WalkFiles(legacyCtx, prefix, func(file FileInfo) error {
+		toMove = append(toMove, file.Key)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return m.moveFiles(ctx, toMove, concurrency, "migrate", func(filePath string) (string, string) {
+		return m.resolveKey(legacyCtx, filePath), m.resolveKey(ctx, filePath)
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestNamespaceKey_Disabled(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	assert.Equal(t, "pipeline/1", manager.GetPipelineKey("1"))
+	assert.Equal(t, "pipeline/1", manager.resolveKey(WithKeyNamespace(context.TODO(), KeyNamespaceArtifact), "pipeline/1"))
+}
+
+func TestNamespaceKey_NoOverlap(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	manager.SetKeyNamespacer(DefaultKeyNamespacer)
+
+	// Paths never carry the namespace, the stored keys do.
+	assert.Equal(t, "pipeline/1", manager.GetPipelineKey("1"))
+	specKey := manager.resolveKey(context.TODO(), "pipeline/1")
+	artifactKey := manager.resolveKey(WithKeyNamespace(context.TODO(), KeyNamespaceArtifact), "pipeline/1")
+	bundleKey := manager.resolveKey(WithKeyNamespace(context.TODO(), KeyNamespaceBundle), "pipeline/1")
+	assert.Equal(t, "spec/pipeline/1", specKey)
+	assert.Equal(t, "artifact/pipeline/1", artifactKey)
+	assert.Equal(t, "bundle/pipeline/1", bundleKey)
+
+	keys := []string{specKey, artifactKey, bundleKey}
+	for i := range keys {
+		for j := range keys {
+			if i != j {
+				assert.False(t, strings.HasPrefix(keys[i], keys[j]), "%v overlaps %v", keys[i], keys[j])
+			}
+		}
+	}
+}
+
+func TestNamespaceKey_PathsLikeNamespacedKeys(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	manager.SetKeyNamespacer(DefaultKeyNamespacer)
+
+	// A path starting like the namespace prefix is namespaced all the same.
+	assert.Equal(t, "spec/spec/1", manager.resolveKey(context.TODO(), "spec/1"))
+}
+
+func TestNamespaceKey_None(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	manager.SetKeyNamespacer(func(namespace KeyNamespace) string { return "kfp-" + string(namespace) })
+
+	assert.Equal(t, "artifacts/run/1", manager.resolveKey(WithKeyNamespace(context.TODO(), KeyNamespaceNone), "artifacts/run/1"))
+}
+
+func TestNamespacedRoundTrip(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	manager.SetKeyNamespacer(DefaultKeyNamespacer)
+	specCtx := context.TODO()
+	artifactCtx := WithKeyNamespace(context.TODO(), KeyNamespaceArtifact)
+
+	require.Nil(t, manager.AddFile(specCtx, []byte("spec"), "pipeline/1"))
+	require.Nil(t, manager.AddFile(artifactCtx, []byte("artifact"), "pipeline/1"))
+	assert.Equal(t, 2, minioClient.GetObjectCount())
+	assert.True(t, minioClient.ExistObject("spec/pipeline/1"))
+	assert.True(t, minioClient.ExistObject("artifact/pipeline/1"))
+
+	spec, err := manager.GetFile(specCtx, manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), spec)
+	artifact, err := manager.GetFile(artifactCtx, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("artifact"), artifact)
+
+	require.Nil(t, manager.DeleteFile(artifactCtx, "pipeline/1"))
+	assert.False(t, minioClient.ExistObject("artifact/pipeline/1"))
+	assert.True(t, minioClient.ExistObject("spec/pipeline/1"))
+}
+
+func TestMigrateKeyNamespace(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	for i := 0; i < 5; i++ {
+		require.Nil(t, manager.AddFile(context.TODO(), []byte(fmt.Sprintf("spec %d", i)), fmt.Sprintf("pipeline/%d", i)))
+	}
+	manager.SetKeyNamespacer(DefaultKeyNamespacer)
+	// Stored namespaced already.
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec 5"), "pipeline/5"))
+
+	moved, err := manager.MigrateKeyNamespace(context.TODO(), "pipeline/", 2)
+
+	require.Nil(t, err)
+	assert.Equal(t, 5, moved)
+	for i := 0; i <= 5; i++ {
+		assert.True(t, minioClient.ExistObject(fmt.Sprintf("spec/pipeline/%d", i)))
+		assert.False(t, minioClient.ExistObject(fmt.Sprintf("pipeline/%d", i)))
+		data, err := manager.GetFile(context.TODO(), fmt.Sprintf("pipeline/%d", i))
+		require.Nil(t, err)
+		assert.Equal(t, []byte(fmt.Sprintf("spec %d", i)), data)
+	}
+
+	moved, err = manager.MigrateKeyNamespace(context.TODO(), "pipeline/", 2)
+
+	require.Nil(t, err)
+	assert.Equal(t, 0, moved)
+}
+
+func TestMigrateKeyNamespace_NamespacingDisabled(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+
+	_, err := manager.MigrateKeyNamespace(context.TODO(), "pipeline/", 1)
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.FailedPrecondition, err.(*util.UserError).ExternalStatusCode())
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+)
+
+// GetNewestFile returns the content and the path of the most recently modified of the
+// files at candidatePaths, for specs stored under several historical paths. Missing
+// candidates are skipped; if all of them are missing, a NotFound error is returned.
+func (m *MinioObjectStore) GetNewestFile(ctx context.Context, candidatePaths []string) ([]byte, string, error) {
+	newestPath := ""
+	var newest minio.ObjectInfo
+	for _, filePath := range candidatePaths {
+		if err := m.checkOpen("get file", filePath); err != nil {
+			return nil, "", err
+		}
+		if err := m.checkEnvironment(filePath, false); err != nil {
+			return nil, "", err
+		}
+		info, err := m.minioClient.StatObject(ctx, m.bucketName, m.resolveKey(ctx, filePath), m.getObjectOptions(ctx))
+		if err != nil {
+			if ClassifyError(err) == ErrNotFound {
+				continue
+			}
+			return nil, "", newObjectStoreError(err, "Failed to stat file %v", filePath)
+		}
+		if newestPath == "" || info.LastModified.After(newest.LastModified) {
+			newestPath, newest = filePath, info
+		}
+	}
+	if newestPath == "" {
+		return nil, "", util.NewNotFoundError(ErrNotFound, "None of the files %v exists", strings.Join(candidatePaths, ", "))
+	}
+	data, err := m.GetFile(ctx, newestPath)
+	if err != nil {
+		return nil, "", util.Wrapf(err, "Failed to get the newest file %v", newestPath)
+	}
+	return data, newestPath, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func addFileModifiedAt(t *testing.T, manager *MinioObjectStore, minioClient *FakeMinioClient, filePath string, modified time.Time) {
+	require.Nil(t, manager.AddFile(context.TODO(), []byte(filePath), filePath))
+	info := minioClient.objectInfo[filePath]
+	info.LastModified = modified
+	minioClient.objectInfo[filePath] = info
+}
+
+func TestGetNewestFile(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	now := time.Now()
+	addFileModifiedAt(t, manager, minioClient, "legacy/1.yaml", now.Add(-2*time.Hour))
+	addFileModifiedAt(t, manager, minioClient, "pipelines/1", now)
+	addFileModifiedAt(t, manager, minioClient, "pipelines/1.yaml", now.Add(-time.Hour))
+
+	data, filePath, err := manager.GetNewestFile(context.TODO(),
+		[]string{"legacy/1.yaml", "missing/1", "pipelines/1", "pipelines/1.yaml"})
+	require.Nil(t, err)
+	assert.Equal(t, "pipelines/1", filePath)
+	assert.Equal(t, []byte("pipelines/1"), data)
+}
+
+func TestGetNewestFile_AllMissing(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+
+	_, _, err := manager.GetNewestFile(context.TODO(), []string{"legacy/1.yaml", "pipelines/1"})
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestGetNewestFileError(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipelines"}
+
+	_, _, err := manager.GetNewestFile(context.TODO(), []string{"pipelines/1"})
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"strings"
+)
+
+// SetKeyNormalization sets whether the file paths operations are called with are
+// normalized before being mapped to keys: backslashes, as sent by Windows clients, become
+// slashes, and runs of slashes are collapsed into one, so "a\\b//c" and "a/b/c" name the
+// same object. It is enabled by default. Disable it only to reach objects stored with such
+// characters in their keys.
+func (m *MinioObjectStore) SetKeyNormalization(enabled bool) {
+	m.disableKeyNormalization = !enabled
+}
+
+// normalizeKey returns filePath with backslashes replaced by slashes and duplicate slashes
+// collapsed, unless normalization is disabled.
+func (m *MinioObjectStore) normalizeKey(filePath string) string {
+	if m.disableKeyNormalization || !strings.ContainsAny(filePath, "\\/") {
+		return filePath
+	}
+	filePath = strings.ReplaceAll(filePath, "\\", "/")
+	for strings.Contains(filePath, "//") {
+		filePath = strings.ReplaceAll(filePath, "//", "/")
+	}
+	return filePath
+}
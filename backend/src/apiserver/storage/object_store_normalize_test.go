@@ -0,0 +1,57 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeKey(t *testing.T) {
+	manager := &MinioObjectStore{}
+	assert.Equal(t, "pipelines/a/b.yaml", manager.normalizeKey("pipelines\\a\\b.yaml"))
+	assert.Equal(t, "pipelines/a/b.yaml", manager.normalizeKey("pipelines//a\\\\b.yaml"))
+	assert.Equal(t, "pipelines/a/", manager.normalizeKey("pipelines/a\\/"))
+	assert.Equal(t, "pipelines/a", manager.normalizeKey("pipelines/a"))
+}
+
+func TestAddFile_BackslashKeyRoundTrips(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := NewMinioObjectStoreWithOptions(minioClient, "", "pipelines")
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines\\windows\\spec.yaml"))
+
+	assert.True(t, minioClient.ExistObject("pipelines/windows/spec.yaml"))
+	for _, filePath := range []string{"pipelines\\windows\\spec.yaml", "pipelines/windows/spec.yaml", "pipelines//windows\\spec.yaml"} {
+		data, err := manager.GetFile(context.TODO(), filePath)
+		require.Nil(t, err, filePath)
+		assert.Equal(t, []byte("spec"), data)
+	}
+	require.Nil(t, manager.DeleteFile(context.TODO(), "pipelines//windows/spec.yaml"))
+	assert.Equal(t, 0, minioClient.GetObjectCount())
+}
+
+func TestAddFile_KeyNormalizationDisabled(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := NewMinioObjectStoreWithOptions(minioClient, "", "pipelines", WithKeyNormalization(false))
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines\\windows\\spec.yaml"))
+
+	assert.True(t, minioClient.ExistObject("pipelines\\windows\\spec.yaml"))
+	assert.True(t, manager.Config().DisableKeyNormalization)
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+)
+
+// FindOrphans returns the keys of the pipeline specs for which exists returns false.
+// Only the specs stored directly under the base folder, or under its shard folders when
+// keys are sharded, are considered, so quarantined specs and other nested objects are
+// never reported.
+func (m *MinioObjectStore) FindOrphans(ctx context.Context, exists func(pipelineID string) bool) ([]string, error) {
+	return m.findOrphans(ctx, exists, false)
+}
+
+// ReapOrphans deletes the pipeline specs for which exists returns false, and returns their
+// keys. It stops at the first failed deletion, returning the keys deleted so far.
+func (m *MinioObjectStore) ReapOrphans(ctx context.Context, exists func(pipelineID string) bool) ([]string, error) {
+	return m.findOrphans(ctx, exists, true)
+}
+
+func (m *MinioObjectStore) findOrphans(ctx context.Context, exists func(pipelineID string) bool, reap bool) ([]string, error) {
+	if err := m.checkOpen("list files under", m.baseFolder); err != nil {
+		return nil, err
+	}
+	ctx = WithKeyNamespace(ctx, KeyNamespaceSpec)
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	prefix := ""
+	if m.baseFolder != "" {
+		prefix = m.resolvePrefix(ctx, m.baseFolder+"/")
+	}
+	var orphans []string
+	// Sharded specs are nested in shard folders, so the listing is recursive.
+	opts := minio.ListObjectsOptions{Prefix: m.transformPrefix(prefix), Recursive: true}
+	for object := range m.minioClient.ListObjects(listCtx, m.bucketName, opts) {
+		if object.Err != nil {
+			return nil, newObjectStoreError(object.Err, "Failed to list files under %v", m.baseFolder)
+		}
+		// Skips the objects of other applications.
+		key, ok := m.logicalKey(object.Key)
+		if !ok {
+			continue
+		}
+		pipelineID := strings.TrimPrefix(unshardKey(key), prefix)
+		// Skips the nested objects.
+		if pipelineID == "" || strings.Contains(pipelineID, "/") {
+			continue
+		}
+		if !exists(pipelineID) {
+			orphans = append(orphans, m.GetPipelineKey(pipelineID))
+		}
+	}
+	if !reap {
+		return orphans, nil
+	}
+
+	for i, key := range orphans {
+		if err := m.DeleteFile(ctx, key); err != nil {
+			return orphans[:i], util.Wrapf(err, "Failed to reap orphaned file %v", key)
+		}
+		glog.Infof("Reaped orphaned file %v", key)
+	}
+	return orphans, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOrphanTestStore(t *testing.T) (*MinioObjectStore, *FakeMinioClient) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	ctx := context.TODO()
+	for _, id := range []string{"1", "2", "3", "4"} {
+		require.Nil(t, manager.AddFile(ctx, []byte("spec"), manager.GetPipelineKey(id)))
+	}
+	require.Nil(t, manager.AddFile(ctx, []byte("spec"), manager.GetQuarantineKey("5")))
+	require.Nil(t, manager.AddFile(ctx, []byte("other"), "artifacts/6"))
+	return manager, minioClient
+}
+
+var orphanTestRecords = map[string]bool{"1": true, "3": true}
+
+func orphanTestExists(pipelineID string) bool {
+	return orphanTestRecords[pipelineID]
+}
+
+func TestFindOrphans(t *testing.T) {
+	manager, minioClient := newOrphanTestStore(t)
+
+	orphans, err := manager.FindOrphans(context.TODO(), orphanTestExists)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"pipelines/2", "pipelines/4"}, orphans)
+	assert.Equal(t, 6, minioClient.GetObjectCount())
+}
+
+func TestFindOrphans_ShardedKeys(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines", WithKeySharding(true))
+	ctx := context.TODO()
+	for _, id := range []string{"1", "2", "3", "4"} {
+		require.Nil(t, manager.AddFile(ctx, []byte("spec"), manager.GetPipelineKey(id)))
+	}
+	require.Nil(t, manager.AddFile(ctx, []byte("spec"), manager.GetQuarantineKey("5")))
+
+	orphans, err := manager.FindOrphans(ctx, orphanTestExists)
+	require.Nil(t, err)
+	assert.ElementsMatch(t, []string{"pipelines/2", "pipelines/4"}, orphans)
+
+	_, err = manager.ReapOrphans(ctx, orphanTestExists)
+	require.Nil(t, err)
+	assert.False(t, minioClient.ExistObject("pipelines/"+keyShard("2")+"/2"))
+	assert.True(t, minioClient.ExistObject("pipelines/"+keyShard("1")+"/1"))
+}
+
+func TestReapOrphans(t *testing.T) {
+	manager, minioClient := newOrphanTestStore(t)
+
+	orphans, err := manager.ReapOrphans(context.TODO(), orphanTestExists)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"pipelines/2", "pipelines/4"}, orphans)
+	assert.False(t, minioClient.ExistObject("pipelines/2"))
+	assert.False(t, minioClient.ExistObject("pipelines/4"))
+	assert.True(t, minioClient.ExistObject("pipelines/1"))
+	assert.True(t, minioClient.ExistObject("pipelines/3"))
+	assert.True(t, minioClient.ExistObject("pipelines/quarantine/5"))
+	assert.True(t, minioClient.ExistObject("artifacts/6"))
+}
+
+func TestFindOrphans_Namespaced(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	manager.SetKeyNamespacer(DefaultKeyNamespacer)
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), manager.GetPipelineKey("1")))
+	require.Nil(t, manager.AddFile(WithKeyNamespace(context.TODO(), KeyNamespaceArtifact), []byte("log"), "pipelines/2"))
+
+	orphans, err := manager.ReapOrphans(context.TODO(), orphanTestExists)
+	require.Nil(t, err)
+	assert.Empty(t, orphans)
+
+	orphans, err = manager.ReapOrphans(context.TODO(), func(string) bool { return false })
+	require.Nil(t, err)
+	assert.Equal(t, []string{"pipelines/1"}, orphans)
+	assert.False(t, minioClient.ExistObject("spec/pipelines/1"))
+	assert.True(t, minioClient.ExistObject("artifact/pipelines/2"))
+}
+
+func TestFindOrphans_ListError(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipelines"}
+
+	_, err := manager.FindOrphans(context.TODO(), orphanTestExists)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync/atomic"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// pointerKind marks the pointer objects GetFile follows.
+	pointerKind = "kfp.dev/object-pointer"
+	// maxPointerSize bounds the objects inspected as pointers, so regular files are never parsed.
+	maxPointerSize = 1024
+)
+
+// ErrPointerLoop is the cause of errors returned for pointers that lead back to themselves.
+var ErrPointerLoop = errors.New("object pointer loop")
+
+// objectPointer is a small object standing in for the file stored at Target.
+type objectPointer struct {
+	Kind   string `json:"kind"`
+	Target string `json:"target"`
+}
+
+type pointerReportContextKey struct{}
+
+// pointerReport records whether the reads using a context followed a pointer, so callers
+// caching the content read can tell it is not the content of the object read.
+type pointerReport struct {
+	followed atomic.Bool
+}
+
+// withPointerReport returns a context whose reads record in the returned report whether
+// they followed a pointer.
+func withPointerReport(ctx context.Context) (context.Context, *pointerReport) {
+	report := &pointerReport{}
+	return context.WithValue(ctx, pointerReportContextKey{}, report), report
+}
+
+// SetPointerResolution makes GetFile follow pointer objects to the file they reference,
+// through at most maxDepth pointers. Zero disables pointer resolution.
+func (m *MinioObjectStore) SetPointerResolution(maxDepth int) {
+	m.maxPointerDepth = maxDepth
+}
+
+// AddPointerFile stores a pointer at filePath, referencing the file at targetPath.
+func (m *MinioObjectStore) AddPointerFile(ctx context.Context, filePath string, targetPath string) error {
+	pointer, err := json.Marshal(objectPointer{Kind: pointerKind, Target: targetPath})
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to marshal pointer to %v", targetPath)
+	}
+	return m.AddFile(ctx, pointer, filePath)
+}
+
+// followPointers resolves data, read from filePath, to the content of the file it
+// ultimately references. Content that is not a pointer is returned as is.
+func (m *MinioObjectStore) followPointers(ctx context.Context, filePath string, data []byte) ([]byte, error) {
+	visited := map[string]bool{filePath: true}
+	for depth := 0; ; depth++ {
+		target, ok := parsePointer(data)
+		if !ok {
+			return data, nil
+		}
+		if visited[target] {
+			return nil, util.NewInternalServerError(ErrPointerLoop, "Failed to get file %v: pointer to %v loops", filePath, target)
+		}
+		if depth >= m.maxPointerDepth {
+			return nil, util.NewInternalServerError(errors.Errorf("more than %v pointers followed", m.maxPointerDepth),
+				"Failed to get file %v", filePath)
+		}
+		visited[target] = true
+		if report, ok := ctx.Value(pointerReportContextKey{}).(*pointerReport); ok {
+			report.followed.Store(true)
+		}
+		var err error
+		data, err = m.getDecodedFile(ctx, target)
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to follow pointer from %v", filePath)
+		}
+	}
+}
+
+// parsePointer returns the target of data if it is a pointer object.
+func parsePointer(data []byte) (string, bool) {
+	if len(data) > maxPointerSize || !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return "", false
+	}
+	var pointer objectPointer
+	if err := json.Unmarshal(data, &pointer); err != nil {
+		return "", false
+	}
+	if pointer.Kind != pointerKind || pointer.Target == "" {
+		return "", false
+	}
+	return pointer.Target, true
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func newPointerStore(maxDepth int) *MinioObjectStore {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	manager.SetPointerResolution(maxDepth)
+	return manager
+}
+
+func TestGetFile_FollowsPointer(t *testing.T) {
+	manager := newPointerStore(3)
+	ctx := context.TODO()
+	require.Nil(t, manager.AddFile(ctx, []byte("spec"), "blobs/abc"))
+	require.Nil(t, manager.AddPointerFile(ctx, manager.GetPipelineKey("1"), "blobs/abc"))
+
+	data, err := manager.GetFile(ctx, manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+}
+
+func TestGetFile_PointerLoop(t *testing.T) {
+	manager := newPointerStore(10)
+	ctx := context.TODO()
+	require.Nil(t, manager.AddPointerFile(ctx, "pipeline/1", "pipeline/2"))
+	require.Nil(t, manager.AddPointerFile(ctx, "pipeline/2", "pipeline/1"))
+
+	_, err := manager.GetFile(ctx, "pipeline/1")
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrPointerLoop))
+}
+
+func TestGetFile_PointerDepthExceeded(t *testing.T) {
+	manager := newPointerStore(1)
+	ctx := context.TODO()
+	require.Nil(t, manager.AddFile(ctx, []byte("spec"), "blobs/abc"))
+	require.Nil(t, manager.AddPointerFile(ctx, "pipeline/2", "blobs/abc"))
+	require.Nil(t, manager.AddPointerFile(ctx, "pipeline/1", "pipeline/2"))
+
+	_, err := manager.GetFile(ctx, "pipeline/1")
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "more than 1 pointers followed")
+}
+
+func TestGetFile_DanglingPointer(t *testing.T) {
+	manager := newPointerStore(3)
+	require.Nil(t, manager.AddPointerFile(context.TODO(), "pipeline/1", "blobs/missing"))
+
+	_, err := manager.GetFile(context.TODO(), "pipeline/1")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestGetFile_PlainObjectUnaffected(t *testing.T) {
+	manager := newPointerStore(3)
+	content := []byte(`{"kind": "Pipeline", "target": "blobs/abc"}`)
+	require.Nil(t, manager.AddFile(context.TODO(), content, "pipeline/1"))
+
+	data, err := manager.GetFile(context.TODO(), "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, content, data)
+}
+
+func TestGetFile_PointersNotFollowedByDefault(t *testing.T) {
+	manager := newPointerStore(0)
+	require.Nil(t, manager.AddPointerFile(context.TODO(), "pipeline/1", "blobs/abc"))
+
+	data, err := manager.GetFile(context.TODO(), "pipeline/1")
+	require.Nil(t, err)
+	target, ok := parsePointer(data)
+	assert.True(t, ok)
+	assert.Equal(t, "blobs/abc", target)
+}
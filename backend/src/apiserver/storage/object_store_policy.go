@@ -0,0 +1,127 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+// PolicyOperation is the kind of access to a file an object store policy decides on.
+type PolicyOperation string
+
+const (
+	PolicyRead   PolicyOperation = "read"
+	PolicyWrite  PolicyOperation = "write"
+	PolicyDelete PolicyOperation = "delete"
+)
+
+// ObjectStorePolicy allows an operation on filePath by returning nil, and denies it by
+// returning the reason why. The principal of the operation is PrincipalFromContext(ctx).
+type ObjectStorePolicy func(ctx context.Context, operation PolicyOperation, filePath string) error
+
+// ErrPolicyDenied is the cause of errors returned for operations denied by a policy.
+var ErrPolicyDenied = errors.New("object store operation denied by policy")
+
+type principalContextKey struct{}
+
+// WithPrincipal sets the principal the operations using ctx are performed on behalf of.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal set with WithPrincipal, empty if none.
+func PrincipalFromContext(ctx context.Context) string {
+	principal, _ := ctx.Value(principalContextKey{}).(string)
+	return principal
+}
+
+// PolicyObjectStore consults a policy before every operation, independently of the access
+// control of the backend, and fails the operations it denies with a PermissionDenied error
+// without calling the wrapped store. Moves need the file to be deleted from its source and
+// written to its destination.
+type PolicyObjectStore struct {
+	ObjectStoreInterface
+	policy ObjectStorePolicy
+}
+
+func NewPolicyObjectStore(store ObjectStoreInterface, policy ObjectStorePolicy) *PolicyObjectStore {
+	return &PolicyObjectStore{ObjectStoreInterface: store, policy: policy}
+}
+
+func (p *PolicyObjectStore) AddFile(ctx context.Context, file []byte, filePath string) error {
+	if err := p.check(ctx, PolicyWrite, filePath); err != nil {
+		return err
+	}
+	return p.ObjectStoreInterface.AddFile(ctx, file, filePath)
+}
+
+func (p *PolicyObjectStore) DeleteFile(ctx context.Context, filePath string) error {
+	if err := p.check(ctx, PolicyDelete, filePath); err != nil {
+		return err
+	}
+	return p.ObjectStoreInterface.DeleteFile(ctx, filePath)
+}
+
+func (p *PolicyObjectStore) GetFile(ctx context.Context, filePath string) ([]byte, error) {
+	if err := p.check(ctx, PolicyRead, filePath); err != nil {
+		return nil, err
+	}
+	return p.ObjectStoreInterface.GetFile(ctx, filePath)
+}
+
+func (p *PolicyObjectStore) AddAsYamlFile(ctx context.Context, o interface{}, filePath string) error {
+	if err := p.check(ctx, PolicyWrite, filePath); err != nil {
+		return err
+	}
+	return p.ObjectStoreInterface.AddAsYamlFile(ctx, o, filePath)
+}
+
+func (p *PolicyObjectStore) GetFromYamlFile(ctx context.Context, o interface{}, filePath string) error {
+	if err := p.check(ctx, PolicyRead, filePath); err != nil {
+		return err
+	}
+	return p.ObjectStoreInterface.GetFromYamlFile(ctx, o, filePath)
+}
+
+func (p *PolicyObjectStore) GetFileInfo(ctx context.Context, filePath string) (*FileInfo, error) {
+	if err := p.check(ctx, PolicyRead, filePath); err != nil {
+		return nil, err
+	}
+	return p.ObjectStoreInterface.GetFileInfo(ctx, filePath)
+}
+
+func (p *PolicyObjectStore) MoveFile(ctx context.Context, srcPath string, dstPath string) error {
+	if err := p.check(ctx, PolicyDelete, srcPath); err != nil {
+		return err
+	}
+	if err := p.check(ctx, PolicyWrite, dstPath); err != nil {
+		return err
+	}
+	return p.ObjectStoreInterface.MoveFile(ctx, srcPath, dstPath)
+}
+
+// check returns a PermissionDenied error if the policy denies operation on filePath.
+func (p *PolicyObjectStore) check(ctx context.Context, operation PolicyOperation, filePath string) error {
+	err := p.policy(ctx, operation, filePath)
+	if err == nil {
+		return nil
+	}
+	return util.NewPermissionDeniedError(fmt.Errorf("%w: %w", ErrPolicyDenied, err),
+		"Failed to %v %v: denied by policy", operation, filePath)
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// protectedPrefixPolicy only lets the admin change the files under "pipelines/protected/".
+func protectedPrefixPolicy(ctx context.Context, operation PolicyOperation, filePath string) error {
+	if operation == PolicyRead || PrincipalFromContext(ctx) == "admin" || !strings.HasPrefix(filePath, "pipelines/protected/") {
+		return nil
+	}
+	return errors.Errorf("%v may not %v protected files", PrincipalFromContext(ctx), operation)
+}
+
+func newPolicyTestStore(t *testing.T) (*PolicyObjectStore, *FakeMinioClient) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines/protected/1"))
+	return NewPolicyObjectStore(manager, protectedPrefixPolicy), minioClient
+}
+
+func assertPolicyDenied(t *testing.T, err error) {
+	require.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+	assert.True(t, errors.Is(err, ErrPolicyDenied))
+}
+
+func TestPolicyObjectStore_DeniesWritesToProtectedPrefix(t *testing.T) {
+	store, minioClient := newPolicyTestStore(t)
+	ctx := WithPrincipal(context.TODO(), "alice")
+
+	assertPolicyDenied(t, store.AddFile(ctx, []byte("changed"), "pipelines/protected/1"))
+	assertPolicyDenied(t, store.AddAsYamlFile(ctx, map[string]string{"name": "changed"}, "pipelines/protected/2"))
+	assertPolicyDenied(t, store.DeleteFile(ctx, "pipelines/protected/1"))
+	assertPolicyDenied(t, store.MoveFile(ctx, "pipelines/protected/1", "pipelines/1"))
+	assertPolicyDenied(t, store.MoveFile(ctx, "pipelines/other", "pipelines/protected/1"))
+
+	data, err := store.GetFile(ctx, "pipelines/protected/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+	assert.Equal(t, 1, minioClient.GetObjectCount())
+	require.Nil(t, store.AddFile(ctx, []byte("spec"), "pipelines/unprotected"))
+}
+
+func TestPolicyObjectStore_AllowsAdmin(t *testing.T) {
+	store, minioClient := newPolicyTestStore(t)
+	ctx := WithPrincipal(context.TODO(), "admin")
+
+	require.Nil(t, store.AddFile(ctx, []byte("changed"), "pipelines/protected/1"))
+	require.Nil(t, store.MoveFile(ctx, "pipelines/protected/1", "pipelines/protected/2"))
+	data, err := store.GetFile(ctx, "pipelines/protected/2")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("changed"), data)
+	require.Nil(t, store.DeleteFile(ctx, "pipelines/protected/2"))
+	assert.Equal(t, 0, minioClient.GetObjectCount())
+}
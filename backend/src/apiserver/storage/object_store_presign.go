@@ -0,0 +1,48 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// maxPresignedURLExpiry is the longest validity of presigned URLs the backend accepts.
+const maxPresignedURLExpiry = 7 * 24 * time.Hour
+
+// GetPresignedURL returns a URL from which the file can be downloaded without credentials
+// until expiry elapses. Every byte of the key outside of the unreserved characters of RFC
+// 3986 and "/" is percent-encoded, as UTF-8 for unicode, which is also how the backend
+// encodes the key of the canonical request it checks the signature against. Keys with
+// spaces, "+", "%" or unicode thus stay both reachable and validly signed.
+func (m *MinioObjectStore) GetPresignedURL(ctx context.Context, filePath string, expiry time.Duration) (string, error) {
+	if err := m.checkOpen("presign", filePath); err != nil {
+		return "", err
+	}
+	if err := m.checkEnvironment(filePath, false); err != nil {
+		return "", err
+	}
+	if expiry < time.Second || expiry > maxPresignedURLExpiry {
+		return "", util.NewInvalidInputError("Invalid expiry %v of presigned URL of file %v: must be between 1s and %v",
+			expiry, filePath, maxPresignedURLExpiry)
+	}
+	presignedURL, err := m.minioClient.PresignedGetObject(ctx, m.bucketName, m.resolveKey(ctx, filePath), expiry, nil)
+	if err != nil {
+		return "", newObjectStoreError(err, "Failed to presign file %v", filePath)
+	}
+	return presignedURL.String(), nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	presignAccessKey = "minio"
+	presignSecretKey = "minio123"
+	presignRegion    = "us-east-1"
+)
+
+// newPresigningObjectStore returns a store on a real minio client. Presigning with a known
+// region makes no request, so no backend is needed.
+func newPresigningObjectStore(t *testing.T) *MinioObjectStore {
+	client, err := minio.New("minio-service.kubeflow:9000", &minio.Options{
+		Creds:  credentials.NewStaticV4(presignAccessKey, presignSecretKey, ""),
+		Region: presignRegion,
+	})
+	require.Nil(t, err)
+	return NewMinioObjectStore(&MinioClient{Client: client}, "mlpipeline", "pipelines", false)
+}
+
+// uriEncode encodes s like S3 does when building canonical requests: every byte but the
+// unreserved characters is percent-encoded, and "/" too unless keepSlash.
+func uriEncode(s string, keepSlash bool) string {
+	var builder strings.Builder
+	for _, b := range []byte(s) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~', b == '/' && keepSlash:
+			builder.WriteByte(b)
+		default:
+			fmt.Fprintf(&builder, "%%%02X", b)
+		}
+	}
+	return builder.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// verifyPresignedURL checks the signature of a presigned GET URL the way the backend does,
+// from the URL as received: the path is decoded then canonically re-encoded.
+func verifyPresignedURL(t *testing.T, presignedURL *url.URL) {
+	query := presignedURL.Query()
+	signature := query.Get("X-Amz-Signature")
+	query.Del("X-Amz-Signature")
+	var params []string
+	for k, values := range query {
+		for _, v := range values {
+			params = append(params, uriEncode(k, false)+"="+uriEncode(v, false))
+		}
+	}
+	sort.Strings(params)
+	canonicalRequest := strings.Join([]string{
+		"GET",
+		uriEncode(presignedURL.Path, true),
+		strings.Join(params, "&"),
+		"host:" + presignedURL.Host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+	amzDate := query.Get("X-Amz-Date")
+	scope := amzDate[:8] + "/" + presignRegion + "/s3/aws4_request"
+	hashedRequest := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hashedRequest[:])
+	signingKey := hmacSHA256([]byte("AWS4"+presignSecretKey), amzDate[:8])
+	signingKey = hmacSHA256(signingKey, presignRegion)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+
+	assert.Equal(t, hex.EncodeToString(hmacSHA256(signingKey, stringToSign)), signature)
+	assert.Equal(t, presignAccessKey+"/"+scope, query.Get("X-Amz-Credential"))
+}
+
+func TestGetPresignedURL_Escaping(t *testing.T) {
+	manager := newPresigningObjectStore(t)
+	for name, key := range map[string]string{
+		"plain":     "pipelines/spec.yaml",
+		"space":     "pipelines/my spec.yaml",
+		"plus":      "pipelines/a+b.yaml",
+		"percent":   "pipelines/100%.yaml",
+		"escaped":   "pipelines/a%20b.yaml",
+		"unicode":   "pipelines/spéc-パイプライン.yaml",
+		"reserved":  "pipelines/a?b#c&d=e;f.yaml",
+		"mixed":     "pipelines/dir with space/ü+%2F.yaml",
+		"separator": "pipelines/a:b@c,d.yaml",
+	} {
+		t.Run(name, func(t *testing.T) {
+			rawURL, err := manager.GetPresignedURL(context.TODO(), key, time.Hour)
+			require.Nil(t, err)
+
+			presignedURL, err := url.Parse(rawURL)
+			require.Nil(t, err)
+			assert.Equal(t, "/mlpipeline/"+key, presignedURL.Path)
+			assert.Equal(t, "3600", presignedURL.Query().Get("X-Amz-Expires"))
+			assert.NotContains(t, presignedURL.EscapedPath(), " ")
+			assert.NotContains(t, presignedURL.EscapedPath(), "+")
+			verifyPresignedURL(t, presignedURL)
+		})
+	}
+}
+
+func TestGetPresignedURL_InvalidExpiry(t *testing.T) {
+	manager := newPresigningObjectStore(t)
+	for _, expiry := range []time.Duration{0, time.Millisecond, 8 * 24 * time.Hour} {
+		_, err := manager.GetPresignedURL(context.TODO(), "pipelines/1", expiry)
+		require.NotNil(t, err)
+		assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+	}
+}
+
+func TestGetPresignedURL_ResolvesKey(t *testing.T) {
+	manager := NewMinioObjectStore(NewFakeMinioClient(), "mlpipeline", "pipelines", false)
+
+	rawURL, err := manager.GetPresignedURL(context.TODO(), "pipelines//my spec", time.Minute)
+
+	require.Nil(t, err)
+	assert.Equal(t, "http://minio.fake/mlpipeline/pipelines/my%20spec?X-Amz-Expires=60", rawURL)
+}
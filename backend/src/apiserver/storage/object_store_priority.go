@@ -0,0 +1,162 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+)
+
+// Priority orders the operations waiting for the limits of the store: operations of higher
+// priority are admitted first.
+type Priority int
+
+const (
+	// PriorityLow is the priority of background work, e.g. reconciliation, and of the
+	// operations whose context carries no priority.
+	PriorityLow Priority = 0
+	// PriorityHigh is the priority of the operations serving interactive user requests.
+	PriorityHigh Priority = 10
+)
+
+type priorityContextKey struct{}
+
+// WithPriority sets the priority of the object store operations using ctx.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityContextKey{}, priority)
+}
+
+func priorityFromContext(ctx context.Context) Priority {
+	if priority, ok := ctx.Value(priorityContextKey{}).(Priority); ok {
+		return priority
+	}
+	return PriorityLow
+}
+
+// prioritySemaphore is a weighted semaphore admitting its waiters by priority, and in
+// arrival order among waiters of the same priority. Like semaphore.Weighted, a waiter
+// blocks the waiters behind it until enough of the semaphore is released for it, so large
+// acquisitions are not starved by small ones.
+type prioritySemaphore struct {
+	size int64
+
+	mutex   sync.Mutex
+	used    int64
+	arrived uint64
+	waiters priorityWaiters
+}
+
+type priorityWaiter struct {
+	n        int64
+	priority Priority
+	arrival  uint64
+	ready    chan struct{}
+	index    int
+}
+
+func newPrioritySemaphore(size int64) *prioritySemaphore {
+	return &prioritySemaphore{size: size}
+}
+
+// Acquire acquires n of the semaphore, waiting for it to be released by others, or for ctx
+// to be done, in which case nothing is acquired and the error of ctx is returned.
+func (s *prioritySemaphore) Acquire(ctx context.Context, n int64, priority Priority) error {
+	s.mutex.Lock()
+	if err := ctx.Err(); err != nil {
+		s.mutex.Unlock()
+		return err
+	}
+	if len(s.waiters) == 0 && s.size-s.used >= n {
+		s.used += n
+		s.mutex.Unlock()
+		return nil
+	}
+	waiter := &priorityWaiter{n: n, priority: priority, arrival: s.arrived, ready: make(chan struct{})}
+	s.arrived++
+	heap.Push(&s.waiters, waiter)
+	s.mutex.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return nil
+	case <-ctx.Done():
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		select {
+		case <-waiter.ready:
+			// Admitted while giving up: gives the semaphore back.
+			s.used -= n
+		default:
+			heap.Remove(&s.waiters, waiter.index)
+		}
+		// The waiters blocked behind this one may fit now.
+		s.admitWaiters()
+		return ctx.Err()
+	}
+}
+
+// Release releases n of the semaphore, admitting the waiters that fit.
+func (s *prioritySemaphore) Release(n int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.used -= n
+	s.admitWaiters()
+}
+
+// admitWaiters admits the waiters in order for as long as the next one fits.
+func (s *prioritySemaphore) admitWaiters() {
+	for len(s.waiters) > 0 {
+		next := s.waiters[0]
+		if s.size-s.used < next.n {
+			return
+		}
+		s.used += next.n
+		heap.Pop(&s.waiters)
+		close(next.ready)
+	}
+}
+
+// priorityWaiters is a heap of waiters, the next one to admit first.
+type priorityWaiters []*priorityWaiter
+
+func (w priorityWaiters) Len() int { return len(w) }
+
+func (w priorityWaiters) Less(i, j int) bool {
+	if w[i].priority != w[j].priority {
+		return w[i].priority > w[j].priority
+	}
+	return w[i].arrival < w[j].arrival
+}
+
+func (w priorityWaiters) Swap(i, j int) {
+	w[i], w[j] = w[j], w[i]
+	w[i].index = i
+	w[j].index = j
+}
+
+func (w *priorityWaiters) Push(x any) {
+	waiter := x.(*priorityWaiter)
+	waiter.index = len(*w)
+	*w = append(*w, waiter)
+}
+
+func (w *priorityWaiters) Pop() any {
+	old := *w
+	waiter := old[len(old)-1]
+	old[len(old)-1] = nil
+	*w = old[:len(old)-1]
+	return waiter
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waitForWaiters waits until count acquisitions are waiting for the semaphore.
+func waitForWaiters(t *testing.T, s *prioritySemaphore, count int) {
+	require.Eventually(t, func() bool {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		return len(s.waiters) == count
+	}, 5*time.Second, time.Millisecond)
+}
+
+// acquireInOrder acquires n of the semaphore in the background, reporting name on admitted
+// once it got it, before releasing it.
+func acquireInOrder(s *prioritySemaphore, n int64, priority Priority, name string, admitted chan<- string) {
+	go func() {
+		if err := s.Acquire(context.TODO(), n, priority); err != nil {
+			admitted <- err.Error()
+			return
+		}
+		admitted <- name
+		s.Release(n)
+	}()
+}
+
+func TestPrioritySemaphore_HighPriorityAdmittedFirst(t *testing.T) {
+	s := newPrioritySemaphore(10)
+	require.Nil(t, s.Acquire(context.TODO(), 10, PriorityLow))
+	admitted := make(chan string, 4)
+	for i, name := range []string{"low 1", "low 2", "low 3"} {
+		acquireInOrder(s, 10, PriorityLow, name, admitted)
+		waitForWaiters(t, s, i+1)
+	}
+	acquireInOrder(s, 10, PriorityHigh, "high", admitted)
+	waitForWaiters(t, s, 4)
+
+	s.Release(10)
+
+	var order []string
+	for range 4 {
+		order = append(order, <-admitted)
+	}
+	assert.Equal(t, []string{"high", "low 1", "low 2", "low 3"}, order)
+}
+
+func TestPrioritySemaphore_NoWaitUnderCapacity(t *testing.T) {
+	s := newPrioritySemaphore(10)
+
+	require.Nil(t, s.Acquire(context.TODO(), 4, PriorityLow))
+	require.Nil(t, s.Acquire(context.TODO(), 6, PriorityHigh))
+
+	s.Release(10)
+	assert.Equal(t, int64(0), s.used)
+}
+
+func TestPrioritySemaphore_CancelledWaiterUnblocksOthers(t *testing.T) {
+	s := newPrioritySemaphore(10)
+	require.Nil(t, s.Acquire(context.TODO(), 5, PriorityLow))
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancelled := make(chan error)
+	go func() {
+		cancelled <- s.Acquire(ctx, 10, PriorityHigh)
+	}()
+	waitForWaiters(t, s, 1)
+	admitted := make(chan string, 1)
+	// Fits, but waits behind the larger waiter of higher priority.
+	acquireInOrder(s, 5, PriorityLow, "small", admitted)
+	waitForWaiters(t, s, 2)
+
+	cancel()
+
+	assert.Equal(t, context.Canceled, <-cancelled)
+	assert.Equal(t, "small", <-admitted)
+	s.Release(5)
+	assert.Equal(t, int64(0), s.used)
+}
+
+func TestMaxInFlightBytes_HighPriorityAdmittedFirst(t *testing.T) {
+	store, minioClient := newBlockingPutStore(100, "pipelines/large")
+	largeDone := make(chan error)
+	go func() {
+		largeDone <- store.AddFile(context.TODO(), bytes.Repeat([]byte("a"), 100), "pipelines/large")
+	}()
+	require.Equal(t, "pipelines/large", <-minioClient.started)
+	done := make(chan error, 2)
+	go func() {
+		done <- store.AddFile(context.TODO(), bytes.Repeat([]byte("b"), 100), "pipelines/background")
+	}()
+	waitForWaiters(t, store.inFlightBytes, 1)
+	go func() {
+		done <- store.AddFile(WithPriority(context.TODO(), PriorityHigh), bytes.Repeat([]byte("c"), 100), "pipelines/interactive")
+	}()
+	waitForWaiters(t, store.inFlightBytes, 2)
+
+	close(minioClient.blocked["pipelines/large"])
+
+	require.Nil(t, <-largeDone)
+	assert.Equal(t, "pipelines/interactive", <-minioClient.started)
+	assert.Equal(t, "pipelines/background", <-minioClient.started)
+	require.Nil(t, <-done)
+	require.Nil(t, <-done)
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// canaryFolder holds the short-lived objects written by probes, under the base folder.
+const canaryFolder = ".canary"
+
+var canaryContent = []byte("kfp-canary")
+
+var objectStoreProbeLatency = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "object_store_probe_latency_seconds",
+	Help: "The round-trip latency of the last object store probe, by operation",
+}, []string{"operation"})
+
+// LatencyReport holds the round-trip durations of one object store probe.
+type LatencyReport struct {
+	Write  time.Duration
+	Read   time.Duration
+	Delete time.Duration
+}
+
+// MeasureLatency writes, reads back and deletes a tiny canary object, and reports how long
+// each operation took. The durations are also exported as the object_store_probe_latency_seconds gauge.
+// The canary goes straight through the client, so it measures the backend alone and is
+// deleted for good, even when deleted files go to the recycle bin.
+func (m *MinioObjectStore) MeasureLatency(ctx context.Context) (LatencyReport, error) {
+	var report LatencyReport
+	filePath := m.newCanaryKey()
+	if err := m.checkOpen("probe", filePath); err != nil {
+		return report, err
+	}
+	if err := m.checkMaintenance("probe", filePath); err != nil {
+		return report, err
+	}
+	key := m.resolveKey(ctx, filePath)
+	// The canary is deleted by the probe itself, so the probe is audited as one write.
+	if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationWrite, Path: filePath, Size: int64(len(canaryContent))}); err != nil {
+		return report, err
+	}
+
+	start := m.now()
+	_, err := m.minioClient.PutObject(ctx, m.bucketName, key, bytes.NewReader(canaryContent),
+		int64(len(canaryContent)), m.putObjectOptions(ctx))
+	if err != nil {
+		return report, newObjectStoreError(err, "Failed to measure object store write latency")
+	}
+	report.Write = m.now().Sub(start)
+
+	start = m.now()
+	if err := m.readCanary(ctx, key); err != nil {
+		m.minioClient.DeleteObject(ctx, m.bucketName, key)
+		return report, newObjectStoreError(err, "Failed to measure object store read latency")
+	}
+	report.Read = m.now().Sub(start)
+
+	start = m.now()
+	if err := m.minioClient.DeleteObject(ctx, m.bucketName, key); err != nil {
+		return report, newObjectStoreError(err, "Failed to measure object store delete latency")
+	}
+	report.Delete = m.now().Sub(start)
+
+	objectStoreProbeLatency.WithLabelValues("write").Set(report.Write.Seconds())
+	objectStoreProbeLatency.WithLabelValues("read").Set(report.Read.Seconds())
+	objectStoreProbeLatency.WithLabelValues("delete").Set(report.Delete.Seconds())
+	return report, nil
+}
+
+// newCanaryKey returns a unique key for a canary object.
+func (m *MinioObjectStore) newCanaryKey() string {
+	return path.Join(m.baseFolder, canaryFolder, uuid.NewString())
+}
+
+// PurgeStaleCanaries deletes the canary objects under the base folder last modified more
+// than olderThan ago, left behind by probes interrupted before cleaning up after
+// themselves, and returns the number of objects deleted.
+func (m *MinioObjectStore) PurgeStaleCanaries(ctx context.Context, olderThan time.Duration) (int, error) {
+	prefix := path.Join(m.baseFolder, canaryFolder) + "/"
+	if err := m.checkOpen("purge", prefix); err != nil {
+		return 0, err
+	}
+	if err := m.checkMaintenance("purge", prefix); err != nil {
+		return 0, err
+	}
+	cutoff := m.now().Add(-olderThan)
+	var stale []string
+	err := m.WalkFiles(ctx, prefix, func(file FileInfo) error {
+		if file.LastModified.Before(cutoff) {
+			stale = append(stale, file.Key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for i, filePath := range stale {
+		if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationDelete, Path: filePath, Size: -1}); err != nil {
+			return i, err
+		}
+		// Canaries are deleted for good, even when deleted files go to the recycle bin.
+		if err := m.minioClient.DeleteObject(ctx, m.bucketName, m.resolveKey(ctx, filePath)); err != nil && ClassifyError(err) != ErrNotFound {
+			return i, newObjectStoreError(err, "Failed to purge canary %v", filePath)
+		}
+	}
+	return len(stale), nil
+}
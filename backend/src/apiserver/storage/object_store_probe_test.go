@@ -0,0 +1,92 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// slowMinioClient delays every call to the fake minio client by a fixed duration per operation.
+type slowMinioClient struct {
+	*FakeMinioClient
+	putDelay    time.Duration
+	getDelay    time.Duration
+	deleteDelay time.Duration
+}
+
+func (c *slowMinioClient) PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader,
+	objectSize int64, opts minio.PutObjectOptions,
+) (int64, error) {
+	time.Sleep(c.putDelay)
+	return c.FakeMinioClient.PutObject(ctx, bucketName, objectName, reader, objectSize, opts)
+}
+
+func (c *slowMinioClient) GetObject(ctx context.Context, bucketName, objectName string,
+	opts minio.GetObjectOptions,
+) (io.Reader, error) {
+	time.Sleep(c.getDelay)
+	return c.FakeMinioClient.GetObject(ctx, bucketName, objectName, opts)
+}
+
+func (c *slowMinioClient) DeleteObject(ctx context.Context, bucketName, objectName string) error {
+	time.Sleep(c.deleteDelay)
+	return c.FakeMinioClient.DeleteObject(ctx, bucketName, objectName)
+}
+
+func TestMeasureLatency(t *testing.T) {
+	minioClient := &slowMinioClient{
+		FakeMinioClient: NewFakeMinioClient(),
+		putDelay:        30 * time.Millisecond,
+		getDelay:        10 * time.Millisecond,
+		deleteDelay:     20 * time.Millisecond,
+	}
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+
+	report, err := manager.MeasureLatency(context.TODO())
+	require.Nil(t, err)
+	assert.GreaterOrEqual(t, report.Write, 30*time.Millisecond)
+	assert.GreaterOrEqual(t, report.Read, 10*time.Millisecond)
+	assert.GreaterOrEqual(t, report.Delete, 20*time.Millisecond)
+	assert.Less(t, report.Write, time.Second)
+	assert.Less(t, report.Read, time.Second)
+	assert.Less(t, report.Delete, time.Second)
+	assert.Equal(t, 0, minioClient.GetObjectCount())
+	assert.GreaterOrEqual(t, util.GetMetricValue(objectStoreProbeLatency.WithLabelValues("write")), 0.03)
+}
+
+func TestMeasureLatency_SoftDeleteLeavesNoCopy(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipeline", WithSoftDelete(true))
+
+	_, err := manager.MeasureLatency(context.TODO())
+	require.Nil(t, err)
+	assert.Equal(t, 0, minioClient.GetObjectCount())
+}
+
+func TestMeasureLatencyError(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipeline"}
+	_, err := manager.MeasureLatency(context.TODO())
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.Error(), "write latency")
+}
@@ -0,0 +1,275 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// QuorumObjectStore replicates files to several backing stores. Writes and deletes succeed
+// once a majority of the stores acknowledge them. Reads return the copy held by a majority
+// of the stores as soon as it is known, and repair the stores holding a missing or
+// divergent copy in the background, if they can copy files as stored, as MinioObjectStore
+// does.
+type QuorumObjectStore struct {
+	stores []ObjectStoreInterface
+	// repairs tracks the read repairs running in the background.
+	repairs sync.WaitGroup
+}
+
+// quorumRead is the outcome of reading a file from one of the stores.
+type quorumRead struct {
+	store int
+	data  []byte
+	hash  [sha256.Size]byte
+	err   error
+	// etag is the ETag of the file before it was read, empty if it was missing. A copy
+	// newer than the one read has another ETag, so a repair conditional on it never
+	// overwrites it. etagKnown is false if the file could not be stat-ed.
+	etag      string
+	etagKnown bool
+}
+
+func NewQuorumObjectStore(stores ...ObjectStoreInterface) *QuorumObjectStore {
+	return &QuorumObjectStore{stores: stores}
+}
+
+// quorum is the number of stores that make up a majority.
+func (q *QuorumObjectStore) quorum() int {
+	return len(q.stores)/2 + 1
+}
+
+// forEachStore calls f with every store concurrently and returns the errors it returned,
+// indexed like the stores.
+func (q *QuorumObjectStore) forEachStore(f func(i int, store ObjectStoreInterface) error) []error {
+	errs := make([]error, len(q.stores))
+	var wg sync.WaitGroup
+	for i, store := range q.stores {
+		wg.Add(1)
+		go func(i int, store ObjectStoreInterface) {
+			defer wg.Done()
+			errs[i] = f(i, store)
+		}(i, store)
+	}
+	wg.Wait()
+	return errs
+}
+
+// checkQuorum returns an error unless a majority of the stores succeeded.
+func (q *QuorumObjectStore) checkQuorum(errs []error, operation string, filePath string) error {
+	acks := 0
+	var lastErr error
+	for i, err := range errs {
+		if err == nil {
+			acks++
+			continue
+		}
+		lastErr = err
+		glog.Warningf("Store %v failed to %v %v: %v", i, operation, filePath, err)
+	}
+	if acks >= q.quorum() {
+		return nil
+	}
+	if lastErr == nil {
+		return util.NewInternalServerError(errors.New("no backing stores"), "Failed to %v %v", operation, filePath)
+	}
+	return util.Wrapf(lastErr, "Failed to %v %v: %v of %v stores acknowledged, %v required",
+		operation, filePath, acks, len(q.stores), q.quorum())
+}
+
+func (q *QuorumObjectStore) AddFile(ctx context.Context, file []byte, filePath string) error {
+	errs := q.forEachStore(func(_ int, store ObjectStoreInterface) error {
+		return store.AddFile(ctx, file, filePath)
+	})
+	return q.checkQuorum(errs, "store file", filePath)
+}
+
+func (q *QuorumObjectStore) DeleteFile(ctx context.Context, filePath string) error {
+	errs := q.forEachStore(func(_ int, store ObjectStoreInterface) error {
+		return store.DeleteFile(ctx, filePath)
+	})
+	return q.checkQuorum(errs, "delete file", filePath)
+}
+
+func (q *QuorumObjectStore) MoveFile(ctx context.Context, srcPath string, dstPath string) error {
+	errs := q.forEachStore(func(_ int, store ObjectStoreInterface) error {
+		return store.MoveFile(ctx, srcPath, dstPath)
+	})
+	return q.checkQuorum(errs, "move file", srcPath)
+}
+
+// GetFile returns the copy of the file held by a majority of the stores. It returns as soon
+// as a majority agree, cancelling the reads of the other stores. Stores that answered with
+// another copy, or none, are then repaired in the background by copying the file as stored
+// in the store read first among the majority, unless they were written since they were
+// read. Stores whose read was cancelled are left as they are.
+func (q *QuorumObjectStore) GetFile(ctx context.Context, filePath string) ([]byte, error) {
+	readCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan quorumRead, len(q.stores))
+	for i, store := range q.stores {
+		go func(i int, store ObjectStoreInterface) {
+			read := quorumRead{store: i}
+			info, err := store.GetFileInfo(readCtx, filePath)
+			switch {
+			case err == nil:
+				read.etag, read.etagKnown = info.ETag, true
+			case errors.Is(err, ErrNotFound):
+				read.etagKnown = true
+			}
+			read.data, read.err = store.GetFile(readCtx, filePath)
+			if read.err == nil {
+				read.hash = sha256.Sum256(read.data)
+			}
+			results <- read
+		}(i, store)
+	}
+
+	votes := make(map[[sha256.Size]byte]int)
+	notFound := 0
+	var lastErr error
+	reads := make([]quorumRead, 0, len(q.stores))
+	for range q.stores {
+		read := <-results
+		reads = append(reads, read)
+		if read.err != nil {
+			lastErr = read.err
+			if errors.Is(read.err, ErrNotFound) {
+				notFound++
+				if notFound >= q.quorum() {
+					return nil, util.Wrapf(lastErr, "Failed to get file %v", filePath)
+				}
+			}
+			continue
+		}
+		votes[read.hash]++
+		if votes[read.hash] >= q.quorum() {
+			q.repair(ctx, filePath, read, reads)
+			return read.data, nil
+		}
+	}
+	return nil, util.NewInternalServerError(errors.New("no copy of the file is held by a majority of stores"),
+		"Failed to get file %v", filePath)
+}
+
+// repair copies the file as stored in the store of the majority read to the stores whose
+// read returned another copy or none, in the background. Each copy is conditional on the
+// ETag the stale store had when read, so a write landing after the read is kept.
+func (q *QuorumObjectStore) repair(ctx context.Context, filePath string, majority quorumRead, reads []quorumRead) {
+	var stale []quorumRead
+	for _, read := range reads {
+		if read.err != nil || read.hash != majority.hash {
+			stale = append(stale, read)
+		}
+	}
+	if len(stale) == 0 {
+		return
+	}
+	source, ok := q.stores[majority.store].(rawFileStore)
+	if !ok {
+		glog.Warningf("Not repairing file %v: store %v cannot copy files", filePath, majority.store)
+		return
+	}
+	// The repair outlives the read.
+	ctx = context.WithoutCancel(ctx)
+	q.repairs.Add(1)
+	go func() {
+		defer q.repairs.Done()
+		file, err := source.getRawFile(ctx, filePath)
+		if err != nil {
+			glog.Warningf("Failed to read file %v to repair from store %v: %v", filePath, majority.store, err)
+			return
+		}
+		for _, read := range stale {
+			target, ok := q.stores[read.store].(rawFileStore)
+			if !ok || !read.etagKnown {
+				glog.Warningf("Not repairing file %v in store %v: its state is unknown or it cannot copy files", filePath, read.store)
+				continue
+			}
+			glog.Warningf("Repairing file %v in store %v", filePath, read.store)
+			etag := read.etag
+			err := target.putRawFile(ctx, file, filePath, &etag)
+			if ClassifyError(err) == ErrConflict {
+				glog.Infof("Not repairing file %v in store %v: it was written since it was read", filePath, read.store)
+				continue
+			}
+			if err != nil {
+				glog.Warningf("Failed to repair file %v in store %v: %v", filePath, read.store, err)
+			}
+		}
+	}()
+}
+
+func (q *QuorumObjectStore) AddAsYamlFile(ctx context.Context, o interface{}, filePath string) error {
+	bytes, err := yaml.Marshal(o)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to marshal file %v: %v", filePath, err.Error())
+	}
+	err = q.AddFile(ctx, bytes, filePath)
+	if err != nil {
+		return util.Wrap(err, "Failed to add a yaml file")
+	}
+	return nil
+}
+
+func (q *QuorumObjectStore) GetFromYamlFile(ctx context.Context, o interface{}, filePath string) error {
+	bytes, err := readYamlFile(ctx, q, filePath)
+	return unmarshalYamlFile(ctx, bytes, err, o, filePath)
+}
+
+// GetPipelineKey returns the key of the first store. All stores are expected to share
+// the same key layout.
+func (q *QuorumObjectStore) GetPipelineKey(pipelineID string) string {
+	if len(q.stores) == 0 {
+		return pipelineID
+	}
+	return q.stores[0].GetPipelineKey(pipelineID)
+}
+
+// GetFileInfo returns the attributes of the file in the first store that has it.
+func (q *QuorumObjectStore) GetFileInfo(ctx context.Context, filePath string) (*FileInfo, error) {
+	var lastErr error
+	for _, store := range q.stores {
+		info, err := store.GetFileInfo(ctx, filePath)
+		if err == nil {
+			return info, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		return nil, util.NewInternalServerError(errors.New("no backing stores"), "Failed to stat file %v", filePath)
+	}
+	return nil, lastErr
+}
+
+// Close waits for the read repairs in progress, then closes every store and returns the
+// first error encountered.
+func (q *QuorumObjectStore) Close() error {
+	q.repairs.Wait()
+	var firstErr error
+	for _, store := range q.stores {
+		if err := store.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
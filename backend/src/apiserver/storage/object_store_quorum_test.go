@@ -0,0 +1,231 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func newQuorumTestStores() ([]*FakeMinioClient, []ObjectStoreInterface) {
+	var clients []*FakeMinioClient
+	var stores []ObjectStoreInterface
+	for i := 0; i < 3; i++ {
+		minioClient := NewFakeMinioClient()
+		clients = append(clients, minioClient)
+		stores = append(stores, &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"})
+	}
+	return clients, stores
+}
+
+// orderedReadStore delays its reads until wait is closed, and closes read once done reading.
+type orderedReadStore struct {
+	*MinioObjectStore
+	wait <-chan struct{}
+	read chan struct{}
+}
+
+func (s *orderedReadStore) GetFile(ctx context.Context, filePath string) ([]byte, error) {
+	if s.wait != nil {
+		select {
+		case <-s.wait:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	data, err := s.MinioObjectStore.GetFile(ctx, filePath)
+	if s.read != nil {
+		close(s.read)
+	}
+	return data, err
+}
+
+// readFirst makes the store at first answer reads before the others.
+func readFirst(stores []ObjectStoreInterface, first int) {
+	read := make(chan struct{})
+	for i := range stores {
+		if i == first {
+			stores[i] = &orderedReadStore{MinioObjectStore: stores[i].(*MinioObjectStore), read: read}
+		} else {
+			stores[i] = &orderedReadStore{MinioObjectStore: stores[i].(*MinioObjectStore), wait: read}
+		}
+	}
+}
+
+func TestQuorumObjectStore_WriteSucceedsWithMajority(t *testing.T) {
+	clients, stores := newQuorumTestStores()
+	stores[2] = &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipeline"}
+	store := NewQuorumObjectStore(stores...)
+
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipeline/1"))
+	assert.True(t, clients[0].ExistObject("pipeline/1"))
+	assert.True(t, clients[1].ExistObject("pipeline/1"))
+}
+
+func TestQuorumObjectStore_WriteFailsWithoutMajority(t *testing.T) {
+	_, stores := newQuorumTestStores()
+	stores[1] = &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipeline"}
+	stores[2] = &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipeline"}
+	store := NewQuorumObjectStore(stores...)
+
+	err := store.AddFile(context.TODO(), []byte("spec"), "pipeline/1")
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "1 of 3 stores acknowledged, 2 required")
+}
+
+func TestQuorumObjectStore_ReadRepairsLaggingStore(t *testing.T) {
+	clients, stores := newQuorumTestStores()
+	ctx := context.TODO()
+	require.Nil(t, stores[0].AddFile(ctx, []byte("v2"), "pipeline/1"))
+	require.Nil(t, stores[1].AddFile(ctx, []byte("v2"), "pipeline/1"))
+	require.Nil(t, stores[2].AddFile(ctx, []byte("v1"), "pipeline/1"))
+	readFirst(stores, 2)
+	store := NewQuorumObjectStore(stores...)
+
+	data, err := store.GetFile(ctx, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("v2"), data)
+	store.repairs.Wait()
+	assert.Equal(t, []byte("v2"), clients[2].minioClient["pipeline/1"])
+}
+
+func TestQuorumObjectStore_ReadRepairsMissingCopy(t *testing.T) {
+	clients, stores := newQuorumTestStores()
+	ctx := context.TODO()
+	require.Nil(t, stores[0].AddFile(ctx, []byte("v1"), "pipeline/1"))
+	require.Nil(t, stores[2].AddFile(ctx, []byte("v1"), "pipeline/1"))
+	readFirst(stores, 1)
+	store := NewQuorumObjectStore(stores...)
+
+	data, err := store.GetFile(ctx, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("v1"), data)
+	require.Nil(t, store.Close())
+	assert.Equal(t, []byte("v1"), clients[1].minioClient["pipeline/1"])
+}
+
+// writeAfterReadStore stores newer content right after each read, as a write racing with
+// the read would, then closes read.
+type writeAfterReadStore struct {
+	*MinioObjectStore
+	newer []byte
+	read  chan struct{}
+}
+
+func (s *writeAfterReadStore) GetFile(ctx context.Context, filePath string) ([]byte, error) {
+	data, err := s.MinioObjectStore.GetFile(ctx, filePath)
+	if addErr := s.MinioObjectStore.AddFile(ctx, s.newer, filePath); addErr != nil {
+		return nil, addErr
+	}
+	close(s.read)
+	return data, err
+}
+
+func TestQuorumObjectStore_RepairKeepsNewerWrite(t *testing.T) {
+	clients, stores := newQuorumTestStores()
+	ctx := context.TODO()
+	require.Nil(t, stores[0].AddFile(ctx, []byte("v2"), "pipeline/1"))
+	require.Nil(t, stores[1].AddFile(ctx, []byte("v2"), "pipeline/1"))
+	require.Nil(t, stores[2].AddFile(ctx, []byte("v1"), "pipeline/1"))
+	read := make(chan struct{})
+	stores[0] = &orderedReadStore{MinioObjectStore: stores[0].(*MinioObjectStore), wait: read}
+	stores[1] = &orderedReadStore{MinioObjectStore: stores[1].(*MinioObjectStore), wait: read}
+	stores[2] = &writeAfterReadStore{MinioObjectStore: stores[2].(*MinioObjectStore), newer: []byte("v3"), read: read}
+	store := NewQuorumObjectStore(stores...)
+
+	data, err := store.GetFile(ctx, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("v2"), data)
+	require.Nil(t, store.Close())
+	assert.Equal(t, []byte("v3"), clients[2].minioClient["pipeline/1"])
+}
+
+func TestQuorumObjectStore_RepairKeepsEncodingAndMetadata(t *testing.T) {
+	clients, stores := newQuorumTestStores()
+	ctx := context.TODO()
+	for _, i := range []int{0, 2} {
+		require.Nil(t, stores[i].(*MinioObjectStore).AddFileFromReader(ctx, strings.NewReader("v1"), "pipeline/1", true))
+	}
+	readFirst(stores, 1)
+	store := NewQuorumObjectStore(stores...)
+
+	_, err := store.GetFile(ctx, "pipeline/1")
+	require.Nil(t, err)
+	require.Nil(t, store.Close())
+	repaired, err := clients[1].StatObject(ctx, "", "pipeline/1", minio.StatObjectOptions{})
+	require.Nil(t, err)
+	original, err := clients[0].StatObject(ctx, "", "pipeline/1", minio.StatObjectOptions{})
+	require.Nil(t, err)
+	assert.Equal(t, original.ETag, repaired.ETag)
+	assert.Equal(t, contentEncodingGzip, repaired.Metadata.Get(contentEncodingHeader))
+	assert.Equal(t, original.UserMetadata, repaired.UserMetadata)
+}
+
+func TestQuorumObjectStore_ReadReturnsOnceMajorityAgrees(t *testing.T) {
+	clients, stores := newQuorumTestStores()
+	ctx := context.TODO()
+	require.Nil(t, stores[0].AddFile(ctx, []byte("v1"), "pipeline/1"))
+	require.Nil(t, stores[1].AddFile(ctx, []byte("v1"), "pipeline/1"))
+	require.Nil(t, stores[2].AddFile(ctx, []byte("v0"), "pipeline/1"))
+	// The read of the last store only completes once cancelled.
+	stores[2] = &orderedReadStore{MinioObjectStore: stores[2].(*MinioObjectStore), wait: make(chan struct{})}
+	store := NewQuorumObjectStore(stores...)
+
+	data, err := store.GetFile(ctx, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("v1"), data)
+	require.Nil(t, store.Close())
+	// The store whose read was cancelled is not repaired.
+	assert.Equal(t, []byte("v0"), clients[2].minioClient["pipeline/1"])
+}
+
+func TestQuorumObjectStore_ReadWithoutMajority(t *testing.T) {
+	_, stores := newQuorumTestStores()
+	ctx := context.TODO()
+	require.Nil(t, stores[0].AddFile(ctx, []byte("v1"), "pipeline/1"))
+	require.Nil(t, stores[1].AddFile(ctx, []byte("v2"), "pipeline/1"))
+	store := NewQuorumObjectStore(stores...)
+
+	_, err := store.GetFile(ctx, "pipeline/1")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestQuorumObjectStore_ReadNotFound(t *testing.T) {
+	_, stores := newQuorumTestStores()
+	store := NewQuorumObjectStore(stores...)
+
+	_, err := store.GetFile(context.TODO(), "pipeline/1")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestQuorumObjectStore_Delete(t *testing.T) {
+	clients, stores := newQuorumTestStores()
+	store := NewQuorumObjectStore(stores...)
+	require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), "pipeline/1"))
+
+	require.Nil(t, store.DeleteFile(context.TODO(), "pipeline/1"))
+	for _, minioClient := range clients {
+		assert.False(t, minioClient.ExistObject("pipeline/1"))
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+// ErrTenantQuotaExceeded is the cause of errors returned for writes of tenants over their quota.
+var ErrTenantQuotaExceeded = errors.New("tenant storage quota exceeded")
+
+// QuotaChecker enforces per-tenant storage quotas.
+type QuotaChecker interface {
+	// CheckQuota returns an error caused by ErrTenantQuotaExceeded if writing size more
+	// bytes would take tenant over its quota.
+	CheckQuota(ctx context.Context, tenant string, size int64) error
+	// RecordUsage adds delta bytes, negative for freed bytes, to the usage of tenant.
+	RecordUsage(ctx context.Context, tenant string, delta int64)
+}
+
+type tenantContextKey struct{}
+
+// WithTenant charges the writes and deletes using ctx to the quota of tenant.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+func tenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantContextKey{}).(string)
+	return tenant
+}
+
+// SetQuotaChecker enforces the quotas of checker on the writes of tenants, set with
+// WithTenant. The size of a write is the number of bytes it adds to the file it replaces.
+// Quotas are soft: concurrent writes of a tenant are checked independently.
+func (m *MinioObjectStore) SetQuotaChecker(checker QuotaChecker) {
+	m.quotaChecker = checker
+}
+
+// tenantQuota returns the tenant whose quota applies to the operations using ctx, if any.
+func (m *MinioObjectStore) tenantQuota(ctx context.Context) (string, bool) {
+	if m.quotaChecker == nil {
+		return "", false
+	}
+	tenant := tenantFromContext(ctx)
+	return tenant, tenant != ""
+}
+
+// storedSize returns the size of the object at key, or zero if there is none.
+func (m *MinioObjectStore) storedSize(ctx context.Context, key string) int64 {
+	info, err := m.minioClient.StatObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+	if err != nil {
+		return 0
+	}
+	return info.Size
+}
+
+// checkQuota returns an error if writing size more bytes to filePath takes tenant over its quota.
+func (m *MinioObjectStore) checkQuota(ctx context.Context, tenant string, filePath string, size int64) error {
+	err := m.quotaChecker.CheckQuota(ctx, tenant, size)
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, ErrTenantQuotaExceeded) {
+		return util.NewResourceExhaustedError(err, "Failed to store file %v: tenant %v is over its storage quota", filePath, tenant)
+	}
+	return util.NewInternalServerError(err, "Failed to check the storage quota of tenant %v", tenant)
+}
+
+// InMemoryQuotaChecker is a QuotaChecker allowing every tenant the same quota, tracking
+// usage in memory.
+type InMemoryQuotaChecker struct {
+	limit int64
+	mutex sync.Mutex
+	usage map[string]int64
+}
+
+// NewInMemoryQuotaChecker returns a checker allowing every tenant to store limit bytes.
+func NewInMemoryQuotaChecker(limit int64) *InMemoryQuotaChecker {
+	return &InMemoryQuotaChecker{limit: limit, usage: make(map[string]int64)}
+}
+
+func (c *InMemoryQuotaChecker) CheckQuota(ctx context.Context, tenant string, size int64) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.usage[tenant]+size > c.limit {
+		return errors.Wrapf(ErrTenantQuotaExceeded, "tenant %v uses %v of %v bytes", tenant, c.usage[tenant], c.limit)
+	}
+	return nil
+}
+
+func (c *InMemoryQuotaChecker) RecordUsage(ctx context.Context, tenant string, delta int64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.usage[tenant] += delta
+	if c.usage[tenant] < 0 {
+		c.usage[tenant] = 0
+	}
+}
+
+// Usage returns the bytes stored by tenant.
+func (c *InMemoryQuotaChecker) Usage(tenant string) int64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.usage[tenant]
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func newQuotaTestStore(limit int64) (*MinioObjectStore, *InMemoryQuotaChecker) {
+	checker := NewInMemoryQuotaChecker(limit)
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	manager.SetQuotaChecker(checker)
+	return manager, checker
+}
+
+func TestAddFile_Quota(t *testing.T) {
+	manager, checker := newQuotaTestStore(10)
+	ctx := WithTenant(context.TODO(), "team-a")
+
+	require.Nil(t, manager.AddFile(ctx, []byte("123456"), "pipeline/1"))
+	assert.Equal(t, int64(6), checker.Usage("team-a"))
+
+	err := manager.AddFile(ctx, []byte("12345"), "pipeline/2")
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrTenantQuotaExceeded))
+	assert.Equal(t, codes.ResourceExhausted, err.(*util.UserError).ExternalStatusCode())
+	_, err = manager.GetFileInfo(ctx, "pipeline/2")
+	assert.NotNil(t, err)
+	assert.Equal(t, int64(6), checker.Usage("team-a"))
+
+	// Other tenants have their own quota.
+	require.Nil(t, manager.AddFile(WithTenant(context.TODO(), "team-b"), []byte("12345"), "pipeline/2"))
+	assert.Equal(t, int64(6), checker.Usage("team-a"))
+	assert.Equal(t, int64(5), checker.Usage("team-b"))
+}
+
+func TestAddFile_QuotaOverwrite(t *testing.T) {
+	manager, checker := newQuotaTestStore(10)
+	ctx := WithTenant(context.TODO(), "team-a")
+	require.Nil(t, manager.AddFile(ctx, []byte("12345678"), "pipeline/1"))
+
+	// Replacing a file only charges the bytes it adds.
+	require.Nil(t, manager.AddFile(ctx, []byte("1234567890"), "pipeline/1"))
+	assert.Equal(t, int64(10), checker.Usage("team-a"))
+	require.Nil(t, manager.AddFile(ctx, []byte("123"), "pipeline/1"))
+	assert.Equal(t, int64(3), checker.Usage("team-a"))
+}
+
+func TestDeleteFile_FreesQuota(t *testing.T) {
+	manager, checker := newQuotaTestStore(10)
+	ctx := WithTenant(context.TODO(), "team-a")
+	require.Nil(t, manager.AddFile(ctx, []byte("12345678"), "pipeline/1"))
+	require.NotNil(t, manager.AddFile(ctx, []byte("12345678"), "pipeline/2"))
+
+	require.Nil(t, manager.DeleteFile(ctx, "pipeline/1"))
+	assert.Equal(t, int64(0), checker.Usage("team-a"))
+	require.Nil(t, manager.AddFile(ctx, []byte("12345678"), "pipeline/2"))
+	assert.Equal(t, int64(8), checker.Usage("team-a"))
+}
+
+func TestAddFile_QuotaWithoutTenant(t *testing.T) {
+	manager, checker := newQuotaTestStore(1)
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("12345678"), "pipeline/1"))
+	assert.Equal(t, int64(0), checker.Usage(""))
+}
+
+type failingQuotaChecker struct {
+	QuotaChecker
+}
+
+func (c *failingQuotaChecker) CheckQuota(ctx context.Context, tenant string, size int64) error {
+	return errors.New("quota service unreachable")
+}
+
+func TestAddFile_QuotaCheckError(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	manager.SetQuotaChecker(&failingQuotaChecker{})
+
+	err := manager.AddFile(WithTenant(context.TODO(), "team-a"), []byte("spec"), "pipeline/1")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+	assert.False(t, errors.Is(err, ErrTenantQuotaExceeded))
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+
+	minio "github.com/minio/minio-go/v7"
+)
+
+// rawFileStore is implemented by the stores able to copy files as stored, e.g. to mirror
+// or repair them in another store. Unlike GetFile and AddFile, copies keep the content
+// encoding and the user metadata of the original, so their content hashes match.
+type rawFileStore interface {
+	getRawFile(ctx context.Context, filePath string) (*rawFile, error)
+	putRawFile(ctx context.Context, file *rawFile, filePath string, expectedETag *string) error
+}
+
+// rawFile is a file as stored: its content, neither decoded nor decompressed, along with
+// the attributes of its object.
+type rawFile struct {
+	content []byte
+	info    minio.ObjectInfo
+}
+
+// getRawFile returns the file at filePath as stored. The attributes are those of the
+// object read, so they always match the content.
+func (m *MinioObjectStore) getRawFile(ctx context.Context, filePath string) (*rawFile, error) {
+	if err := m.checkOpen("get file", filePath); err != nil {
+		return nil, err
+	}
+	if err := m.checkEnvironment(filePath, false); err != nil {
+		return nil, err
+	}
+	release, err := m.reserveReadInFlightBytes(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	key := m.resolveKey(ctx, filePath)
+	file := &rawFile{}
+	err = m.retry(ctx, func(ctx context.Context) error {
+		reader, err := m.minioClient.GetObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+		if err != nil {
+			return err
+		}
+		defer closeReader(reader)
+		if object, ok := reader.(objectStater); ok {
+			file.info, err = object.Stat()
+		} else {
+			file.info, err = m.minioClient.StatObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+		}
+		if err != nil {
+			return err
+		}
+		file.content, err = readObject(reader)
+		return err
+	})
+	if err != nil {
+		return nil, newObjectStoreError(err, "Failed to get file %v", filePath)
+	}
+	m.auditRead(ctx, AuditEvent{Operation: AuditOperationRead, Path: filePath, Size: int64(len(file.content))})
+	return file, nil
+}
+
+// putRawFile stores file at filePath as is, with its content type, content encoding and
+// user metadata, holding the lock of the file meanwhile. If expectedETag is set, the put
+// only succeeds if the stored object is still at that ETag, or still missing if it is
+// empty, and otherwise fails with a conflict.
+func (m *MinioObjectStore) putRawFile(ctx context.Context, file *rawFile, filePath string, expectedETag *string) error {
+	return m.WithKeyLock(ctx, filePath, func(ctx context.Context) error {
+		if err := m.checkOpen("store file", filePath); err != nil {
+			return err
+		}
+		if err := m.checkMaintenance("store file", filePath); err != nil {
+			return err
+		}
+		if err := m.checkEnvironment(filePath, true); err != nil {
+			return err
+		}
+		key := m.resolveKey(ctx, filePath)
+		if err := m.checkKeyLength("store file", filePath, key); err != nil {
+			return err
+		}
+		if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationWrite, Path: filePath, Size: int64(len(file.content))}); err != nil {
+			return err
+		}
+		opts := m.putObjectOptions(ctx)
+		opts.ContentType = file.info.ContentType
+		opts.ContentEncoding = file.info.Metadata.Get(contentEncodingHeader)
+		for k, v := range file.info.UserMetadata {
+			setUserMetadata(&opts, k, v)
+		}
+		if expectedETag != nil {
+			if *expectedETag == "" {
+				opts.SetMatchETagExcept("*")
+			} else {
+				opts.SetMatchETag(*expectedETag)
+			}
+		}
+		release, err := m.reserveInFlightBytes(ctx, "store file", filePath, int64(len(file.content)))
+		if err != nil {
+			return err
+		}
+		defer release()
+		err = m.retry(ctx, func(ctx context.Context) error {
+			_, err := m.minioClient.PutObject(ctx, m.bucketName, key, bytes.NewReader(file.content),
+				int64(len(file.content)), opts)
+			return err
+		})
+		if err != nil {
+			m.recordWarningEvent(ctx, eventReasonWriteFailed, "Failed to store file %v: %v", filePath, err)
+			return newObjectStoreError(err, "Failed to store file %v", filePath)
+		}
+		return nil
+	})
+}
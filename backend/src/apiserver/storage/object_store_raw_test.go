@@ -0,0 +1,67 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawFile_CopyKeepsAttributes(t *testing.T) {
+	src := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	dstClient := NewFakeMinioClient()
+	dst := &MinioObjectStore{minioClient: dstClient, baseFolder: "pipeline"}
+	ctx := context.TODO()
+	require.Nil(t, src.AddFileFromReader(ctx, strings.NewReader("spec"), "pipeline/1", true))
+
+	file, err := src.getRawFile(ctx, "pipeline/1")
+	require.Nil(t, err)
+	require.Nil(t, dst.putRawFile(ctx, file, "pipeline/1", nil))
+
+	srcInfo, err := src.GetFileInfo(ctx, "pipeline/1")
+	require.Nil(t, err)
+	dstInfo, err := dst.GetFileInfo(ctx, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, srcInfo.ETag, dstInfo.ETag)
+	assert.Equal(t, contentEncodingGzip, dstInfo.ContentEncoding)
+	assert.Equal(t, int64(4), dstInfo.LogicalSize)
+}
+
+func TestPutRawFile_Conditional(t *testing.T) {
+	store := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	ctx := context.TODO()
+	require.Nil(t, store.AddFile(ctx, []byte("v1"), "pipeline/1"))
+	file, err := store.getRawFile(ctx, "pipeline/1")
+	require.Nil(t, err)
+	require.Nil(t, store.AddFile(ctx, []byte("v2"), "pipeline/1"))
+
+	absent, stale := "", file.info.ETag
+	err = store.putRawFile(ctx, file, "pipeline/1", &absent)
+	assert.Equal(t, ErrConflict, ClassifyError(err))
+	err = store.putRawFile(ctx, file, "pipeline/1", &stale)
+	assert.Equal(t, ErrConflict, ClassifyError(err))
+	data, err := store.GetFile(ctx, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("v2"), data)
+
+	require.Nil(t, store.putRawFile(ctx, file, "pipeline/2", &absent))
+	data, err = store.GetFile(ctx, "pipeline/2")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("v1"), data)
+}
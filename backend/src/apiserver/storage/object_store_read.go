@@ -0,0 +1,85 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// maxPooledBufferSize keeps the buffers of unusually large objects out of the pool.
+const maxPooledBufferSize = 64 << 20
+
+// readBufferPool holds the buffers objects of unknown size are read into.
+var readBufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// readObject reads the whole content of an object. When the reader reports the size of the
+// object, as those minio.Client.GetObject returns do, the content is read into a buffer of
+// that size, allocated once. Otherwise it is read into a pooled buffer and copied out, so
+// the buffer growth is paid once per pooled buffer rather than once per read.
+func readObject(reader io.Reader) ([]byte, error) {
+	if object, ok := reader.(objectStater); ok {
+		info, err := object.Stat()
+		if err != nil {
+			return nil, err
+		}
+		if info.Size >= 0 {
+			return readSizedObject(reader, info.Size)
+		}
+	}
+	buf := readBufferPool.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledBufferSize {
+			buf.Reset()
+			readBufferPool.Put(buf)
+		}
+	}()
+	if _, err := buf.ReadFrom(reader); err != nil {
+		return nil, err
+	}
+	return bytes.Clone(buf.Bytes()), nil
+}
+
+// readSizedObject reads the content of an object of the given size.
+func readSizedObject(reader io.Reader, size int64) ([]byte, error) {
+	// The spare byte lets the end of the content be checked without another allocation.
+	data := make([]byte, size, size+1)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, errors.Wrapf(err, "object shorter than its size of %d bytes", size)
+		}
+		return nil, err
+	}
+	n, err := reader.Read(data[size : size+1])
+	if n == 0 && err == io.EOF {
+		return data, nil
+	}
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	// Objects growing while read are not expected, but must not be truncated.
+	rest, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return append(data[:size+int64(n)], rest...), nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var largeObjectContent = bytes.Repeat([]byte("pipelineSpec:\n  name: large\n"), 64<<10)
+
+// unsizedReader hides the size of the content, and the other interfaces of its reader.
+type unsizedReader struct {
+	io.Reader
+}
+
+// sizedReader reports the given size as the size of the object.
+type sizedReader struct {
+	io.Reader
+	size int64
+}
+
+func (r *sizedReader) Stat() (minio.ObjectInfo, error) {
+	return minio.ObjectInfo{Size: r.size}, nil
+}
+
+func TestReadObject_Sized(t *testing.T) {
+	data, err := readObject(&sizedReader{Reader: bytes.NewReader(largeObjectContent), size: int64(len(largeObjectContent))})
+	require.Nil(t, err)
+	assert.Equal(t, largeObjectContent, data)
+
+	data, err = readObject(&sizedReader{Reader: bytes.NewReader(nil), size: 0})
+	require.Nil(t, err)
+	assert.Empty(t, data)
+}
+
+func TestReadObject_Unsized(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		// Reads reusing a pooled buffer must not see the content of earlier reads.
+		content := largeObjectContent[:len(largeObjectContent)>>i]
+		data, err := readObject(&unsizedReader{Reader: bytes.NewReader(content)})
+		require.Nil(t, err)
+		assert.Equal(t, content, data)
+	}
+}
+
+func TestReadObject_SizeMismatch(t *testing.T) {
+	_, err := readObject(&sizedReader{Reader: bytes.NewReader([]byte("spec")), size: 10})
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "object shorter than its size of 10 bytes")
+
+	data, err := readObject(&sizedReader{Reader: bytes.NewReader([]byte("spec")), size: 2})
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+}
+
+func TestGetFile_Large(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	require.Nil(t, manager.AddFile(context.TODO(), largeObjectContent, "pipeline/1"))
+
+	data, err := manager.GetFile(context.TODO(), "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, largeObjectContent, data)
+}
+
+// BenchmarkReadObject compares the allocations of reading an object with and without its
+// size, against those of io.ReadAll.
+func BenchmarkReadObject(b *testing.B) {
+	size := int64(len(largeObjectContent))
+	b.Run("ReadAll", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			io.ReadAll(&unsizedReader{Reader: bytes.NewReader(largeObjectContent)})
+		}
+	})
+	b.Run("Sized", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			readObject(&sizedReader{Reader: bytes.NewReader(largeObjectContent), size: size})
+		}
+	})
+	b.Run("Unsized", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			readObject(&unsizedReader{Reader: bytes.NewReader(largeObjectContent)})
+		}
+	})
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+)
+
+// recycleBinFolder holds soft-deleted objects under the prefix of the store, followed by
+// their original key.
+const recycleBinFolder = ".recyclebin"
+
+// deletedAtMetadata is the user metadata of the objects in the recycle bin recording when
+// they were deleted, in RFC 3339.
+const deletedAtMetadata = "Kfp-Deleted-At"
+
+// SetSoftDelete enables soft-deletes. When enabled, DeleteFile moves files to the recycle
+// bin, where they can be restored with RestoreFile until PurgeRecycleBin removes them.
+func (m *MinioObjectStore) SetSoftDelete(enabled bool) {
+	m.softDelete = enabled
+}
+
+// RestoreFile moves a soft-deleted file back from the recycle bin to its original key,
+// holding the lock of the file meanwhile. It fails with AlreadyExists if a file was stored
+// at the key since, rather than overwriting it.
+func (m *MinioObjectStore) RestoreFile(ctx context.Context, filePath string) error {
+	return m.WithKeyLock(ctx, filePath, func(ctx context.Context) error {
+		return m.restoreFile(ctx, filePath)
+	})
+}
+
+func (m *MinioObjectStore) restoreFile(ctx context.Context, filePath string) error {
+	if err := m.checkOpen("restore file", filePath); err != nil {
+		return err
+	}
+	if err := m.checkMaintenance("restore file", filePath); err != nil {
+		return err
+	}
+	if err := m.checkEnvironment(filePath, true); err != nil {
+		return err
+	}
+	key, binKey := m.resolveKey(ctx, filePath), m.recycleBinKey(ctx, filePath)
+	_, err := m.minioClient.StatObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+	if err == nil {
+		return util.NewAlreadyExistError("Failed to restore file %v: a file was stored at its key since it was deleted", filePath)
+	}
+	if ClassifyError(err) != ErrNotFound {
+		return newObjectStoreError(err, "Failed to restore file %v", filePath)
+	}
+	binInfo, err := m.minioClient.StatObject(ctx, m.bucketName, binKey, m.getObjectOptions(ctx))
+	if err != nil {
+		return newObjectStoreError(err, "Failed to restore file %v", filePath)
+	}
+	if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationRestore, Path: filePath, Size: binInfo.Size}); err != nil {
+		return err
+	}
+	tenant, quota := m.tenantQuota(ctx)
+	if quota {
+		if err := m.checkQuota(ctx, tenant, filePath, binInfo.Size); err != nil {
+			return err
+		}
+	}
+	err = m.moveObjectEditingMetadata(ctx, binKey, key, func(userMetadata map[string]string) {
+		deleteUserMetadata(userMetadata, deletedAtMetadata)
+	})
+	if err != nil {
+		return newObjectStoreError(err, "Failed to restore file %v", filePath)
+	}
+	if quota {
+		m.quotaChecker.RecordUsage(ctx, tenant, binInfo.Size)
+	}
+	return nil
+}
+
+// softDeleteFile moves the file at filePath to the recycle bin, recording the time of its
+// deletion.
+func (m *MinioObjectStore) softDeleteFile(ctx context.Context, filePath string) error {
+	deletedAt := m.now().UTC().Format(time.RFC3339)
+	return m.moveObjectEditingMetadata(ctx, m.resolveKey(ctx, filePath), m.recycleBinKey(ctx, filePath),
+		func(userMetadata map[string]string) {
+			deleteUserMetadata(userMetadata, deletedAtMetadata)
+			userMetadata[deletedAtMetadata] = deletedAt
+		})
+}
+
+// PurgeRecycleBin permanently deletes the files that were moved to the recycle bin more
+// than olderThan ago, and returns the number of files deleted.
+func (m *MinioObjectStore) PurgeRecycleBin(ctx context.Context, olderThan time.Duration) (int, error) {
+	root := m.recycleBinRoot()
+	if err := m.checkOpen("purge", root); err != nil {
+		return 0, err
+	}
+	if err := m.checkMaintenance("purge", root); err != nil {
+		return 0, err
+	}
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	cutoff := m.now().Add(-olderThan)
+	purged := 0
+	opts := minio.ListObjectsOptions{Prefix: m.transformPrefix(root + "/"), Recursive: true}
+	for object := range m.minioClient.ListObjects(listCtx, m.bucketName, opts) {
+		if object.Err != nil {
+			return purged, newObjectStoreError(object.Err, "Failed to list files under %v", root)
+		}
+		key, ok := m.logicalKey(object.Key)
+		if !ok {
+			continue
+		}
+		// Listings do not carry the user metadata, so the time of deletion is stat-ed.
+		info, err := m.minioClient.StatObject(ctx, m.bucketName, object.Key, m.getObjectOptions(ctx))
+		if err != nil {
+			if ClassifyError(err) == ErrNotFound {
+				continue
+			}
+			return purged, newObjectStoreError(err, "Failed to stat file %v", object.Key)
+		}
+		if !deletedAt(info).Before(cutoff) {
+			continue
+		}
+		if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationDelete, Path: key, Size: info.Size}); err != nil {
+			return purged, err
+		}
+		if err := m.minioClient.DeleteObject(ctx, m.bucketName, object.Key); err != nil {
+			return purged, newObjectStoreError(err, "Failed to purge file %v", object.Key)
+		}
+		glog.Infof("Purged file %v from the recycle bin", strings.TrimPrefix(key, root+"/"))
+		purged++
+	}
+	return purged, nil
+}
+
+// deletedAt returns when the object in the recycle bin was deleted. Objects moved there
+// before the time was recorded fall back to the time of the move, their modification time.
+func deletedAt(info minio.ObjectInfo) time.Time {
+	deletedAt, err := time.Parse(time.RFC3339, userMetadataValue(info, deletedAtMetadata))
+	if err != nil {
+		return info.LastModified
+	}
+	return deletedAt
+}
+
+// recycleBinRoot returns the folder of the recycle bin of the store: under the environment
+// prefix if set, so environments sharing a bucket have separate bins, and otherwise under
+// the base folder.
+func (m *MinioObjectStore) recycleBinRoot() string {
+	if m.environmentPrefix != "" {
+		return path.Join(m.environmentPrefix, recycleBinFolder)
+	}
+	return path.Join(m.baseFolder, recycleBinFolder)
+}
+
+// recycleBinKey returns the key the file at filePath is moved to when soft-deleted. It is
+// transformed like the other keys, so the bin stays within the layout of the transform.
+func (m *MinioObjectStore) recycleBinKey(ctx context.Context, filePath string) string {
+	return m.transformKey(path.Join(m.recycleBinRoot(), m.untransformedKey(ctx, filePath)))
+}
+
+// isRecycleBinKey returns whether the logical key is the key of a soft-deleted object.
+func (m *MinioObjectStore) isRecycleBinKey(key string) bool {
+	return strings.HasPrefix(key, m.recycleBinRoot()+"/")
+}
+
+// deleteUserMetadata deletes the user metadata entry named key, regardless of its casing.
+func deleteUserMetadata(userMetadata map[string]string, key string) {
+	for k := range userMetadata {
+		if http.CanonicalHeaderKey(k) == http.CanonicalHeaderKey(key) {
+			delete(userMetadata, k)
+		}
+	}
+}
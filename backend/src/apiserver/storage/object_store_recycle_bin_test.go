@@ -0,0 +1,184 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func newSoftDeleteStore() (*MinioObjectStore, *FakeMinioClient) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	manager.SetSoftDelete(true)
+	return manager, minioClient
+}
+
+func TestSoftDelete_MovesToRecycleBin(t *testing.T) {
+	manager, minioClient := newSoftDeleteStore()
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), manager.GetPipelineKey("1")))
+
+	require.Nil(t, manager.DeleteFile(context.TODO(), manager.GetPipelineKey("1")))
+	assert.False(t, minioClient.ExistObject("pipeline/1"))
+	assert.Equal(t, []byte("spec"), minioClient.minioClient["pipeline/.recyclebin/pipeline/1"])
+
+	_, err := manager.GetFile(context.TODO(), manager.GetPipelineKey("1"))
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestSoftDelete_NotFound(t *testing.T) {
+	manager, minioClient := newSoftDeleteStore()
+
+	require.Nil(t, manager.DeleteFile(context.TODO(), manager.GetPipelineKey("1")))
+	assert.Equal(t, 0, minioClient.GetObjectCount())
+}
+
+func TestRestoreFile(t *testing.T) {
+	manager, minioClient := newSoftDeleteStore()
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), manager.GetPipelineKey("1")))
+	require.Nil(t, manager.DeleteFile(context.TODO(), manager.GetPipelineKey("1")))
+
+	require.Nil(t, manager.RestoreFile(context.TODO(), manager.GetPipelineKey("1")))
+	data, err := manager.GetFile(context.TODO(), manager.GetPipelineKey("1"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+	assert.False(t, minioClient.ExistObject("pipeline/.recyclebin/pipeline/1"))
+}
+
+func TestRestoreFile_KeyReused(t *testing.T) {
+	manager, minioClient := newSoftDeleteStore()
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("old"), manager.GetPipelineKey("1")))
+	require.Nil(t, manager.DeleteFile(context.TODO(), manager.GetPipelineKey("1")))
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("new"), manager.GetPipelineKey("1")))
+
+	err := manager.RestoreFile(context.TODO(), manager.GetPipelineKey("1"))
+	require.NotNil(t, err)
+	assert.Equal(t, codes.AlreadyExists, err.(*util.UserError).ExternalStatusCode())
+	assert.Equal(t, []byte("new"), minioClient.minioClient["pipeline/1"])
+	assert.True(t, minioClient.ExistObject("pipeline/.recyclebin/pipeline/1"))
+}
+
+func TestRestoreFile_Quota(t *testing.T) {
+	manager, checker := newQuotaTestStore(10)
+	manager.SetSoftDelete(true)
+	ctx := WithTenant(context.TODO(), "team-a")
+	require.Nil(t, manager.AddFile(ctx, []byte("spec"), manager.GetPipelineKey("1")))
+	require.Nil(t, manager.DeleteFile(ctx, manager.GetPipelineKey("1")))
+	assert.Equal(t, int64(0), checker.Usage("team-a"))
+
+	require.Nil(t, manager.RestoreFile(ctx, manager.GetPipelineKey("1")))
+	assert.Equal(t, int64(4), checker.Usage("team-a"))
+
+	require.Nil(t, manager.DeleteFile(ctx, manager.GetPipelineKey("1")))
+	require.Nil(t, manager.AddFile(ctx, []byte("1234567890"), manager.GetPipelineKey("2")))
+	err := manager.RestoreFile(ctx, manager.GetPipelineKey("1"))
+	require.NotNil(t, err)
+	assert.Equal(t, codes.ResourceExhausted, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestRestoreFile_NotInRecycleBin(t *testing.T) {
+	manager, _ := newSoftDeleteStore()
+
+	err := manager.RestoreFile(context.TODO(), manager.GetPipelineKey("1"))
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestPurgeRecycleBin(t *testing.T) {
+	clock := NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	minioClient := NewFakeMinioClient()
+	minioClient.SetClock(clock)
+	manager := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipeline", WithSoftDelete(true), WithClock(clock))
+	ctx := context.TODO()
+	require.Nil(t, manager.AddFile(ctx, []byte("spec"), manager.GetPipelineKey("1")))
+	require.Nil(t, manager.DeleteFile(ctx, manager.GetPipelineKey("1")))
+	clock.Advance(48 * time.Hour)
+	require.Nil(t, manager.AddFile(ctx, []byte("spec"), manager.GetPipelineKey("2")))
+	require.Nil(t, manager.DeleteFile(ctx, manager.GetPipelineKey("2")))
+	require.Nil(t, manager.AddFile(ctx, []byte("spec"), manager.GetPipelineKey("3")))
+	// Rewriting the copy in the bin, e.g. to change its storage class, does not delay its purge.
+	require.Nil(t, manager.SetStorageClass(ctx, "pipeline/.recyclebin/pipeline/1", StorageClassGlacier))
+
+	purged, err := manager.PurgeRecycleBin(ctx, 24*time.Hour)
+	require.Nil(t, err)
+	assert.Equal(t, 1, purged)
+	assert.False(t, minioClient.ExistObject("pipeline/.recyclebin/pipeline/1"))
+	assert.True(t, minioClient.ExistObject("pipeline/.recyclebin/pipeline/2"))
+	assert.True(t, minioClient.ExistObject("pipeline/3"))
+}
+
+func TestSoftDelete_RecordsDeletionTime(t *testing.T) {
+	clock := NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	minioClient := NewFakeMinioClient()
+	manager := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipeline", WithSoftDelete(true), WithClock(clock))
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipeline/1"))
+
+	require.Nil(t, manager.DeleteFile(context.TODO(), "pipeline/1"))
+	info := minioClient.objectInfo["pipeline/.recyclebin/pipeline/1"]
+	assert.Equal(t, "2025-01-01T00:00:00Z", userMetadataValue(info, deletedAtMetadata))
+	assert.NotEmpty(t, userMetadataValue(info, contentSha256Metadata))
+
+	require.Nil(t, manager.RestoreFile(context.TODO(), "pipeline/1"))
+	assert.Empty(t, userMetadataValue(minioClient.objectInfo["pipeline/1"], deletedAtMetadata))
+}
+
+func TestSoftDelete_RecycleBinUnderEnvironmentPrefix(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	staging := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "staging/pipeline", WithSoftDelete(true))
+	staging.SetEnvironmentPrefix("staging", false)
+	production := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "production/pipeline", WithSoftDelete(true))
+	production.SetEnvironmentPrefix("production", false)
+	for _, store := range []*MinioObjectStore{staging, production} {
+		filePath := store.GetPipelineKey("1")
+		require.Nil(t, store.AddFile(context.TODO(), []byte("spec"), filePath))
+		require.Nil(t, store.DeleteFile(context.TODO(), filePath))
+	}
+
+	assert.True(t, minioClient.ExistObject("staging/.recyclebin/staging/pipeline/1"))
+	assert.True(t, minioClient.ExistObject("production/.recyclebin/production/pipeline/1"))
+	purged, err := staging.PurgeRecycleBin(context.TODO(), 0)
+	require.Nil(t, err)
+	assert.Equal(t, 1, purged)
+	assert.True(t, minioClient.ExistObject("production/.recyclebin/production/pipeline/1"))
+}
+
+func TestSoftDelete_RecycleBinHiddenFromListings(t *testing.T) {
+	manager, _ := newSoftDeleteStore()
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipeline/1"))
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipeline/2"))
+	require.Nil(t, manager.DeleteFile(context.TODO(), "pipeline/1"))
+
+	var listed []string
+	require.Nil(t, manager.WalkFiles(context.TODO(), "pipeline/", func(file FileInfo) error {
+		listed = append(listed, file.Key)
+		return nil
+	}))
+	assert.Equal(t, []string{"pipeline/2"}, listed)
+}
+
+func TestHardDeleteByDefault(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), manager.GetPipelineKey("1")))
+
+	require.Nil(t, manager.DeleteFile(context.TODO(), manager.GetPipelineKey("1")))
+	assert.Equal(t, 0, minioClient.GetObjectCount())
+}
@@ -0,0 +1,251 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// specRefField marks a mapping standing in for the spec stored at the referenced path,
+	// e.g. `component: {$objectRef: components/train.yaml}`.
+	specRefField = "$objectRef"
+	// maxConcurrentSpecFetches bounds the referenced specs fetched at once.
+	maxConcurrentSpecFetches = 8
+)
+
+// ErrSpecReferenceCycle is the cause of errors returned for specs referencing themselves.
+var ErrSpecReferenceCycle = errors.New("spec reference cycle")
+
+// ErrSpecBudgetExceeded is the cause of errors returned for specs whose resolution fetches
+// more bytes than allowed.
+var ErrSpecBudgetExceeded = errors.New("spec size budget exceeded")
+
+// GetResolvedSpec returns the spec at filePath as a single YAML bundle, in which every
+// reference to another spec is replaced by the referenced spec, itself resolved. Specs
+// referenced several times are fetched once. A spec referencing itself, directly or
+// through other specs, is rejected.
+func (m *MinioObjectStore) GetResolvedSpec(ctx context.Context, filePath string) ([]byte, error) {
+	return m.GetResolvedSpecWithBudget(ctx, filePath, 0)
+}
+
+// GetResolvedSpecWithBudget is GetResolvedSpec, failing as soon as the specs fetched add up
+// to more than maxBytes, so that huge or maliciously referenced specs cannot exhaust the
+// server. Sizes are checked before downloading, and the fetches in progress are abandoned
+// once the budget is exceeded. A budget of zero is unlimited.
+func (m *MinioObjectStore) GetResolvedSpecWithBudget(ctx context.Context, filePath string, maxBytes int64) ([]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	// Abandons the fetches still in progress once resolved.
+	defer cancel()
+	resolver := &specResolver{
+		store:     m,
+		ctx:       ctx,
+		cancel:    cancel,
+		root:      filePath,
+		budget:    maxBytes,
+		semaphore: make(chan struct{}, maxConcurrentSpecFetches),
+		specs:     make(map[string]*fetchedSpec),
+	}
+	resolver.prefetch([]string{filePath})
+	resolved, err := resolver.load(filePath, nil)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := yaml.Marshal(resolved)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal the resolved spec %v", filePath)
+	}
+	return bundle, nil
+}
+
+// specResolver fetches and inlines the specs referenced by a root spec.
+type specResolver struct {
+	store     *MinioObjectStore
+	ctx       context.Context
+	cancel    context.CancelFunc
+	root      string
+	budget    int64
+	semaphore chan struct{}
+	mutex     sync.Mutex
+	specs     map[string]*fetchedSpec
+	fetched   int64
+	// budgetErr is set once the budget is exceeded, failing the fetches it cancelled.
+	budgetErr error
+}
+
+// fetchedSpec is a spec fetch, done once done is closed.
+type fetchedSpec struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// prefetch starts fetching the specs at the given paths that are not fetched yet.
+func (r *specResolver) prefetch(filePaths []string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, filePath := range filePaths {
+		if _, ok := r.specs[filePath]; ok {
+			continue
+		}
+		spec := &fetchedSpec{done: make(chan struct{})}
+		r.specs[filePath] = spec
+		go func(filePath string) {
+			defer close(spec.done)
+			r.semaphore <- struct{}{}
+			defer func() { <-r.semaphore }()
+			spec.data, spec.err = r.fetch(filePath)
+		}(filePath)
+	}
+}
+
+// fetch returns the content of the spec at filePath, charging it to the budget.
+func (r *specResolver) fetch(filePath string) ([]byte, error) {
+	if r.budget <= 0 {
+		return r.store.GetFile(r.ctx, filePath)
+	}
+	if err := r.ctx.Err(); err != nil {
+		return nil, util.NewUnavailableServerError(err, "Failed to get file %v", filePath)
+	}
+	info, err := r.store.GetFileInfo(r.ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.charge(info.Size); err != nil {
+		return nil, err
+	}
+	data, err := r.store.GetFile(r.ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	// The spec may have been replaced since it was stat-ed.
+	if err := r.charge(int64(len(data)) - info.Size); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// charge adds size bytes to the bytes fetched, cancelling the resolution if they exceed
+// the budget.
+func (r *specResolver) charge(size int64) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.fetched += size
+	if r.fetched > r.budget && r.budgetErr == nil {
+		r.budgetErr = util.NewInvalidInputErrorWithDetails(ErrSpecBudgetExceeded,
+			fmt.Sprintf("Failed to resolve spec %v: the referenced specs exceed the budget of %v bytes", r.root, r.budget))
+		r.cancel()
+	}
+	return r.budgetErr
+}
+
+// load returns the resolved spec at filePath, referenced through the specs in path.
+func (r *specResolver) load(filePath string, path []string) (interface{}, error) {
+	for _, ancestor := range path {
+		if ancestor == filePath {
+			chain := strings.Join(append(path, filePath), " -> ")
+			return nil, util.NewInvalidInputErrorWithDetails(ErrSpecReferenceCycle,
+				fmt.Sprintf("Failed to resolve spec %v: reference cycle %v", path[0], chain))
+		}
+	}
+	r.prefetch([]string{filePath})
+	r.mutex.Lock()
+	spec := r.specs[filePath]
+	r.mutex.Unlock()
+	<-spec.done
+	if spec.err != nil {
+		r.mutex.Lock()
+		budgetErr := r.budgetErr
+		r.mutex.Unlock()
+		if budgetErr != nil {
+			return nil, budgetErr
+		}
+		return nil, util.Wrapf(spec.err, "Failed to resolve spec %v", filePath)
+	}
+
+	var node interface{}
+	if err := yaml.Unmarshal(spec.data, &node); err != nil {
+		return nil, util.NewInvalidInputError("Failed to resolve spec %v: invalid YAML: %v", filePath, err.Error())
+	}
+	path = append(path[:len(path):len(path)], filePath)
+	r.prefetch(collectSpecRefs(node, nil))
+	return r.inline(node, path)
+}
+
+// inline replaces the references in node with the specs they reference.
+func (r *specResolver) inline(node interface{}, path []string) (interface{}, error) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := specRef(value); ok {
+			return r.load(ref, path)
+		}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			resolved, err := r.inline(value[key], path)
+			if err != nil {
+				return nil, err
+			}
+			value[key] = resolved
+		}
+	case []interface{}:
+		for i, item := range value {
+			resolved, err := r.inline(item, path)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = resolved
+		}
+	}
+	return node, nil
+}
+
+// collectSpecRefs appends the paths of the specs referenced in node to refs.
+func collectSpecRefs(node interface{}, refs []string) []string {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := specRef(value); ok {
+			return append(refs, ref)
+		}
+		for _, child := range value {
+			refs = collectSpecRefs(child, refs)
+		}
+	case []interface{}:
+		for _, item := range value {
+			refs = collectSpecRefs(item, refs)
+		}
+	}
+	return refs
+}
+
+// specRef returns the referenced path if node is a reference to another spec.
+func specRef(node map[string]interface{}) (string, bool) {
+	if len(node) != 1 {
+		return "", false
+	}
+	ref, ok := node[specRefField].(string)
+	return ref, ok && ref != ""
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"sigs.k8s.io/yaml"
+)
+
+func addSpecs(t *testing.T, manager *MinioObjectStore, specs map[string]string) {
+	for filePath, spec := range specs {
+		require.Nil(t, manager.AddFile(context.TODO(), []byte(spec), filePath))
+	}
+}
+
+func TestGetResolvedSpec(t *testing.T) {
+	minioClient := newCountingMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	addSpecs(t, manager, map[string]string{
+		"pipelines/1": `
+pipelineInfo:
+  name: training
+components:
+  train:
+    $objectRef: components/train
+  evaluate:
+    $objectRef: components/evaluate
+  evaluate-again:
+    $objectRef: components/evaluate
+`,
+		"components/train":    "image: trainer\nargs: [--epochs, 3]\n",
+		"components/evaluate": "image: evaluator\n",
+	})
+	minioClient.getCount = 0
+
+	bundle, err := manager.GetResolvedSpec(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	var resolved map[string]interface{}
+	require.Nil(t, yaml.Unmarshal(bundle, &resolved))
+	assert.Equal(t, map[string]interface{}{
+		"pipelineInfo": map[string]interface{}{"name": "training"},
+		"components": map[string]interface{}{
+			"train":          map[string]interface{}{"image": "trainer", "args": []interface{}{"--epochs", float64(3)}},
+			"evaluate":       map[string]interface{}{"image": "evaluator"},
+			"evaluate-again": map[string]interface{}{"image": "evaluator"},
+		},
+	}, resolved)
+	assert.Equal(t, 3, minioClient.getCount)
+}
+
+func TestGetResolvedSpec_Nested(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+	addSpecs(t, manager, map[string]string{
+		"pipelines/1":      "root: {$objectRef: components/outer}\n",
+		"components/outer": "tasks:\n- {$objectRef: components/inner}\n",
+		"components/inner": "image: inner\n",
+	})
+
+	bundle, err := manager.GetResolvedSpec(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	assert.YAMLEq(t, "root:\n  tasks:\n  - image: inner\n", string(bundle))
+}
+
+func TestGetResolvedSpec_Cycle(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+	addSpecs(t, manager, map[string]string{
+		"pipelines/1":  "component: {$objectRef: components/a}\n",
+		"components/a": "component: {$objectRef: components/b}\n",
+		"components/b": "component: {$objectRef: components/a}\n",
+	})
+
+	_, err := manager.GetResolvedSpec(context.TODO(), "pipelines/1")
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrSpecReferenceCycle))
+	assert.Contains(t, err.Error(), "pipelines/1 -> components/a -> components/b -> components/a")
+}
+
+func TestGetResolvedSpec_MissingReference(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+	addSpecs(t, manager, map[string]string{"pipelines/1": "component: {$objectRef: components/missing}\n"})
+
+	_, err := manager.GetResolvedSpec(context.TODO(), "pipelines/1")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestGetResolvedSpecWithBudget(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+	specs := map[string]string{
+		"pipelines/1":      "root: {$objectRef: components/outer}\n",
+		"components/outer": "tasks:\n- {$objectRef: components/inner}\n",
+		"components/inner": "image: inner\n",
+	}
+	addSpecs(t, manager, specs)
+	total := 0
+	for _, spec := range specs {
+		total += len(spec)
+	}
+
+	bundle, err := manager.GetResolvedSpecWithBudget(context.TODO(), "pipelines/1", int64(total))
+	require.Nil(t, err)
+	assert.YAMLEq(t, "root:\n  tasks:\n  - image: inner\n", string(bundle))
+}
+
+func TestGetResolvedSpecWithBudget_Exceeded(t *testing.T) {
+	minioClient := newCountingMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	addSpecs(t, manager, map[string]string{
+		"pipelines/1":     "root: {$objectRef: components/huge}\n",
+		"components/huge": "image: huge\nargs: [" + strings.Repeat("x, ", 1000) + "x]\n",
+	})
+	minioClient.getCount = 0
+
+	bundle, err := manager.GetResolvedSpecWithBudget(context.TODO(), "pipelines/1", 1024)
+	require.NotNil(t, err)
+	assert.Nil(t, bundle)
+	assert.True(t, errors.Is(err, ErrSpecBudgetExceeded))
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.Error(), "exceed the budget of 1024 bytes")
+	// The huge spec is rejected from its size, without being downloaded.
+	assert.Equal(t, 1, minioClient.getCount)
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+)
+
+func TestAddFile_ObjectRetention(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	retainUntil := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	minioClient.EXPECT().PutObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (int64, error) {
+			assert.Equal(t, minio.Compliance, opts.Mode)
+			assert.Equal(t, retainUntil, opts.RetainUntilDate)
+			assert.Equal(t, minio.LegalHoldEnabled, opts.LegalHold)
+			return objectSize, nil
+		})
+
+	ctx := WithObjectRetention(context.TODO(), ObjectRetention{
+		Mode:        minio.Compliance,
+		RetainUntil: retainUntil,
+		LegalHold:   minio.LegalHoldEnabled,
+	})
+	require.Nil(t, manager.AddFile(ctx, []byte("spec"), "pipelines/1"))
+}
+
+func TestAddFile_ObjectRetentionWithoutDate(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+
+	ctx := WithObjectRetention(context.TODO(), ObjectRetention{Mode: minio.Governance})
+	err := manager.AddFile(ctx, []byte("spec"), "pipelines/1")
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestSetRetention(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	retainUntil := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	minioClient.EXPECT().PutObjectRetention(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, bucketName, objectName string, opts minio.PutObjectRetentionOptions) error {
+			assert.Equal(t, minio.Governance, *opts.Mode)
+			assert.Equal(t, retainUntil, *opts.RetainUntilDate)
+			return nil
+		})
+	minioClient.EXPECT().PutObjectLegalHold(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, bucketName, objectName string, opts minio.PutObjectLegalHoldOptions) error {
+			assert.Equal(t, minio.LegalHoldDisabled, *opts.Status)
+			return nil
+		})
+
+	err := manager.SetRetention(context.TODO(), "pipelines/1", ObjectRetention{
+		Mode:        minio.Governance,
+		RetainUntil: retainUntil,
+		LegalHold:   minio.LegalHoldDisabled,
+	})
+	require.Nil(t, err)
+}
+
+func TestSetRetention_LegalHoldOnly(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	minioClient.EXPECT().PutObjectLegalHold(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any()).Return(nil)
+
+	err := manager.SetRetention(context.TODO(), "pipelines/1", ObjectRetention{LegalHold: minio.LegalHoldEnabled})
+	require.Nil(t, err)
+}
+
+func TestSetRetention_NotFound(t *testing.T) {
+	manager := NewMinioObjectStore(NewFakeMinioClient(), "mlpipeline", "pipelines", false)
+
+	err := manager.SetRetention(context.TODO(), "pipelines/1", ObjectRetention{LegalHold: minio.LegalHoldEnabled})
+
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestDeleteFile_ObjectLocked(t *testing.T) {
+	for name, lockedErr := range map[string]error{
+		"minio": minio.ErrorResponse{
+			Code:       "ObjectLocked",
+			Message:    "Object is WORM protected and cannot be overwritten",
+			StatusCode: http.StatusBadRequest,
+		},
+		"s3": minio.ErrorResponse{
+			Code:       "AccessDenied",
+			Message:    "Access Denied because object protected by object lock.",
+			StatusCode: http.StatusForbidden,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			minioClient := NewMockMinioClient(gomock.NewController(t))
+			manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+			minioClient.EXPECT().DeleteObject(gomock.Any(), "mlpipeline", "pipelines/1").Return(lockedErr)
+
+			err := manager.DeleteFile(context.TODO(), "pipelines/1")
+
+			require.NotNil(t, err)
+			assert.True(t, errors.Is(err, ErrObjectLocked))
+			assert.Equal(t, codes.FailedPrecondition, err.(*util.UserError).ExternalStatusCode())
+		})
+	}
+}
+
+func TestDeleteFile_AccessDeniedIsNotLocked(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	minioClient.EXPECT().DeleteObject(gomock.Any(), "mlpipeline", "pipelines/1").Return(minio.ErrorResponse{
+		Code:       "AccessDenied",
+		Message:    "Access Denied.",
+		StatusCode: http.StatusForbidden,
+	})
+
+	err := manager.DeleteFile(context.TODO(), "pipelines/1")
+
+	require.NotNil(t, err)
+	assert.False(t, errors.Is(err, ErrObjectLocked))
+	assert.True(t, errors.Is(err, ErrAuth))
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// RetryPolicy sets how the backend calls failing with transient errors, those classified
+// as ErrNetwork, are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts of a call, including the first one. Zero or
+	// one disables retries.
+	MaxAttempts int
+	// Backoff is the wait before the first retry, doubled before every following one.
+	Backoff time.Duration
+	// MaxRetryAfter caps the wait the backend asks for with the Retry-After header of its
+	// 503 and 429 responses, which replaces the backoff before the next retry. Zero ignores
+	// the header. The header is only seen if the client uses a RetryAfterTransport, and the
+	// client should then make a single attempt per call, as its own retries ignore it.
+	MaxRetryAfter time.Duration
+}
+
+type maxRetriesContextKey struct{}
+
+// WithMaxRetries overrides the number of retries of the operations using ctx, e.g. so
+// interactive requests fail fast while background jobs use the retry policy of the store.
+// Zero disables retries.
+func WithMaxRetries(ctx context.Context, maxRetries int) context.Context {
+	return context.WithValue(ctx, maxRetriesContextKey{}, maxRetries)
+}
+
+// maxAttempts returns the number of attempts of the calls of an operation using ctx.
+func (m *MinioObjectStore) maxAttempts(ctx context.Context) int {
+	if maxRetries, ok := ctx.Value(maxRetriesContextKey{}).(int); ok {
+		return maxRetries + 1
+	}
+	return m.retryPolicy.MaxAttempts
+}
+
+// retry calls call until it succeeds, fails with an error that is not transient, the
+// retry policy gives up, or ctx is done. It returns the error of the last attempt. Each
+// attempt is passed a context recording the Retry-After the backend answered it with.
+// The outcome is recorded for HealthCheck.
+func (m *MinioObjectStore) retry(ctx context.Context, call func(ctx context.Context) error) (err error) {
+	defer func() {
+		m.recordOutcome(err)
+	}()
+	maxAttempts := m.maxAttempts(ctx)
+	backoff := m.retryPolicy.Backoff
+	for attempt := 1; ; attempt++ {
+		attemptCtx, hint := withRetryAfterHint(ctx)
+		err = call(attemptCtx)
+		if err == nil || attempt >= maxAttempts || ClassifyError(err) != ErrNetwork {
+			return err
+		}
+		wait := backoff
+		if retryAfter, ok := hint.get(); ok && m.retryPolicy.MaxRetryAfter > 0 {
+			wait = min(retryAfter, m.retryPolicy.MaxRetryAfter)
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-m.after(wait):
+		}
+		backoff *= 2
+	}
+}
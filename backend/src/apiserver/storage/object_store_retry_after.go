@@ -0,0 +1,105 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// retryAfterHint records the wait the backend asked for while serving an attempt of a call.
+type retryAfterHint struct {
+	mutex      sync.Mutex
+	retryAfter time.Duration
+	ok         bool
+}
+
+type retryAfterHintContextKey struct{}
+
+// withRetryAfterHint returns a context whose requests record the Retry-After of their
+// responses in the returned hint, if sent through a RetryAfterTransport.
+func withRetryAfterHint(ctx context.Context) (context.Context, *retryAfterHint) {
+	hint := &retryAfterHint{}
+	return context.WithValue(ctx, retryAfterHintContextKey{}, hint), hint
+}
+
+func (h *retryAfterHint) set(retryAfter time.Duration) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.retryAfter = retryAfter
+	h.ok = true
+}
+
+// get returns the last wait recorded, if any.
+func (h *retryAfterHint) get() (time.Duration, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.retryAfter, h.ok
+}
+
+// RetryAfterTransport passes the Retry-After header of the 503 and 429 responses of the
+// object store on to the retries of the store. The minio client does not report response
+// headers with its errors, so its transport must be wrapped for RetryPolicy.MaxRetryAfter
+// to have any effect.
+type RetryAfterTransport struct {
+	Base http.RoundTripper
+}
+
+// NewRetryAfterTransport wraps base, http.DefaultTransport if nil.
+func NewRetryAfterTransport(base http.RoundTripper) *RetryAfterTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RetryAfterTransport{Base: base}
+}
+
+func (t *RetryAfterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil || (resp.StatusCode != http.StatusServiceUnavailable && resp.StatusCode != http.StatusTooManyRequests) {
+		return resp, err
+	}
+	hint, ok := req.Context().Value(retryAfterHintContextKey{}).(*retryAfterHint)
+	if !ok {
+		return resp, err
+	}
+	if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		hint.set(retryAfter)
+	}
+	return resp, err
+}
+
+// parseRetryAfter parses a Retry-After header, either a number of seconds or an HTTP date,
+// into the wait it asks for from now.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(date.Sub(now), 0), true
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// serviceUnavailable sends a request with ctx through a RetryAfterTransport answering 503
+// with the given Retry-After, and returns the error the minio client reports for it.
+func serviceUnavailable(t *testing.T, ctx context.Context, retryAfter string) error {
+	transport := NewRetryAfterTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		if retryAfter != "" {
+			header.Set("Retry-After", retryAfter)
+		}
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: header, Body: http.NoBody}, nil
+	}))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, "http://minio/mlpipeline/pipelines/1", nil)
+	require.Nil(t, err)
+	resp, err := transport.RoundTrip(req)
+	require.Nil(t, err)
+	resp.Body.Close()
+	return minio.ErrorResponse{Code: "SlowDown", StatusCode: http.StatusServiceUnavailable}
+}
+
+func newRetryAfterTestStore(t *testing.T, maxRetryAfter time.Duration, retryAfter string) (*MinioObjectStore, *FakeClock) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	gomock.InOrder(
+		minioClient.EXPECT().
+			PutObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, bucketName, objectName string, reader io.Reader,
+				objectSize int64, opts minio.PutObjectOptions,
+			) (int64, error) {
+				return 0, serviceUnavailable(t, ctx, retryAfter)
+			}),
+		minioClient.EXPECT().
+			PutObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(int64(4), nil),
+	)
+	clock := NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	manager := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines", WithClock(clock),
+		WithRetry(RetryPolicy{MaxAttempts: 3, Backoff: 100 * time.Millisecond, MaxRetryAfter: maxRetryAfter}))
+	return manager, clock
+}
+
+func TestRetry_HonorsRetryAfter(t *testing.T) {
+	manager, clock := newRetryAfterTestStore(t, time.Minute, "3")
+	start := clock.Now()
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+
+	assert.Equal(t, 3*time.Second, clock.Now().Sub(start))
+}
+
+func TestRetry_RetryAfterCapped(t *testing.T) {
+	manager, clock := newRetryAfterTestStore(t, time.Second, "120")
+	start := clock.Now()
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+
+	assert.Equal(t, time.Second, clock.Now().Sub(start))
+}
+
+func TestRetry_RetryAfterIgnoredWhenDisabled(t *testing.T) {
+	manager, clock := newRetryAfterTestStore(t, 0, "3")
+	start := clock.Now()
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+
+	assert.Equal(t, 100*time.Millisecond, clock.Now().Sub(start))
+}
+
+func TestRetry_NoRetryAfterUsesBackoff(t *testing.T) {
+	manager, clock := newRetryAfterTestStore(t, time.Minute, "")
+	start := clock.Now()
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+
+	assert.Equal(t, 100*time.Millisecond, clock.Now().Sub(start))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{value: "5", expected: 5 * time.Second, ok: true},
+		{value: "Wed, 01 Jan 2025 00:00:10 GMT", expected: 10 * time.Second, ok: true},
+		{value: "Tue, 31 Dec 2024 23:59:00 GMT", expected: 0, ok: true},
+		{value: "", ok: false},
+		{value: "-1", ok: false},
+		{value: "soon", ok: false},
+	}
+	for _, tt := range tests {
+		retryAfter, ok := parseRetryAfter(tt.value, now)
+		assert.Equal(t, tt.ok, ok, tt.value)
+		assert.Equal(t, tt.expected, retryAfter, tt.value)
+	}
+}
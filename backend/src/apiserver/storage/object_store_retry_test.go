@@ -0,0 +1,151 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// flakyMinioClient fails the first calls to the fake minio client with a given error.
+type flakyMinioClient struct {
+	*FakeMinioClient
+	failures int
+	err      error
+	calls    int
+}
+
+func (c *flakyMinioClient) fail() error {
+	c.calls++
+	if c.calls <= c.failures {
+		return c.err
+	}
+	return nil
+}
+
+func (c *flakyMinioClient) PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader,
+	objectSize int64, opts minio.PutObjectOptions,
+) (int64, error) {
+	if err := c.fail(); err != nil {
+		// Like a real upload, the failure may happen after the content was partly read.
+		io.CopyN(io.Discard, reader, 1)
+		return 0, err
+	}
+	return c.FakeMinioClient.PutObject(ctx, bucketName, objectName, reader, objectSize, opts)
+}
+
+func (c *flakyMinioClient) GetObject(ctx context.Context, bucketName, objectName string,
+	opts minio.GetObjectOptions,
+) (io.Reader, error) {
+	if err := c.fail(); err != nil {
+		return nil, err
+	}
+	return c.FakeMinioClient.GetObject(ctx, bucketName, objectName, opts)
+}
+
+func newFlakyStore(failures int, err error, maxAttempts int) (*MinioObjectStore, *flakyMinioClient) {
+	minioClient := &flakyMinioClient{FakeMinioClient: NewFakeMinioClient(), failures: failures, err: err}
+	manager := NewMinioObjectStoreWithOptions(minioClient, "", "pipelines",
+		WithRetry(RetryPolicy{MaxAttempts: maxAttempts, Backoff: time.Millisecond}))
+	return manager, minioClient
+}
+
+func TestRetry_TransientErrors(t *testing.T) {
+	manager, minioClient := newFlakyStore(2, io.ErrUnexpectedEOF, 3)
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+	assert.Equal(t, 3, minioClient.calls)
+	data, err := minioClient.FakeMinioClient.GetObject(context.TODO(), "", "pipelines/1", minio.GetObjectOptions{})
+	require.Nil(t, err)
+	stored, err := io.ReadAll(data)
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), stored)
+
+	minioClient.calls = 0
+	content, err := manager.GetFile(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), content)
+	assert.Equal(t, 3, minioClient.calls)
+}
+
+func TestRetry_GivesUp(t *testing.T) {
+	manager, minioClient := newFlakyStore(5, io.ErrUnexpectedEOF, 3)
+
+	err := manager.AddFile(context.TODO(), []byte("spec"), "pipelines/1")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+	assert.Equal(t, 3, minioClient.calls)
+}
+
+func TestRetry_PermanentErrors(t *testing.T) {
+	manager, minioClient := newFlakyStore(5, errors.New("some error"), 3)
+
+	err := manager.AddFile(context.TODO(), []byte("spec"), "pipelines/1")
+	require.NotNil(t, err)
+	assert.Equal(t, 1, minioClient.calls)
+}
+
+func TestRetry_DisabledByDefault(t *testing.T) {
+	manager, minioClient := newFlakyStore(1, io.ErrUnexpectedEOF, 0)
+
+	require.NotNil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+	assert.Equal(t, 1, minioClient.calls)
+}
+
+func TestRetry_StopsWhenContextDone(t *testing.T) {
+	minioClient := &flakyMinioClient{FakeMinioClient: NewFakeMinioClient(), failures: 5, err: io.ErrUnexpectedEOF}
+	manager := NewMinioObjectStoreWithOptions(minioClient, "", "pipelines",
+		WithRetry(RetryPolicy{MaxAttempts: 5, Backoff: time.Hour}))
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+
+	require.NotNil(t, manager.AddFile(ctx, []byte("spec"), "pipelines/1"))
+	assert.Equal(t, 1, minioClient.calls)
+}
+
+func TestRetry_ContextOverride(t *testing.T) {
+	manager, minioClient := newFlakyStore(2, io.ErrUnexpectedEOF, 3)
+
+	err := manager.AddFile(WithMaxRetries(context.TODO(), 0), []byte("spec"), "pipelines/1")
+	require.NotNil(t, err)
+	assert.Equal(t, 1, minioClient.calls)
+
+	// One failure left, which the store default would retry.
+	minioClient.calls, minioClient.failures = 0, 1
+	_, err = manager.GetFile(WithMaxRetries(context.TODO(), 0), "pipelines/1")
+	require.NotNil(t, err)
+	assert.Equal(t, 1, minioClient.calls)
+}
+
+func TestRetry_ContextOverrideAboveDefault(t *testing.T) {
+	manager, minioClient := newFlakyStore(4, io.ErrUnexpectedEOF, 2)
+
+	require.Nil(t, manager.AddFile(WithMaxRetries(context.TODO(), 5), []byte("spec"), "pipelines/1"))
+	assert.Equal(t, 5, minioClient.calls)
+
+	// Without the override, the store default gives up after 2 attempts.
+	minioClient.calls, minioClient.failures = 0, 4
+	require.NotNil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+	assert.Equal(t, 2, minioClient.calls)
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"strings"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// PrefixRewrite redirects the keys starting with From to the same keys starting with To.
+type PrefixRewrite struct {
+	From string
+	To   string
+}
+
+// ParsePrefixRewrites parses a comma separated list of rewrite rules written `from=to`,
+// e.g. "old/=new/,legacy/pipelines/=pipelines/".
+func ParsePrefixRewrites(rules string) ([]PrefixRewrite, error) {
+	var rewrites []PrefixRewrite
+	for _, rule := range strings.Split(rules, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(rule, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" {
+			return nil, util.NewInvalidInputError("Invalid prefix rewrite %q: expected from=to", rule)
+		}
+		rewrites = append(rewrites, PrefixRewrite{From: from, To: to})
+	}
+	return rewrites, nil
+}
+
+// SetPrefixRewrites redirects every file path the store is called with through the given
+// rules, on reads and writes alike, so objects can be relocated without changing callers.
+// Rules are tried in order and only the first matching one is applied.
+func (m *MinioObjectStore) SetPrefixRewrites(rewrites []PrefixRewrite) {
+	m.prefixRewrites = append([]PrefixRewrite(nil), rewrites...)
+}
+
+// rewritePrefix applies the first rewrite rule matching filePath.
+func (m *MinioObjectStore) rewritePrefix(filePath string) string {
+	for _, rewrite := range m.prefixRewrites {
+		if strings.HasPrefix(filePath, rewrite.From) {
+			return rewrite.To + strings.TrimPrefix(filePath, rewrite.From)
+		}
+	}
+	return filePath
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixRewrites(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	manager.SetPrefixRewrites([]PrefixRewrite{
+		{From: "old/", To: "new/"},
+		{From: "old/nested/", To: "unused/"},
+	})
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "old/nested/1"))
+	assert.Equal(t, 1, minioClient.GetObjectCount())
+	assert.True(t, minioClient.ExistObject("new/nested/1"))
+
+	data, err := manager.GetFile(context.TODO(), "old/nested/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+	data, err = manager.GetFile(context.TODO(), "new/nested/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+
+	require.Nil(t, manager.DeleteFile(context.TODO(), "old/nested/1"))
+	assert.Equal(t, 0, minioClient.GetObjectCount())
+}
+
+func TestPrefixRewrites_Unmatched(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	manager.SetPrefixRewrites([]PrefixRewrite{{From: "old/", To: "new/"}})
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines/old/1"))
+	assert.True(t, minioClient.ExistObject("pipelines/old/1"))
+}
+
+func TestParsePrefixRewrites(t *testing.T) {
+	rewrites, err := ParsePrefixRewrites(" old/=new/, legacy/pipelines/=pipelines/,")
+	require.Nil(t, err)
+	assert.Equal(t, []PrefixRewrite{{From: "old/", To: "new/"}, {From: "legacy/pipelines/", To: "pipelines/"}}, rewrites)
+
+	rewrites, err = ParsePrefixRewrites("")
+	require.Nil(t, err)
+	assert.Empty(t, rewrites)
+
+	_, err = ParsePrefixRewrites("old/")
+	assert.NotNil(t, err)
+	_, err = ParsePrefixRewrites("=new/")
+	assert.NotNil(t, err)
+}
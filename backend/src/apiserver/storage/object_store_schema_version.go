@@ -0,0 +1,48 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// ErrSchemaVersionMismatch is the cause of errors returned for specs of an unexpected schema version.
+var ErrSchemaVersionMismatch = errors.New("spec schema version mismatch")
+
+// GetFromYamlFileForVersion is GetFromYamlFile for specs of the given schema version. The
+// schemaVersion field of the spec is checked before it is unmarshalled into out, and a
+// FailedPrecondition error caused by ErrSchemaVersionMismatch is returned if it differs,
+// instead of parsing a spec of another version into a struct it does not fit.
+func (m *MinioObjectStore) GetFromYamlFileForVersion(ctx context.Context, filePath string, expectedVersion string, out interface{}) error {
+	bytes, err := m.GetFile(ctx, filePath)
+	if err != nil {
+		return util.Wrap(err, "Failed to read from a yaml file")
+	}
+	var header struct {
+		SchemaVersion string `json:"schemaVersion"`
+	}
+	if err := yaml.Unmarshal(bytes, &header); err != nil {
+		return util.NewInternalServerError(err, "Failed to unmarshal file %v: %v", filePath, err.Error())
+	}
+	if header.SchemaVersion != expectedVersion {
+		return util.NewFailedPreconditionError(ErrSchemaVersionMismatch,
+			"File %v has schema version %q, expected %q", filePath, header.SchemaVersion, expectedVersion)
+	}
+	return unmarshalYamlFile(ctx, bytes, nil, out, filePath)
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+type versionedSpec struct {
+	SchemaVersion string   `json:"schemaVersion"`
+	Tasks         []string `json:"tasks"`
+}
+
+func TestGetFromYamlFileForVersion(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("schemaVersion: 2.1.0\ntasks: [train]\n"), "pipeline/1"))
+
+	var spec versionedSpec
+	require.Nil(t, manager.GetFromYamlFileForVersion(context.TODO(), "pipeline/1", "2.1.0", &spec))
+	assert.Equal(t, versionedSpec{SchemaVersion: "2.1.0", Tasks: []string{"train"}}, spec)
+}
+
+func TestGetFromYamlFileForVersion_Mismatch(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	// The tasks would not even fit the struct.
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("schemaVersion: 1.0.0\ntasks: {train: {}}\n"), "pipeline/1"))
+
+	var spec versionedSpec
+	err := manager.GetFromYamlFileForVersion(context.TODO(), "pipeline/1", "2.1.0", &spec)
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrSchemaVersionMismatch))
+	assert.Equal(t, codes.FailedPrecondition, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.Error(), `File pipeline/1 has schema version "1.0.0", expected "2.1.0"`)
+	assert.Equal(t, versionedSpec{}, spec)
+}
+
+func TestGetFromYamlFileForVersion_MissingVersion(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("tasks: [train]\n"), "pipeline/1"))
+
+	var spec versionedSpec
+	err := manager.GetFromYamlFileForVersion(context.TODO(), "pipeline/1", "2.1.0", &spec)
+	assert.True(t, errors.Is(err, ErrSchemaVersionMismatch))
+	assert.Contains(t, err.Error(), `schema version "", expected "2.1.0"`)
+}
+
+func TestGetFromYamlFileForVersion_NotFound(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+
+	var spec versionedSpec
+	err := manager.GetFromYamlFileForVersion(context.TODO(), "pipeline/1", "2.1.0", &spec)
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+// keyShards is the number of shard folders the files of a folder are spread over.
+const keyShards = 256
+
+// shardFolderPrefix starts the names of the shard folders, marking the keys that are
+// sharded, so they are never told apart from other keys by guessing.
+const shardFolderPrefix = ".shard-"
+
+// SetKeySharding sets whether files are stored under a shard folder named after the hash
+// of their name, e.g. "pipelines/.shard-3f/<id>" for "pipelines/<id>", spreading the files
+// of large flat folders over many key prefixes, and thus over the partitions of the
+// backend. Paths whose folder is a shard folder, as marked by its ".shard-" prefix, are
+// not sharded again, so the paths listed by WalkFiles can be passed back to the store.
+// Prefixes of folders list the sharded files, but prefixes of file names do not. Files
+// stored before sharding was enabled are moved to their shard folder by Reshard.
+func (m *MinioObjectStore) SetKeySharding(enabled bool) {
+	m.keySharding = enabled
+}
+
+// shardKey inserts the shard folder of key before its name, unless sharding is disabled or
+// key is already sharded.
+func (m *MinioObjectStore) shardKey(key string) string {
+	if !m.keySharding {
+		return key
+	}
+	dir, name := path.Split(key)
+	if name == "" {
+		return key
+	}
+	if isShardFolder(dir) {
+		return key
+	}
+	return dir + keyShard(name) + "/" + name
+}
+
+// unshardKey removes the shard folder from key, if it has one.
+func unshardKey(key string) string {
+	dir, name := path.Split(key)
+	if name == "" || !isShardFolder(dir) {
+		return key
+	}
+	parent, _ := path.Split(strings.TrimSuffix(dir, "/"))
+	return parent + name
+}
+
+// isShardFolder returns whether dir is a shard folder.
+func isShardFolder(dir string) bool {
+	return strings.HasPrefix(path.Base(dir), shardFolderPrefix)
+}
+
+// keyShard returns the name of the shard folder of the files named name.
+func keyShard(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return fmt.Sprintf("%v%02x", shardFolderPrefix, h.Sum32()%keyShards)
+}
+
+// Reshard moves the files under prefix that are not stored under their shard folder, e.g.
+// because they were stored before sharding was enabled, to their sharded key, copying up
+// to concurrency files at once. Each file is copied server-side and the original deleted
+// once the copy is verified, like MoveFile does. Files already sharded are left alone, so
+// re-running Reshard, e.g. after it was interrupted, only moves the remaining files. It
+// returns the number of files moved, and the first failure, if any, once every file has
+// been tried.
+func (m *MinioObjectStore) Reshard(ctx context.Context, prefix string, concurrency int) (int, error) {
+	if err := m.checkOpen("reshard files under", prefix); err != nil {
+		return 0, err
+	}
+	if err := m.checkMaintenance("reshard files under", prefix); err != nil {
+		return 0, err
+	}
+	if !m.keySharding {
+		return 0, util.NewFailedPreconditionError(errors.New("key sharding is disabled"),
+			"Failed to reshard files under %v: key sharding is disabled", prefix)
+	}
+	var toMove []string
+	err := m.WalkFiles(ctx, prefix, func(file FileInfo) error {
+		if m.unshardedKey(ctx, file.Key) != m.resolveKey(ctx, file.Key) {
+			toMove = append(toMove, file.Key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return m.moveFiles(ctx, toMove, concurrency, "reshard", func(filePath string) (string, string) {
+		return m.unshardedKey(ctx, filePath), m.resolveKey(ctx, filePath)
+	})
+}
+
+// moveFiles moves the files from the key keys returns first to the one it returns second,
+// up to concurrency at once. The moves are audited with the keys, as the paths of the files
+// do not change. It returns the number of files moved, and the first failure,
+// if any, once every file has been tried.
+func (m *MinioObjectStore) moveFiles(ctx context.Context, filePaths []string, concurrency int, operation string,
+	keys func(filePath string) (string, string),
+) (int, error) {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	var moved atomic.Int64
+	errs := make([]error, len(filePaths))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < concurrency && worker < len(filePaths); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				filePath := filePaths[i]
+				srcKey, dstKey := keys(filePath)
+				if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationMove, Path: srcKey, TargetPath: dstKey, Size: -1}); err != nil {
+					errs[i] = err
+					continue
+				}
+				if err := m.moveObject(ctx, srcKey, dstKey); err != nil {
+					errs[i] = newObjectStoreError(err, "Failed to %v file %v", operation, filePath)
+					continue
+				}
+				moved.Add(1)
+			}
+		}()
+	}
+	for i := range filePaths {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return int(moved.Load()), err
+		}
+	}
+	return int(moved.Load()), nil
+}
+
+// unshardedKey is resolveKey without sharding: the key the file was stored under before
+// sharding was enabled.
+func (m *MinioObjectStore) unshardedKey(ctx context.Context, filePath string) string {
+	return m.transformKey(m.namespaceKey(keyNamespaceFromContext(ctx), m.rewritePrefix(m.normalizeKey(filePath))))
+}
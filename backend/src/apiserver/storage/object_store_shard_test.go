@@ -0,0 +1,141 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func storedKeys(minioClient *FakeMinioClient) []string {
+	minioClient.mutex.Lock()
+	defer minioClient.mutex.Unlock()
+	var keys []string
+	for key := range minioClient.minioClient {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestKeySharding(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	manager.SetKeySharding(true)
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+
+	shardedKey := "pipelines/" + keyShard("1") + "/1"
+	assert.Equal(t, []string{shardedKey}, storedKeys(minioClient))
+	data, err := manager.GetFile(context.TODO(), "pipelines/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+	// Sharded paths, as listed, resolve to themselves.
+	data, err = manager.GetFile(context.TODO(), shardedKey)
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+	var listed []string
+	require.Nil(t, manager.WalkFiles(context.TODO(), "pipelines/", func(file FileInfo) error {
+		listed = append(listed, file.Key)
+		return nil
+	}))
+	assert.Equal(t, []string{shardedKey}, listed)
+}
+
+func TestKeySharding_UnmarkedFolderNamedLikeShard(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines", WithKeySharding(true))
+	// A folder named like the shard of the file, without the shard marker, is not a shard.
+	filePath := "pipelines/" + strings.TrimPrefix(keyShard("1"), shardFolderPrefix) + "/1"
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), filePath))
+
+	assert.Equal(t, []string{"pipelines/" + strings.TrimPrefix(keyShard("1"), shardFolderPrefix) + "/" + keyShard("1") + "/1"},
+		storedKeys(minioClient))
+}
+
+func TestUnshardKey(t *testing.T) {
+	assert.Equal(t, "pipelines/1", unshardKey("pipelines/"+keyShard("1")+"/1"))
+	assert.Equal(t, "1", unshardKey(keyShard("1")+"/1"))
+	assert.Equal(t, "pipelines/3f/1", unshardKey("pipelines/3f/1"))
+	assert.Equal(t, "pipelines/", unshardKey("pipelines/"))
+}
+
+func TestKeySharding_Spreads(t *testing.T) {
+	shards := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		shards[keyShard(fmt.Sprintf("%d", i))] = true
+	}
+	assert.Greater(t, len(shards), keyShards/2)
+}
+
+func TestReshard(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	for i := 0; i < 10; i++ {
+		require.Nil(t, manager.AddFile(context.TODO(), []byte(fmt.Sprintf("spec %d", i)), fmt.Sprintf("pipelines/%d", i)))
+	}
+	manager.SetKeySharding(true)
+	// Stored sharded already.
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec 10"), "pipelines/10"))
+
+	moved, err := manager.Reshard(context.TODO(), "pipelines/", 4)
+
+	require.Nil(t, err)
+	assert.Equal(t, 10, moved)
+	var expected []string
+	for i := 0; i <= 10; i++ {
+		name := fmt.Sprintf("%d", i)
+		expected = append(expected, "pipelines/"+keyShard(name)+"/"+name)
+		data, err := manager.GetFile(context.TODO(), "pipelines/"+name)
+		require.Nil(t, err)
+		assert.Equal(t, []byte("spec "+name), data)
+	}
+	sort.Strings(expected)
+	assert.Equal(t, expected, storedKeys(minioClient))
+
+	moved, err = manager.Reshard(context.TODO(), "pipelines/", 4)
+
+	require.Nil(t, err)
+	assert.Equal(t, 0, moved)
+	assert.Equal(t, expected, storedKeys(minioClient))
+}
+
+func TestReshard_ShardingDisabled(t *testing.T) {
+	manager := NewMinioObjectStore(NewFakeMinioClient(), "mlpipeline", "pipelines", false)
+
+	_, err := manager.Reshard(context.TODO(), "pipelines/", 1)
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.FailedPrecondition, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestReshard_Error(t *testing.T) {
+	manager := NewMinioObjectStore(&FakeBadMinioClient{}, "mlpipeline", "pipelines", false)
+	manager.SetKeySharding(true)
+
+	moved, err := manager.Reshard(context.TODO(), "pipelines/", 1)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, moved)
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	minio "github.com/minio/minio-go/v7"
+)
+
+// storageClassHeader sets the storage class of the destination of a copy.
+const storageClassHeader = "X-Amz-Storage-Class"
+
+// Storage classes of the S3 API. Backends may support others.
+const (
+	StorageClassStandard          = "STANDARD"
+	StorageClassInfrequentAccess  = "STANDARD_IA"
+	StorageClassReducedRedundancy = "REDUCED_REDUNDANCY"
+	StorageClassGlacier           = "GLACIER"
+)
+
+type storageClassContextKey struct{}
+
+// WithStorageClass stores the files written using ctx in the given storage class, e.g. a
+// cold class for archived specs. Files are otherwise stored in the default class of the
+// bucket, usually standard.
+func WithStorageClass(ctx context.Context, storageClass string) context.Context {
+	return context.WithValue(ctx, storageClassContextKey{}, storageClass)
+}
+
+func storageClassFromContext(ctx context.Context) string {
+	storageClass, _ := ctx.Value(storageClassContextKey{}).(string)
+	return storageClass
+}
+
+// SetStorageClass moves the existing file at filePath to the given storage class. The file
+// is copied onto itself server-side, keeping its content and metadata.
+func (m *MinioObjectStore) SetStorageClass(ctx context.Context, filePath string, storageClass string) error {
+	if err := m.checkOpen("set storage class of", filePath); err != nil {
+		return err
+	}
+	if err := m.checkMaintenance("set storage class of", filePath); err != nil {
+		return err
+	}
+	if err := m.checkEnvironment(filePath, true); err != nil {
+		return err
+	}
+	key := m.resolveKey(ctx, filePath)
+	info, err := m.minioClient.StatObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+	if err != nil {
+		return newObjectStoreError(err, "Failed to set storage class of file %v", filePath)
+	}
+	// Replacing the metadata is the only way to change the storage class of a copy, so
+	// the metadata of the file is carried over.
+	userMetadata := make(map[string]string, len(info.UserMetadata)+1)
+	for k, v := range info.UserMetadata {
+		userMetadata[k] = v
+	}
+	userMetadata[storageClassHeader] = storageClass
+	if err := m.auditMutation(ctx, AuditEvent{Operation: AuditOperationUpdate, Path: filePath, Size: info.Size}); err != nil {
+		return err
+	}
+	_, err = m.minioClient.CopyObject(ctx,
+		minio.CopyDestOptions{
+			Bucket:          m.bucketName,
+			Object:          key,
+			Encryption:      m.serverSideEncryption(ctx),
+			UserMetadata:    userMetadata,
+			ReplaceMetadata: true,
+			ContentType:     info.ContentType,
+			ContentEncoding: info.Metadata.Get(contentEncodingHeader),
+		},
+		minio.CopySrcOptions{Bucket: m.bucketName, Object: key, MatchETag: info.ETag, Encryption: m.copySourceEncryption(ctx)})
+	if err != nil {
+		return newObjectStoreError(err, "Failed to set storage class of file %v", filePath)
+	}
+	return nil
+}
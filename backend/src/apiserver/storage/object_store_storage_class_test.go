@@ -0,0 +1,98 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+)
+
+func TestAddFile_StorageClass(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	minioClient.EXPECT().
+		PutObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any(), gomock.Any(),
+			gomock.Cond(func(opts minio.PutObjectOptions) bool {
+				return opts.StorageClass == StorageClassInfrequentAccess
+			})).
+		Return(int64(4), nil)
+
+	ctx := WithStorageClass(context.TODO(), StorageClassInfrequentAccess)
+	assert.Nil(t, manager.AddFile(ctx, []byte("spec"), manager.GetPipelineKey("1")))
+}
+
+func TestAddFile_DefaultStorageClass(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipeline/1"))
+
+	info, err := minioClient.StatObject(context.TODO(), "", "pipeline/1", minio.StatObjectOptions{})
+	require.Nil(t, err)
+	assert.Empty(t, info.StorageClass)
+}
+
+func TestSetStorageClass(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipeline/1"))
+	before, err := minioClient.StatObject(context.TODO(), "", "pipeline/1", minio.StatObjectOptions{})
+	require.Nil(t, err)
+
+	require.Nil(t, manager.SetStorageClass(context.TODO(), "pipeline/1", StorageClassGlacier))
+
+	after, err := minioClient.StatObject(context.TODO(), "", "pipeline/1", minio.StatObjectOptions{})
+	require.Nil(t, err)
+	assert.Equal(t, StorageClassGlacier, after.StorageClass)
+	assert.Equal(t, before.UserMetadata, after.UserMetadata)
+	assert.Equal(t, before.ContentType, after.ContentType)
+	data, err := manager.GetFile(context.TODO(), "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+}
+
+func TestSetStorageClass_CopyOptions(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	minioClient.EXPECT().
+		StatObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any()).
+		Return(minio.ObjectInfo{Key: "pipelines/1", ETag: "etag", UserMetadata: minio.StringMap{"Kfp-Owner": "a"}}, nil)
+	minioClient.EXPECT().
+		CopyObject(gomock.Any(),
+			minio.CopyDestOptions{
+				Bucket:          "mlpipeline",
+				Object:          "pipelines/1",
+				UserMetadata:    map[string]string{"Kfp-Owner": "a", storageClassHeader: StorageClassInfrequentAccess},
+				ReplaceMetadata: true,
+			},
+			minio.CopySrcOptions{Bucket: "mlpipeline", Object: "pipelines/1", MatchETag: "etag"}).
+		Return(minio.UploadInfo{}, nil)
+
+	assert.Nil(t, manager.SetStorageClass(context.TODO(), "pipelines/1", StorageClassInfrequentAccess))
+}
+
+func TestSetStorageClass_NotFound(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+
+	err := manager.SetStorageClass(context.TODO(), "pipeline/1", StorageClassGlacier)
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
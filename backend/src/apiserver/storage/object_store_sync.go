@@ -0,0 +1,261 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Results of syncing a file, as reported in the sync metrics.
+const (
+	syncResultCopied  = "copied"
+	syncResultSkipped = "skipped"
+	syncResultFailed  = "failed"
+)
+
+var objectStoreSyncFiles = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "object_store_sync_files_total",
+	Help: "The number of files visited by the object store sync worker, by result",
+}, []string{"result"})
+
+var objectStoreSyncLastPass = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "object_store_sync_last_success_timestamp_seconds",
+	Help: "The time the last successful object store sync pass completed",
+})
+
+// fileWalker is implemented by stores able to list their files.
+type fileWalker interface {
+	WalkFiles(ctx context.Context, prefix string, fn func(FileInfo) error) error
+}
+
+// SyncReport describes the outcome of a sync pass.
+type SyncReport struct {
+	Copied  int
+	Skipped int
+	Failed  int
+}
+
+// SyncWorker periodically copies the files missing from, or different in, a mirror store,
+// healing the drift left by failed mirror writes. Files are compared by ETag, and copied as
+// stored, with their encoding and metadata, so the ETags of synced files match. Files only
+// present in the mirror are left alone.
+type SyncWorker struct {
+	src         ObjectStoreInterface
+	dst         ObjectStoreInterface
+	prefix      string
+	interval    time.Duration
+	concurrency int
+	clock       Clock
+
+	mutex  sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewSyncWorker creates a worker syncing the files under prefix of dst with those of src
+// every interval, copying up to concurrency files at once. Both stores must be able to
+// list and copy their files as stored, as MinioObjectStore does.
+func NewSyncWorker(src ObjectStoreInterface, dst ObjectStoreInterface, prefix string, interval time.Duration, concurrency int) *SyncWorker {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return &SyncWorker{
+		src:         src,
+		dst:         dst,
+		prefix:      prefix,
+		interval:    interval,
+		concurrency: concurrency,
+	}
+}
+
+// SetClock sets the clock timing the passes of the worker. A nil clock is the real clock.
+func (w *SyncWorker) SetClock(clock Clock) {
+	w.clock = clock
+}
+
+// Start runs a sync pass every interval in the background, until Stop is called or ctx is
+// cancelled. Starting a started worker is a no-op.
+func (w *SyncWorker) Start(ctx context.Context) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.cancel != nil {
+		return
+	}
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.done = make(chan struct{})
+	go w.run(ctx, w.done)
+}
+
+// Stop stops the worker, waiting for the pass in progress to be abandoned.
+func (w *SyncWorker) Stop() {
+	w.mutex.Lock()
+	cancel, done := w.cancel, w.done
+	w.cancel, w.done = nil, nil
+	w.mutex.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+func (w *SyncWorker) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	ticks, stop := newClockTicker(w.clock, w.interval)
+	defer stop()
+	for {
+		report, err := w.Sync(ctx)
+		if err != nil {
+			glog.Warningf("Failed to sync the object stores: %v", err)
+		} else {
+			glog.Infof("Synced the object stores: %v files copied, %v up to date", report.Copied, report.Skipped)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticks:
+		}
+	}
+}
+
+// Sync runs a single sync pass, copying the files of src missing from dst or whose ETag
+// differs. A failed copy does not stop the pass, the other files are still synced.
+func (w *SyncWorker) Sync(ctx context.Context) (SyncReport, error) {
+	var report SyncReport
+	srcFiles, err := listFiles(ctx, w.src, w.prefix)
+	if err != nil {
+		return report, util.Wrap(err, "Failed to list the files to sync")
+	}
+	dstFiles, err := listFiles(ctx, w.dst, w.prefix)
+	if err != nil {
+		return report, util.Wrap(err, "Failed to list the synced files")
+	}
+
+	var toCopy []string
+	for _, file := range srcFiles {
+		if dstFile, ok := dstFiles[file.Key]; ok && isSameFile(file, dstFile) {
+			report.Skipped++
+			continue
+		}
+		toCopy = append(toCopy, file.Key)
+	}
+	sort.Strings(toCopy)
+	objectStoreSyncFiles.WithLabelValues(syncResultSkipped).Add(float64(report.Skipped))
+
+	errs := make([]error, len(toCopy))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < w.concurrency && worker < len(toCopy); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = w.copyFile(ctx, toCopy[i])
+			}
+		}()
+	}
+	for i := range toCopy {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var firstErr error
+	for i, err := range errs {
+		if err == nil {
+			report.Copied++
+			continue
+		}
+		glog.Warningf("Failed to sync file %v: %v", toCopy[i], err)
+		report.Failed++
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	objectStoreSyncFiles.WithLabelValues(syncResultCopied).Add(float64(report.Copied))
+	objectStoreSyncFiles.WithLabelValues(syncResultFailed).Add(float64(report.Failed))
+	if firstErr != nil {
+		return report, util.Wrapf(firstErr, "Failed to sync %v of %v files", report.Failed, len(toCopy))
+	}
+	objectStoreSyncLastPass.SetToCurrentTime()
+	return report, nil
+}
+
+// copyFile copies the file at filePath from src to dst as stored.
+func (w *SyncWorker) copyFile(ctx context.Context, filePath string) error {
+	src, ok := w.src.(rawFileStore)
+	if !ok {
+		return util.NewInternalServerError(errors.Errorf("store %T cannot copy files", w.src), "Failed to copy file %v", filePath)
+	}
+	dst, ok := w.dst.(rawFileStore)
+	if !ok {
+		return util.NewInternalServerError(errors.Errorf("store %T cannot copy files", w.dst), "Failed to copy file %v", filePath)
+	}
+	file, err := src.getRawFile(ctx, filePath)
+	if err != nil {
+		return err
+	}
+	return dst.putRawFile(ctx, file, filePath, nil)
+}
+
+// listFiles returns the files of store under prefix, by key.
+func listFiles(ctx context.Context, store ObjectStoreInterface, prefix string) (map[string]FileInfo, error) {
+	walker, ok := store.(fileWalker)
+	if !ok {
+		return nil, util.NewInternalServerError(errors.Errorf("store %T cannot list files", store), "Failed to list files")
+	}
+	files := make(map[string]FileInfo)
+	err := walker.WalkFiles(ctx, prefix, func(file FileInfo) error {
+		files[file.Key] = file
+		return nil
+	})
+	return files, err
+}
+
+// isSameFile returns whether the files hold the same content according to their recorded
+// SHA-256 if both have one, and otherwise to their ETags. The ETags of multipart objects,
+// which contain a "-", and of encrypted objects are not content hashes and may change when
+// copied, so only their sizes are compared.
+func isSameFile(src FileInfo, dst FileInfo) bool {
+	if src.Size != dst.Size {
+		return false
+	}
+	if src.ContentSha256 != "" && dst.ContentSha256 != "" {
+		return src.ContentSha256 == dst.ContentSha256
+	}
+	if src.OpaqueETag || dst.OpaqueETag || strings.Contains(src.ETag, "-") || strings.Contains(dst.ETag, "-") {
+		return true
+	}
+	return strings.Trim(src.ETag, `"`) == strings.Trim(dst.ETag, `"`)
+}
+
+// hasOpaqueETag returns whether the object is encrypted with KMS or customer keys, so its
+// ETag is not the MD5 of its content.
+func hasOpaqueETag(info minio.ObjectInfo) bool {
+	return info.Metadata.Get(encrypt.SseCustomerAlgorithm) != "" ||
+		strings.HasPrefix(info.Metadata.Get(encrypt.SseGenericHeader), "aws:kms")
+}
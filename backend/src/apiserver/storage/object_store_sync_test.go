@@ -0,0 +1,167 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSyncTestStores(t *testing.T) (*MinioObjectStore, *MinioObjectStore) {
+	src := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	dst := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	ctx := context.TODO()
+	require.Nil(t, src.AddFile(ctx, []byte("spec 1"), "pipeline/1"))
+	require.Nil(t, src.AddFile(ctx, []byte("spec 2"), "pipeline/2"))
+	require.Nil(t, src.AddFile(ctx, []byte("spec 3"), "pipeline/3"))
+	require.Nil(t, dst.AddFile(ctx, []byte("spec 1"), "pipeline/1"))
+	require.Nil(t, dst.AddFile(ctx, []byte("old spec 3"), "pipeline/3"))
+	require.Nil(t, dst.AddFile(ctx, []byte("spec 4"), "pipeline/4"))
+	return src, dst
+}
+
+func TestSyncWorker_Sync(t *testing.T) {
+	copied := util.GetMetricValue(objectStoreSyncFiles.WithLabelValues(syncResultCopied))
+	skipped := util.GetMetricValue(objectStoreSyncFiles.WithLabelValues(syncResultSkipped))
+	src, dst := newSyncTestStores(t)
+	worker := NewSyncWorker(src, dst, "pipeline/", time.Minute, 2)
+
+	report, err := worker.Sync(context.TODO())
+	require.Nil(t, err)
+	assert.Equal(t, SyncReport{Copied: 2, Skipped: 1}, report)
+	for filePath, expected := range map[string]string{
+		"pipeline/1": "spec 1",
+		"pipeline/2": "spec 2",
+		"pipeline/3": "spec 3",
+		// Files only present in the mirror are kept.
+		"pipeline/4": "spec 4",
+	} {
+		data, err := dst.GetFile(context.TODO(), filePath)
+		require.Nil(t, err)
+		assert.Equal(t, expected, string(data))
+	}
+	assert.Equal(t, copied+2, util.GetMetricValue(objectStoreSyncFiles.WithLabelValues(syncResultCopied)))
+	assert.Equal(t, skipped+1, util.GetMetricValue(objectStoreSyncFiles.WithLabelValues(syncResultSkipped)))
+
+	// Once synced, a pass copies nothing.
+	report, err = worker.Sync(context.TODO())
+	require.Nil(t, err)
+	assert.Equal(t, SyncReport{Skipped: 3}, report)
+}
+
+func TestSyncWorker_SyncKeepsEncodingAndMetadata(t *testing.T) {
+	src := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	dstClient := NewFakeMinioClient()
+	dst := &MinioObjectStore{minioClient: dstClient, baseFolder: "pipeline"}
+	ctx := context.TODO()
+	require.Nil(t, src.AddFileFromReader(ctx, strings.NewReader("spec 1"), "pipeline/1", true))
+	require.Nil(t, src.AddFile(ctx, []byte("spec 2"), "pipeline/2"))
+	worker := NewSyncWorker(src, dst, "pipeline/", time.Minute, 2)
+
+	report, err := worker.Sync(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, SyncReport{Copied: 2}, report)
+	info, err := dst.GetFileInfo(ctx, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, contentEncodingGzip, info.ContentEncoding)
+	data, err := dst.GetFileDecompressed(ctx, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, "spec 1", string(data))
+	stored, err := dstClient.StatObject(ctx, "", "pipeline/2", minio.StatObjectOptions{})
+	require.Nil(t, err)
+	assert.Equal(t, contentSha256([]byte("spec 2")), userMetadataValue(stored, contentSha256Metadata))
+
+	// The copies have the ETags of the originals, so a pass copies nothing.
+	report, err = worker.Sync(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, SyncReport{Skipped: 2}, report)
+}
+
+func TestSyncWorker_SyncScopedToPrefix(t *testing.T) {
+	src, dst := newSyncTestStores(t)
+	require.Nil(t, src.AddFile(context.TODO(), []byte("other"), "other/1"))
+
+	report, err := NewSyncWorker(src, dst, "pipeline/", time.Minute, 2).Sync(context.TODO())
+	require.Nil(t, err)
+	assert.Equal(t, SyncReport{Copied: 2, Skipped: 1}, report)
+	_, err = dst.GetFileInfo(context.TODO(), "other/1")
+	assert.NotNil(t, err)
+}
+
+func TestSyncWorker_SyncListError(t *testing.T) {
+	src, _ := newSyncTestStores(t)
+	dst := &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipeline"}
+
+	_, err := NewSyncWorker(src, dst, "pipeline/", time.Minute, 2).Sync(context.TODO())
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Failed to list the synced files")
+}
+
+func TestSyncWorker_SyncUnlistableStore(t *testing.T) {
+	src, _ := newSyncTestStores(t)
+	dst := NewCachingObjectStore(&MinioObjectStore{minioClient: NewFakeMinioClient()}, 10)
+
+	_, err := NewSyncWorker(src, dst, "pipeline/", time.Minute, 2).Sync(context.TODO())
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "cannot list files")
+}
+
+func TestSyncWorker_StartStop(t *testing.T) {
+	src, dst := newSyncTestStores(t)
+	worker := NewSyncWorker(src, dst, "pipeline/", time.Millisecond, 2)
+	worker.Start(context.TODO())
+	// Starting again is a no-op.
+	worker.Start(context.TODO())
+
+	assert.Eventually(t, func() bool {
+		data, err := dst.GetFile(context.TODO(), "pipeline/2")
+		return err == nil && string(data) == "spec 2"
+	}, 5*time.Second, time.Millisecond)
+	worker.Stop()
+	worker.Stop()
+
+	// No pass runs once stopped.
+	require.Nil(t, src.AddFile(context.TODO(), []byte("spec 5"), "pipeline/5"))
+	time.Sleep(10 * time.Millisecond)
+	_, err := dst.GetFileInfo(context.TODO(), "pipeline/5")
+	assert.NotNil(t, err)
+}
+
+func TestSyncWorker_PassesTimedByClock(t *testing.T) {
+	src, dst := newSyncTestStores(t)
+	clock := NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	worker := NewSyncWorker(src, dst, "pipeline/", time.Hour, 2)
+	worker.SetClock(clock)
+	worker.Start(context.TODO())
+	defer worker.Stop()
+	assert.Eventually(t, func() bool {
+		data, err := dst.GetFile(context.TODO(), "pipeline/2")
+		return err == nil && string(data) == "spec 2"
+	}, 5*time.Second, time.Millisecond)
+
+	require.Nil(t, src.AddFile(context.TODO(), []byte("spec 5"), "pipeline/5"))
+	clock.Advance(time.Hour)
+	assert.Eventually(t, func() bool {
+		data, err := dst.GetFile(context.TODO(), "pipeline/5")
+		return err == nil && string(data) == "spec 5"
+	}, 5*time.Second, time.Millisecond)
+}
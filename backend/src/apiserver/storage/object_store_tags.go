@@ -0,0 +1,95 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sync"
+
+	minio "github.com/minio/minio-go/v7"
+)
+
+// maxConcurrentTagFetches bounds the object tags fetched at once by ListFilesByTag.
+const maxConcurrentTagFetches = 16
+
+// ListFilesByTag returns the files under prefix, in key order, whose object tag named tag
+// has the given value. Tags are not part of listings, so they are fetched for every file,
+// concurrently. Files deleted while listed are skipped.
+func (m *MinioObjectStore) ListFilesByTag(ctx context.Context, prefix string, tag string, value string) ([]string, error) {
+	return m.listFilesMatchingTags(ctx, prefix, func(file FileInfo, tagMap map[string]string) bool {
+		tagValue, ok := tagMap[tag]
+		return ok && tagValue == value
+	})
+}
+
+// listFilesMatchingTags returns the files under prefix, in key order, for which match
+// returns true given the file and its object tags. The tags are fetched concurrently.
+// Files deleted while listed are skipped.
+func (m *MinioObjectStore) listFilesMatchingTags(ctx context.Context, prefix string,
+	match func(file FileInfo, tagMap map[string]string) bool,
+) ([]string, error) {
+	var files []FileInfo
+	err := m.WalkFiles(ctx, prefix, func(file FileInfo) error {
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]bool, len(files))
+	errs := make([]error, len(files))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < maxConcurrentTagFetches && worker < len(files); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				var tagMap map[string]string
+				tagMap, errs[i] = m.fileTags(ctx, files[i].Key)
+				matches[i] = errs[i] == nil && tagMap != nil && match(files[i], tagMap)
+			}
+		}()
+	}
+	for i := range files {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var matching []string
+	for i, file := range files {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if matches[i] {
+			matching = append(matching, file.Key)
+		}
+	}
+	return matching, nil
+}
+
+// fileTags returns the object tags of the file, or nil if it does not exist.
+func (m *MinioObjectStore) fileTags(ctx context.Context, filePath string) (map[string]string, error) {
+	objectTags, err := m.minioClient.GetObjectTagging(ctx, m.bucketName, m.resolveKey(ctx, filePath), minio.GetObjectTaggingOptions{})
+	if err != nil {
+		if ClassifyError(err) == ErrNotFound {
+			return nil, nil
+		}
+		return nil, newObjectStoreError(err, "Failed to get the tags of file %v", filePath)
+	}
+	return objectTags.ToMap(), nil
+}
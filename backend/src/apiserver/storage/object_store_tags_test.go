@@ -0,0 +1,136 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+)
+
+func listedObjects(keys ...string) <-chan minio.ObjectInfo {
+	objectCh := make(chan minio.ObjectInfo, len(keys))
+	for _, key := range keys {
+		objectCh <- minio.ObjectInfo{Key: key}
+	}
+	close(objectCh)
+	return objectCh
+}
+
+func TestListFilesByTag(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	objectTags := map[string]map[string]string{
+		"pipelines/1": {"status": "draft"},
+		"pipelines/2": {"status": "published"},
+		"pipelines/3": {"status": "draft", "owner": "team-a"},
+		"pipelines/4": {},
+		"pipelines/5": {"owner": "draft"},
+	}
+	minioClient.EXPECT().ListObjects(gomock.Any(), "mlpipeline", gomock.Any()).
+		Return(listedObjects("pipelines/1", "pipelines/2", "pipelines/3", "pipelines/4", "pipelines/5"))
+	minioClient.EXPECT().GetObjectTagging(gomock.Any(), "mlpipeline", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, bucketName, objectName string, opts minio.GetObjectTaggingOptions) (*tags.Tags, error) {
+			return tags.MapToObjectTags(objectTags[objectName])
+		}).Times(5)
+
+	files, err := manager.ListFilesByTag(context.TODO(), "pipelines/", "status", "draft")
+	require.Nil(t, err)
+	assert.Equal(t, []string{"pipelines/1", "pipelines/3"}, files)
+}
+
+func TestListFilesByTag_Concurrency(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	var keys []string
+	for i := 0; i < 100; i++ {
+		keys = append(keys, fmt.Sprintf("pipelines/%03d", i))
+	}
+	var inFlight, maxInFlight atomic.Int32
+	minioClient.EXPECT().ListObjects(gomock.Any(), "mlpipeline", gomock.Any()).Return(listedObjects(keys...))
+	minioClient.EXPECT().GetObjectTagging(gomock.Any(), "mlpipeline", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, bucketName, objectName string, opts minio.GetObjectTaggingOptions) (*tags.Tags, error) {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				seen := maxInFlight.Load()
+				if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+					break
+				}
+			}
+			if strings.HasSuffix(objectName, "7") {
+				return tags.MapToObjectTags(map[string]string{"status": "draft"})
+			}
+			return tags.MapToObjectTags(nil)
+		}).Times(100)
+
+	files, err := manager.ListFilesByTag(context.TODO(), "pipelines/", "status", "draft")
+	require.Nil(t, err)
+	assert.Len(t, files, 10)
+	assert.Equal(t, "pipelines/007", files[0])
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(maxConcurrentTagFetches))
+}
+
+func TestListFilesByTag_DeletedWhileListed(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	_, err := minioClient.PutObject(context.TODO(), "mlpipeline", "pipelines/1", strings.NewReader("spec"), 4,
+		minio.PutObjectOptions{UserTags: map[string]string{"status": "draft"}})
+	require.Nil(t, err)
+
+	files, err := manager.ListFilesByTag(context.TODO(), "pipelines/", "status", "draft")
+	require.Nil(t, err)
+	assert.Equal(t, []string{"pipelines/1"}, files)
+
+	wrapped := &deletingTagMinioClient{FakeMinioClient: minioClient}
+	manager = NewMinioObjectStore(wrapped, "mlpipeline", "pipelines", false)
+	files, err = manager.ListFilesByTag(context.TODO(), "pipelines/", "status", "draft")
+	require.Nil(t, err)
+	assert.Empty(t, files)
+}
+
+// deletingTagMinioClient deletes objects right before their tags are fetched.
+type deletingTagMinioClient struct {
+	*FakeMinioClient
+}
+
+func (c *deletingTagMinioClient) GetObjectTagging(ctx context.Context, bucketName, objectName string,
+	opts minio.GetObjectTaggingOptions,
+) (*tags.Tags, error) {
+	c.FakeMinioClient.DeleteObject(ctx, bucketName, objectName)
+	return c.FakeMinioClient.GetObjectTagging(ctx, bucketName, objectName, opts)
+}
+
+func TestListFilesByTagError(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	minioClient.EXPECT().ListObjects(gomock.Any(), "mlpipeline", gomock.Any()).Return(listedObjects("pipelines/1"))
+	minioClient.EXPECT().GetObjectTagging(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any()).
+		Return(nil, errAccessDenied)
+
+	_, err := manager.ListFilesByTag(context.TODO(), "pipelines/", "status", "draft")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+}
@@ -18,12 +18,16 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"net/http"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
@@ -49,6 +53,103 @@ func (c *FakeBadMinioClient) DeleteObject(ctx context.Context, bucketName, objec
 	return errors.New("some error")
 }
 
+func (c *FakeBadMinioClient) StatObject(ctx context.Context, bucketName, objectName string,
+	opts minio.StatObjectOptions,
+) (minio.ObjectInfo, error) {
+	return minio.ObjectInfo{}, errors.New("some error")
+}
+
+func (c *FakeBadMinioClient) CopyObject(ctx context.Context, dst minio.CopyDestOptions,
+	src minio.CopySrcOptions,
+) (minio.UploadInfo, error) {
+	return minio.UploadInfo{}, errors.New("some error")
+}
+
+func (c *FakeBadMinioClient) ListObjects(ctx context.Context, bucketName string,
+	opts minio.ListObjectsOptions,
+) <-chan minio.ObjectInfo {
+	objectCh := make(chan minio.ObjectInfo, 1)
+	objectCh <- minio.ObjectInfo{Err: errors.New("some error")}
+	close(objectCh)
+	return objectCh
+}
+
+func (c *FakeBadMinioClient) GetObjectTagging(ctx context.Context, bucketName, objectName string,
+	opts minio.GetObjectTaggingOptions,
+) (*tags.Tags, error) {
+	return nil, errors.New("some error")
+}
+
+func (c *FakeBadMinioClient) PutObjectTagging(ctx context.Context, bucketName, objectName string,
+	otags *tags.Tags, opts minio.PutObjectTaggingOptions,
+) error {
+	return errors.New("some error")
+}
+
+func (c *FakeBadMinioClient) PutObjectRetention(ctx context.Context, bucketName, objectName string,
+	opts minio.PutObjectRetentionOptions,
+) error {
+	return errors.New("some error")
+}
+
+func (c *FakeBadMinioClient) PutObjectLegalHold(ctx context.Context, bucketName, objectName string,
+	opts minio.PutObjectLegalHoldOptions,
+) error {
+	return errors.New("some error")
+}
+
+func (c *FakeBadMinioClient) PresignedGetObject(ctx context.Context, bucketName, objectName string,
+	expires time.Duration, reqParams url.Values,
+) (*url.URL, error) {
+	return nil, errors.New("some error")
+}
+
+func (c *FakeBadMinioClient) ListIncompleteUploads(ctx context.Context, bucketName, objectPrefix string,
+	recursive bool,
+) <-chan minio.ObjectMultipartInfo {
+	uploadCh := make(chan minio.ObjectMultipartInfo, 1)
+	uploadCh <- minio.ObjectMultipartInfo{Err: errors.New("some error")}
+	close(uploadCh)
+	return uploadCh
+}
+
+func (c *FakeBadMinioClient) RemoveIncompleteUpload(ctx context.Context, bucketName, objectName string) error {
+	return errors.New("some error")
+}
+
+// countingMinioClient counts the calls made to the fake minio client.
+type countingMinioClient struct {
+	*FakeMinioClient
+	putCount  int
+	getCount  int
+	statCount int
+}
+
+func newCountingMinioClient() *countingMinioClient {
+	return &countingMinioClient{FakeMinioClient: NewFakeMinioClient()}
+}
+
+func (c *countingMinioClient) PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader,
+	objectSize int64, opts minio.PutObjectOptions,
+) (int64, error) {
+	c.putCount++
+	return c.FakeMinioClient.PutObject(ctx, bucketName, objectName, reader, objectSize, opts)
+}
+
+func (c *countingMinioClient) GetObject(ctx context.Context, bucketName, objectName string,
+	opts minio.GetObjectOptions,
+) (io.Reader, error) {
+	c.getCount++
+	return c.FakeMinioClient.GetObject(ctx, bucketName, objectName, opts)
+}
+
+func (c *countingMinioClient) StatObject(ctx context.Context, bucketName, objectName string,
+	opts minio.StatObjectOptions,
+) (minio.ObjectInfo, error) {
+	c.statCount++
+	return c.FakeMinioClient.StatObject(ctx, bucketName, objectName, opts)
+}
+
 func TestAddFile(t *testing.T) {
 	minioClient := NewFakeMinioClient()
 	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
@@ -135,3 +236,82 @@ func TestGetFromYamlFile_UnmarshalError(t *testing.T) {
 	assert.Equal(t, codes.Internal, error.(*util.UserError).ExternalStatusCode())
 	assert.Contains(t, error.Error(), "Failed to unmarshal")
 }
+
+func TestGetFromYamlFileWithRaw(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	// Comments, quoting and spacing are lost when marshalling back, but not in the raw bytes.
+	content := []byte("# The spec of pipeline 1.\nid:   \"1\"   # quoted\n")
+	require.Nil(t, manager.AddFile(context.TODO(), content, manager.GetPipelineKey("1")))
+
+	var foo map[string]string
+	raw, err := manager.GetFromYamlFileWithRaw(context.TODO(), manager.GetPipelineKey("1"), &foo)
+
+	require.Nil(t, err)
+	assert.Equal(t, content, raw)
+	assert.Equal(t, map[string]string{"id": "1"}, foo)
+}
+
+func TestGetFromYamlFileWithRaw_Transcoded(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipelines"}
+	latin1 := []byte("id: caf\xe9\n")
+	putCharsetTestObject(t, minioClient, latin1, "application/yaml; charset=ISO-8859-1")
+
+	var foo map[string]string
+	raw, err := manager.GetFromYamlFileWithRaw(WithCharsetTranscoding(context.TODO()), "pipelines/1", &foo)
+
+	require.Nil(t, err)
+	assert.Equal(t, latin1, raw)
+	assert.Equal(t, "café", foo["id"])
+}
+
+func TestGetFromYamlFileWithRaw_Default(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+
+	var foo Foo
+	raw, err := manager.GetFromYamlFileWithRaw(WithYamlDefault(context.TODO(), Foo{ID: 7}), "pipeline/1", &foo)
+
+	require.Nil(t, err)
+	assert.Nil(t, raw)
+	assert.Equal(t, Foo{ID: 7}, foo)
+}
+
+func TestGetFromYamlFileWithRaw_Error(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("invalid"), "pipeline/1"))
+
+	var foo Foo
+	raw, err := manager.GetFromYamlFileWithRaw(context.TODO(), "pipeline/1", &foo)
+	assert.Nil(t, raw)
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+
+	_, err = manager.GetFromYamlFileWithRaw(context.TODO(), "pipeline/missing", &foo)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestClose(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("abc"), manager.GetPipelineKey("1")))
+	require.Nil(t, manager.Close())
+	require.Nil(t, manager.Close())
+
+	err := manager.AddFile(context.TODO(), []byte("abc"), manager.GetPipelineKey("1"))
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+	assert.True(t, errors.Is(err, ErrObjectStoreClosed))
+	assert.Contains(t, err.Error(), "object store closed")
+
+	_, err = manager.GetFile(context.TODO(), manager.GetPipelineKey("1"))
+	assert.True(t, errors.Is(err, ErrObjectStoreClosed))
+	err = manager.DeleteFile(context.TODO(), manager.GetPipelineKey("1"))
+	assert.True(t, errors.Is(err, ErrObjectStoreClosed))
+	var foo Foo
+	err = manager.GetFromYamlFile(context.TODO(), &foo, manager.GetPipelineKey("1"))
+	assert.True(t, errors.Is(err, ErrObjectStoreClosed))
+}
+
+func TestClose_ClosesMinioClient(t *testing.T) {
+	minioClient := &MinioClient{Transport: &http.Transport{}}
+	manager := &MinioObjectStore{minioClient: minioClient}
+	assert.Nil(t, manager.Close())
+}
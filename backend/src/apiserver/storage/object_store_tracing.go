@@ -0,0 +1,176 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/golang/glog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// tracerName is the instrumentation scope of the object store spans.
+	tracerName = "github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	// BaggageTenant is the baggage member carrying the tenant of an operation.
+	BaggageTenant = "kfp.tenant"
+	// BaggageRequestID is the baggage member carrying the ID of the API request an
+	// operation serves.
+	BaggageRequestID = "kfp.request_id"
+)
+
+// TracingObjectStore records a span for every object store operation. The trace baggage of
+// the operation, along with its tenant set with WithTenant, is recorded on the span and
+// propagated to the wrapped store. The requests to the backend only carry the span and
+// the baggage if the client uses a TracePropagatingTransport.
+type TracingObjectStore struct {
+	ObjectStoreInterface
+	tracer trace.Tracer
+}
+
+func NewTracingObjectStore(store ObjectStoreInterface, tracerProvider trace.TracerProvider) *TracingObjectStore {
+	return &TracingObjectStore{
+		ObjectStoreInterface: store,
+		tracer:               tracerProvider.Tracer(tracerName),
+	}
+}
+
+func (s *TracingObjectStore) AddFile(ctx context.Context, file []byte, filePath string) error {
+	ctx, span := s.startSpan(ctx, "AddFile", filePath)
+	defer span.End()
+	span.SetAttributes(attribute.Int("object_store.size", len(file)))
+	return endSpan(span, s.ObjectStoreInterface.AddFile(ctx, file, filePath))
+}
+
+func (s *TracingObjectStore) DeleteFile(ctx context.Context, filePath string) error {
+	ctx, span := s.startSpan(ctx, "DeleteFile", filePath)
+	defer span.End()
+	return endSpan(span, s.ObjectStoreInterface.DeleteFile(ctx, filePath))
+}
+
+func (s *TracingObjectStore) GetFile(ctx context.Context, filePath string) ([]byte, error) {
+	ctx, span := s.startSpan(ctx, "GetFile", filePath)
+	defer span.End()
+	data, err := s.ObjectStoreInterface.GetFile(ctx, filePath)
+	span.SetAttributes(attribute.Int("object_store.size", len(data)))
+	return data, endSpan(span, err)
+}
+
+func (s *TracingObjectStore) AddAsYamlFile(ctx context.Context, o interface{}, filePath string) error {
+	ctx, span := s.startSpan(ctx, "AddAsYamlFile", filePath)
+	defer span.End()
+	return endSpan(span, s.ObjectStoreInterface.AddAsYamlFile(ctx, o, filePath))
+}
+
+func (s *TracingObjectStore) GetFromYamlFile(ctx context.Context, o interface{}, filePath string) error {
+	ctx, span := s.startSpan(ctx, "GetFromYamlFile", filePath)
+	defer span.End()
+	return endSpan(span, s.ObjectStoreInterface.GetFromYamlFile(ctx, o, filePath))
+}
+
+func (s *TracingObjectStore) GetFileInfo(ctx context.Context, filePath string) (*FileInfo, error) {
+	ctx, span := s.startSpan(ctx, "GetFileInfo", filePath)
+	defer span.End()
+	info, err := s.ObjectStoreInterface.GetFileInfo(ctx, filePath)
+	return info, endSpan(span, err)
+}
+
+func (s *TracingObjectStore) MoveFile(ctx context.Context, srcPath string, dstPath string) error {
+	ctx, span := s.startSpan(ctx, "MoveFile", srcPath)
+	defer span.End()
+	span.SetAttributes(attribute.String("object_store.destination_path", dstPath))
+	return endSpan(span, s.ObjectStoreInterface.MoveFile(ctx, srcPath, dstPath))
+}
+
+// startSpan starts the span of an operation on filePath, returning the context to run the
+// operation with, which carries the span and the baggage of the operation.
+func (s *TracingObjectStore) startSpan(ctx context.Context, operation string, filePath string) (context.Context, trace.Span) {
+	ctx = withTenantBaggage(ctx)
+	attributes := []attribute.KeyValue{attribute.String("object_store.file_path", filePath)}
+	for _, member := range baggage.FromContext(ctx).Members() {
+		attributes = append(attributes, attribute.String("baggage."+member.Key(), member.Value()))
+	}
+	return s.tracer.Start(ctx, "ObjectStore."+operation, trace.WithAttributes(attributes...))
+}
+
+// WithRequestID adds the ID of the API request served using ctx to its trace baggage.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return withBaggageMember(ctx, BaggageRequestID, requestID)
+}
+
+// withTenantBaggage adds the tenant of ctx, if any, to its baggage. A tenant already in the
+// baggage is kept.
+func withTenantBaggage(ctx context.Context) context.Context {
+	tenant := tenantFromContext(ctx)
+	if tenant == "" || baggage.FromContext(ctx).Member(BaggageTenant).Key() != "" {
+		return ctx
+	}
+	return withBaggageMember(ctx, BaggageTenant, tenant)
+}
+
+// withBaggageMember sets a member of the baggage of ctx.
+func withBaggageMember(ctx context.Context, key string, value string) context.Context {
+	member, err := baggage.NewMemberRaw(key, value)
+	if err != nil {
+		glog.Warningf("Failed to add %v %q to the trace baggage: %v", key, value, err)
+		return ctx
+	}
+	bag, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		glog.Warningf("Failed to add %v %q to the trace baggage: %v", key, value, err)
+		return ctx
+	}
+	return baggage.ContextWithBaggage(ctx, bag)
+}
+
+// endSpan records err, if any, on span and returns it.
+func endSpan(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// TracePropagatingTransport sends the span and the baggage of the context of each request
+// with the request, in the W3C traceparent and baggage headers, so the object store and
+// the proxies in front of it can correlate the requests with the operations they serve.
+type TracePropagatingTransport struct {
+	Base       http.RoundTripper
+	propagator propagation.TextMapPropagator
+}
+
+// NewTracePropagatingTransport wraps base, http.DefaultTransport if nil.
+func NewTracePropagatingTransport(base http.RoundTripper) *TracePropagatingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &TracePropagatingTransport{
+		Base:       base,
+		propagator: propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+	}
+}
+
+func (t *TracePropagatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A round tripper must not modify the request it is given.
+	req = req.Clone(req.Context())
+	t.propagator.Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+	return t.Base.RoundTrip(req)
+}
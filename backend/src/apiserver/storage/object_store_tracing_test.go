@@ -0,0 +1,163 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// baggageRecordingObjectStore records the baggage of the contexts it is called with.
+type baggageRecordingObjectStore struct {
+	ObjectStoreInterface
+	baggage baggage.Baggage
+	span    trace.SpanContext
+}
+
+func (s *baggageRecordingObjectStore) GetFile(ctx context.Context, filePath string) ([]byte, error) {
+	s.baggage = baggage.FromContext(ctx)
+	s.span = trace.SpanContextFromContext(ctx)
+	return s.ObjectStoreInterface.GetFile(ctx, filePath)
+}
+
+func newTracingTestStore(t *testing.T) (*TracingObjectStore, *baggageRecordingObjectStore, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	inner := &baggageRecordingObjectStore{
+		ObjectStoreInterface: &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"},
+	}
+	require.Nil(t, inner.AddFile(context.TODO(), []byte("spec"), "pipeline/1"))
+	return NewTracingObjectStore(inner, provider), inner, recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]string {
+	attributes := make(map[attribute.Key]string)
+	for _, kv := range span.Attributes() {
+		attributes[kv.Key] = kv.Value.Emit()
+	}
+	return attributes
+}
+
+func TestTracingObjectStore_Baggage(t *testing.T) {
+	store, inner, recorder := newTracingTestStore(t)
+	ctx := WithRequestID(context.TODO(), "request-1")
+	ctx = WithTenant(ctx, "team-a")
+
+	data, err := store.GetFile(ctx, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "ObjectStore.GetFile", spans[0].Name())
+	attributes := spanAttributes(spans[0])
+	assert.Equal(t, "pipeline/1", attributes["object_store.file_path"])
+	assert.Equal(t, "request-1", attributes["baggage."+BaggageRequestID])
+	assert.Equal(t, "team-a", attributes["baggage."+BaggageTenant])
+
+	// The wrapped store runs within the span, with the baggage.
+	assert.Equal(t, "request-1", inner.baggage.Member(BaggageRequestID).Value())
+	assert.Equal(t, "team-a", inner.baggage.Member(BaggageTenant).Value())
+	assert.Equal(t, spans[0].SpanContext().SpanID(), inner.span.SpanID())
+}
+
+func TestTracingObjectStore_BaggageTenantKept(t *testing.T) {
+	store, inner, _ := newTracingTestStore(t)
+	ctx := withBaggageMember(context.TODO(), BaggageTenant, "team-b")
+	ctx = WithTenant(ctx, "team-a")
+
+	_, err := store.GetFile(ctx, "pipeline/1")
+	require.Nil(t, err)
+	assert.Equal(t, "team-b", inner.baggage.Member(BaggageTenant).Value())
+}
+
+func TestTracingObjectStore_Error(t *testing.T) {
+	store, _, recorder := newTracingTestStore(t)
+
+	_, err := store.GetFile(context.TODO(), "pipeline/missing")
+	require.NotNil(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.NotContains(t, spanAttributes(spans[0]), attribute.Key("baggage."+BaggageTenant))
+}
+
+func TestTracingObjectStore_Operations(t *testing.T) {
+	store, _, recorder := newTracingTestStore(t)
+	ctx := context.TODO()
+
+	require.Nil(t, store.AddFile(ctx, []byte("spec"), "pipeline/2"))
+	_, err := store.GetFileInfo(ctx, "pipeline/2")
+	require.Nil(t, err)
+	require.Nil(t, store.MoveFile(ctx, "pipeline/2", "pipeline/3"))
+	require.Nil(t, store.DeleteFile(ctx, "pipeline/3"))
+
+	var names []string
+	for _, span := range recorder.Ended() {
+		names = append(names, span.Name())
+	}
+	assert.Equal(t, []string{"ObjectStore.AddFile", "ObjectStore.GetFileInfo", "ObjectStore.MoveFile", "ObjectStore.DeleteFile"}, names)
+}
+
+func TestTracePropagatingTransport(t *testing.T) {
+	var mutex sync.Mutex
+	headers := make(http.Header)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		headers = r.Header.Clone()
+		mutex.Unlock()
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	client, err := minio.New(strings.TrimPrefix(server.URL, "http://"), &minio.Options{
+		Creds:     credentials.NewStaticV4("minio", "minio123", ""),
+		Region:    "us-east-1",
+		Transport: NewTracePropagatingTransport(nil),
+	})
+	require.Nil(t, err)
+	recorder := tracetest.NewSpanRecorder()
+	store := NewTracingObjectStore(
+		NewMinioObjectStoreWithOptions(&MinioClient{Client: client}, "mlpipeline", "pipelines"),
+		sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	ctx := WithTenant(WithRequestID(context.TODO(), "request-1"), "team-a")
+
+	store.GetFileInfo(ctx, "pipelines/1")
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	bag, err := baggage.Parse(headers.Get("baggage"))
+	require.Nil(t, err)
+	assert.Equal(t, "request-1", bag.Member(BaggageRequestID).Value())
+	assert.Equal(t, "team-a", bag.Member(BaggageTenant).Value())
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Contains(t, headers.Get("traceparent"), spans[0].SpanContext().TraceID().String())
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+// maxURLImportRedirects bounds the redirects followed while downloading an import.
+const maxURLImportRedirects = 5
+
+// errImportTooLarge is the cause of imports rejected for exceeding the size limit.
+var errImportTooLarge = errors.New("import exceeds the size limit")
+
+// URLImportPolicy restricts the URLs specs can be imported from. Only URLs with one of the
+// allowed schemes, pointing at one of the allowed hosts, are downloaded, so the zero value
+// allows no imports at all.
+type URLImportPolicy struct {
+	// AllowedSchemes lists the URL schemes imports may use, e.g. "https".
+	AllowedSchemes []string
+	// AllowedHosts lists the host names imports may be downloaded from, without ports.
+	AllowedHosts []string
+	// MaxBytes limits the size of an import. Zero or less means no limit.
+	MaxBytes int64
+	// Client downloads the imports. Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// SetURLImportPolicy sets the policy ImportFromURL enforces.
+func (m *MinioObjectStore) SetURLImportPolicy(policy URLImportPolicy) {
+	m.urlImportPolicy = policy
+}
+
+// ImportFromURL downloads the spec at sourceURL and stores it at filePath. The download is
+// streamed to the backing store like AddFileFromReader, without being buffered in full
+// unless YAML validation applies, and goes through the same checks as AddFile. The URL,
+// and every URL it redirects to, must be allowed by the import policy.
+func (m *MinioObjectStore) ImportFromURL(ctx context.Context, sourceURL string, filePath string) error {
+	if err := m.checkOpen("import file", filePath); err != nil {
+		return err
+	}
+	if err := m.checkMaintenance("import file", filePath); err != nil {
+		return err
+	}
+	if err := m.checkEnvironment(filePath, true); err != nil {
+		return err
+	}
+	key := m.resolveKey(ctx, filePath)
+	if err := m.checkKeyLength("import file", filePath, key); err != nil {
+		return err
+	}
+	policy := m.urlImportPolicy
+	parsedURL, err := url.Parse(sourceURL)
+	if err != nil {
+		return util.NewInvalidInputError("Invalid import URL %q: %v", sourceURL, err.Error())
+	}
+	if err := policy.checkURL(parsedURL); err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedURL.String(), nil)
+	if err != nil {
+		return util.NewInvalidInputError("Invalid import URL %q: %v", sourceURL, err.Error())
+	}
+	response, err := policy.client().Do(request)
+	if err != nil {
+		var userErr *util.UserError
+		if errors.As(err, &userErr) {
+			return userErr
+		}
+		return util.NewUnavailableServerError(err, "Failed to download %v", sourceURL)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return util.NewInvalidInputError("Failed to download %v: %v", sourceURL, response.Status)
+	}
+	if policy.MaxBytes > 0 && response.ContentLength > policy.MaxBytes {
+		return util.NewInvalidInputError("Failed to import %v: size %v exceeds the limit of %v bytes",
+			sourceURL, response.ContentLength, policy.MaxBytes)
+	}
+
+	var body io.Reader = response.Body
+	var limited *limitedReader
+	if policy.MaxBytes > 0 {
+		limited = &limitedReader{reader: response.Body, remaining: policy.MaxBytes}
+		body = limited
+	}
+	err = m.WithKeyLock(ctx, filePath, func(ctx context.Context) error {
+		return m.addFileFromReader(ctx, body, response.ContentLength, filePath, false)
+	})
+	if limited != nil && limited.remaining < 0 {
+		return util.NewInvalidInputError("Failed to import %v: exceeds the limit of %v bytes", sourceURL, policy.MaxBytes)
+	}
+	return err
+}
+
+// checkURL returns an error unless the policy allows downloading from u.
+func (p URLImportPolicy) checkURL(u *url.URL) error {
+	if !containsFold(p.AllowedSchemes, u.Scheme) {
+		return util.NewInvalidInputError("Import URL scheme %q is not allowed", u.Scheme)
+	}
+	if !containsFold(p.AllowedHosts, u.Hostname()) {
+		return util.NewInvalidInputError("Import URL host %q is not allowed", u.Hostname())
+	}
+	return nil
+}
+
+// client returns a copy of the policy's client that only follows allowed redirects.
+func (p URLImportPolicy) client() *http.Client {
+	client := http.Client{}
+	if p.Client != nil {
+		client = *p.Client
+	}
+	client.CheckRedirect = func(request *http.Request, via []*http.Request) error {
+		if len(via) >= maxURLImportRedirects {
+			return util.NewInvalidInputError("Import stopped after %v redirects", maxURLImportRedirects)
+		}
+		return p.checkURL(request.URL)
+	}
+	return &client
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// limitedReader fails with errImportTooLarge once more than remaining bytes are read.
+type limitedReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	if r.remaining < 0 {
+		return 0, errImportTooLarge
+	}
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return n, errImportTooLarge
+	}
+	return n, err
+}
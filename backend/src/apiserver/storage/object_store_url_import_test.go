@@ -0,0 +1,155 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+const urlImportSpec = "pipelineInfo:\n  name: imported\n"
+
+func newURLImportServer(t *testing.T, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/spec.yaml":
+			w.Write([]byte(body))
+		case "/chunked.yaml":
+			// Flushing before writing the body hides its length.
+			w.(http.Flusher).Flush()
+			w.Write([]byte(body))
+		case "/redirect":
+			http.Redirect(w, r, "http://example.com/spec.yaml", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newURLImportStore(maxBytes int64) (*MinioObjectStore, *FakeMinioClient) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	manager.SetURLImportPolicy(URLImportPolicy{
+		AllowedSchemes: []string{"http"},
+		AllowedHosts:   []string{"127.0.0.1"},
+		MaxBytes:       maxBytes,
+	})
+	return manager, minioClient
+}
+
+func TestImportFromURL(t *testing.T) {
+	server := newURLImportServer(t, urlImportSpec)
+	manager, minioClient := newURLImportStore(1024)
+
+	require.Nil(t, manager.ImportFromURL(context.TODO(), server.URL+"/spec.yaml", "pipeline/1"))
+	assert.Equal(t, []byte(urlImportSpec), minioClient.minioClient["pipeline/1"])
+}
+
+func TestImportFromURL_OverLimit(t *testing.T) {
+	server := newURLImportServer(t, strings.Repeat("a", 100))
+	for _, path := range []string{"/spec.yaml", "/chunked.yaml"} {
+		manager, minioClient := newURLImportStore(99)
+
+		err := manager.ImportFromURL(context.TODO(), server.URL+path, "pipeline/1")
+		require.NotNil(t, err, path)
+		assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode(), path)
+		assert.Contains(t, err.Error(), "limit of 99 bytes", path)
+		assert.False(t, minioClient.ExistObject("pipeline/1"), path)
+	}
+}
+
+func TestImportFromURL_AtLimit(t *testing.T) {
+	server := newURLImportServer(t, strings.Repeat("a", 100))
+	manager, minioClient := newURLImportStore(100)
+
+	require.Nil(t, manager.ImportFromURL(context.TODO(), server.URL+"/chunked.yaml", "pipeline/1"))
+	assert.Len(t, minioClient.minioClient["pipeline/1"], 100)
+}
+
+func TestImportFromURL_BlockedHost(t *testing.T) {
+	server := newURLImportServer(t, urlImportSpec)
+	manager, minioClient := newURLImportStore(1024)
+	manager.urlImportPolicy.AllowedHosts = []string{"example.com"}
+
+	err := manager.ImportFromURL(context.TODO(), server.URL+"/spec.yaml", "pipeline/1")
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), `host "127.0.0.1" is not allowed`)
+	assert.False(t, minioClient.ExistObject("pipeline/1"))
+}
+
+func TestImportFromURL_BlockedRedirect(t *testing.T) {
+	server := newURLImportServer(t, urlImportSpec)
+	manager, minioClient := newURLImportStore(1024)
+
+	err := manager.ImportFromURL(context.TODO(), server.URL+"/redirect", "pipeline/1")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.Error(), `host "example.com" is not allowed`)
+	assert.False(t, minioClient.ExistObject("pipeline/1"))
+}
+
+func TestImportFromURL_BlockedScheme(t *testing.T) {
+	manager, _ := newURLImportStore(1024)
+
+	err := manager.ImportFromURL(context.TODO(), "file:///etc/passwd", "pipeline/1")
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), `scheme "file" is not allowed`)
+}
+
+func TestImportFromURL_NoPolicy(t *testing.T) {
+	server := newURLImportServer(t, urlImportSpec)
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+
+	assert.NotNil(t, manager.ImportFromURL(context.TODO(), server.URL+"/spec.yaml", "pipeline/1"))
+}
+
+func TestImportFromURL_InvalidYaml(t *testing.T) {
+	server := newURLImportServer(t, "name: [unclosed")
+	manager, minioClient := newURLImportStore(1024)
+	manager.SetYamlValidation(true)
+
+	err := manager.ImportFromURL(context.TODO(), server.URL+"/chunked.yaml", "pipeline/1")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+	assert.ErrorIs(t, err, ErrInvalidYAML)
+	assert.False(t, minioClient.ExistObject("pipeline/1"))
+}
+
+func TestImportFromURL_Quota(t *testing.T) {
+	server := newURLImportServer(t, urlImportSpec)
+	manager, minioClient := newURLImportStore(1024)
+	checker := NewInMemoryQuotaChecker(int64(len(urlImportSpec)) - 1)
+	manager.SetQuotaChecker(checker)
+
+	err := manager.ImportFromURL(WithTenant(context.TODO(), "team-a"), server.URL+"/spec.yaml", "pipeline/1")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.ResourceExhausted, err.(*util.UserError).ExternalStatusCode())
+	assert.False(t, minioClient.ExistObject("pipeline/1"))
+
+	checker = NewInMemoryQuotaChecker(int64(len(urlImportSpec)))
+	manager.SetQuotaChecker(checker)
+	require.Nil(t, manager.ImportFromURL(WithTenant(context.TODO(), "team-a"), server.URL+"/spec.yaml", "pipeline/1"))
+	assert.Equal(t, int64(len(urlImportSpec)), checker.Usage("team-a"))
+}
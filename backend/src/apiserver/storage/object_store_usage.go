@@ -0,0 +1,50 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"strings"
+)
+
+// ComputeUsage returns the number of objects stored under prefix and their total size in
+// bytes. The listing is consumed as it is streamed, so memory use does not grow with the
+// number of objects.
+func (m *MinioObjectStore) ComputeUsage(ctx context.Context, prefix string) (objectCount int64, totalBytes int64, err error) {
+	err = m.WalkFiles(ctx, prefix, func(info FileInfo) error {
+		objectCount++
+		totalBytes += info.Size
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return objectCount, totalBytes, nil
+}
+
+// resolvePrefix is resolveKey for key prefixes, keeping the trailing "/" that limits the
+// prefix to a folder. Prefixes are not sharded, so they match the files of their shards.
+// Nor are they transformed: listings list under transformPrefix of the prefix, and map the
+// keys listed back with logicalKey.
+func (m *MinioObjectStore) resolvePrefix(ctx context.Context, prefix string) string {
+	if prefix == "" {
+		return prefix
+	}
+	resolved := m.namespaceKey(keyNamespaceFromContext(ctx), m.rewritePrefix(m.normalizeKey(prefix)))
+	if strings.HasSuffix(prefix, "/") && !strings.HasSuffix(resolved, "/") {
+		resolved += "/"
+	}
+	return resolved
+}
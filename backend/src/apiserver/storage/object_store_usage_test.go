@@ -0,0 +1,79 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestComputeUsage(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+	ctx := context.TODO()
+	require.Nil(t, manager.AddFile(ctx, []byte("a"), "pipelines/1"))
+	require.Nil(t, manager.AddFile(ctx, []byte("bb"), "pipelines/2/spec"))
+	require.Nil(t, manager.AddFile(ctx, []byte("cccc"), "pipelines/2/readme"))
+	require.Nil(t, manager.AddFile(ctx, []byte("ddddddddd"), "pipelinesX/1"))
+	require.Nil(t, manager.AddFile(ctx, []byte("eeeeeeeeeeeeeeee"), "artifacts/1"))
+
+	count, size, err := manager.ComputeUsage(ctx, "pipelines/")
+	require.Nil(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.Equal(t, int64(7), size)
+
+	count, size, err = manager.ComputeUsage(ctx, "pipelines/2/")
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Equal(t, int64(6), size)
+
+	count, size, err = manager.ComputeUsage(ctx, "")
+	require.Nil(t, err)
+	assert.Equal(t, int64(5), count)
+	assert.Equal(t, int64(32), size)
+}
+
+func TestComputeUsage_Empty(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+	count, size, err := manager.ComputeUsage(context.TODO(), "pipelines/")
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+	assert.Equal(t, int64(0), size)
+}
+
+func TestComputeUsage_Namespaced(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+	manager.SetKeyNamespacer(DefaultKeyNamespacer)
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("abc"), "pipelines/1"))
+	artifactCtx := WithKeyNamespace(context.TODO(), KeyNamespaceArtifact)
+	require.Nil(t, manager.AddFile(artifactCtx, []byte("abcdef"), "pipelines/1"))
+
+	count, size, err := manager.ComputeUsage(artifactCtx, "pipelines/")
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, int64(6), size)
+}
+
+func TestComputeUsage_ListError(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: &FakeBadMinioClient{}, baseFolder: "pipelines"}
+	_, _, err := manager.ComputeUsage(context.TODO(), "pipelines/")
+	require.NotNil(t, err)
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.Error(), "Failed to list files under pipelines/")
+}
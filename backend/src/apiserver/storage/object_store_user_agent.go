@@ -0,0 +1,53 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"strings"
+)
+
+// userAgentComponent names the apiserver in the default user agent.
+const userAgentComponent = "kfp-apiserver"
+
+// unknownUserAgentVersion is the version of user agents set without one.
+const unknownUserAgentVersion = "unknown"
+
+// DefaultUserAgent returns the user agent of the apiserver at the given KFP version.
+func DefaultUserAgent(version string) string {
+	return userAgentComponent + "/" + version
+}
+
+// userAgentSetter is implemented by minio clients whose user agent can be extended.
+type userAgentSetter interface {
+	SetUserAgent(name string, version string)
+}
+
+// SetUserAgent attributes the requests of the store to a KFP component in the access logs
+// and rate limits of the backend. userAgent is "<component>/<version>", e.g.
+// "kfp-apiserver/2.5.0", and is appended to the user agent of the minio client. The
+// version is "unknown" if left out. It is a no-op for clients whose user agent cannot be
+// set, and applies to every store sharing the client.
+func (m *MinioObjectStore) SetUserAgent(userAgent string) {
+	m.userAgent = userAgent
+	setter, ok := m.minioClient.(userAgentSetter)
+	if !ok || userAgent == "" {
+		return
+	}
+	name, version, _ := strings.Cut(userAgent, "/")
+	if version == "" {
+		version = unknownUserAgentVersion
+	}
+	setter.SetUserAgent(name, version)
+}
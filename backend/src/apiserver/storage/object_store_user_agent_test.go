@@ -0,0 +1,86 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statUserAgent stats a file with a store on a real minio client created with opts, and
+// returns the user agent the backend received.
+func statUserAgent(t *testing.T, opts ...MinioObjectStoreOption) string {
+	var mutex sync.Mutex
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		userAgent = r.Header.Get("User-Agent")
+		mutex.Unlock()
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	client, err := minio.New(strings.TrimPrefix(server.URL, "http://"), &minio.Options{
+		Creds:  credentials.NewStaticV4("minio", "minio123", ""),
+		Region: "us-east-1",
+	})
+	require.Nil(t, err)
+	manager := NewMinioObjectStoreWithOptions(&MinioClient{Client: client}, "mlpipeline", "pipelines", opts...)
+
+	manager.GetFileInfo(context.TODO(), "pipelines/1")
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	return userAgent
+}
+
+func TestUserAgent(t *testing.T) {
+	userAgent := statUserAgent(t, WithUserAgent("kfp-persistence-agent/2.5.0"))
+
+	assert.True(t, strings.HasPrefix(userAgent, "MinIO ("), userAgent)
+	assert.True(t, strings.HasSuffix(userAgent, " kfp-persistence-agent/2.5.0"), userAgent)
+}
+
+func TestUserAgent_Default(t *testing.T) {
+	userAgent := statUserAgent(t, WithUserAgent(DefaultUserAgent("2.5.0")))
+
+	assert.True(t, strings.HasSuffix(userAgent, " kfp-apiserver/2.5.0"), userAgent)
+}
+
+func TestUserAgent_WithoutVersion(t *testing.T) {
+	userAgent := statUserAgent(t, WithUserAgent("kfp-apiserver"))
+
+	assert.True(t, strings.HasSuffix(userAgent, " kfp-apiserver/unknown"), userAgent)
+}
+
+func TestUserAgent_Unset(t *testing.T) {
+	userAgent := statUserAgent(t)
+
+	assert.NotContains(t, userAgent, "kfp")
+}
+
+func TestUserAgent_Config(t *testing.T) {
+	manager := NewMinioObjectStoreWithOptions(NewFakeMinioClient(), "mlpipeline", "pipelines", WithUserAgent("kfp-apiserver/2.5.0"))
+
+	assert.Equal(t, "kfp-apiserver/2.5.0", manager.Config().UserAgent)
+}
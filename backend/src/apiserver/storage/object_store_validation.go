@@ -0,0 +1,55 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// Causes of the errors returned for content rejected by the YAML validation.
+var (
+	ErrInvalidUTF8 = errors.New("content is not valid UTF-8")
+	ErrInvalidYAML = errors.New("content is not valid YAML")
+)
+
+// SetYamlValidation enables validating pipeline specs before they are written. When
+// enabled, writes in the spec namespace, including AddAsYamlFile, are rejected with an
+// InvalidInput error unless the content is valid UTF-8 and parses as YAML.
+func (m *MinioObjectStore) SetYamlValidation(enabled bool) {
+	m.validateYaml = enabled
+}
+
+// checkYamlContent validates the content of a write if YAML validation applies to it.
+func (m *MinioObjectStore) checkYamlContent(ctx context.Context, content []byte, filePath string) error {
+	if !m.validateYaml || keyNamespaceFromContext(ctx) != KeyNamespaceSpec {
+		return nil
+	}
+	if !utf8.Valid(content) {
+		return util.NewInvalidInputErrorWithDetails(ErrInvalidUTF8,
+			fmt.Sprintf("Failed to store file %v: %v", filePath, ErrInvalidUTF8))
+	}
+	var spec interface{}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		return util.NewInvalidInputErrorWithDetails(errors.Wrap(ErrInvalidYAML, err.Error()),
+			fmt.Sprintf("Failed to store file %v: %v", filePath, ErrInvalidYAML))
+	}
+	return nil
+}
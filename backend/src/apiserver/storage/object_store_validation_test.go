@@ -0,0 +1,77 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func newValidatingStore() (*MinioObjectStore, *FakeMinioClient) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	manager.SetYamlValidation(true)
+	return manager, minioClient
+}
+
+func TestYamlValidation_ValidYaml(t *testing.T) {
+	manager, minioClient := newValidatingStore()
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("pipelineInfo:\n  name: ünïcode\n"), manager.GetPipelineKey("1")))
+	require.Nil(t, manager.AddAsYamlFile(context.TODO(), map[string]string{"name": "p"}, manager.GetPipelineKey("2")))
+	assert.Equal(t, 2, minioClient.GetObjectCount())
+}
+
+func TestYamlValidation_InvalidUTF8(t *testing.T) {
+	manager, minioClient := newValidatingStore()
+
+	err := manager.AddFile(context.TODO(), []byte{'a', ':', ' ', 0xff, 0xfe}, manager.GetPipelineKey("1"))
+	require.NotNil(t, err)
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+	assert.True(t, errors.Is(err, ErrInvalidUTF8))
+	assert.Equal(t, 0, minioClient.GetObjectCount())
+}
+
+func TestYamlValidation_InvalidYaml(t *testing.T) {
+	manager, minioClient := newValidatingStore()
+
+	err := manager.AddFile(context.TODO(), []byte("a: [b\n\tc: d"), manager.GetPipelineKey("1"))
+	require.NotNil(t, err)
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+	assert.True(t, errors.Is(err, ErrInvalidYAML))
+	assert.Equal(t, 0, minioClient.GetObjectCount())
+}
+
+func TestYamlValidation_OnlyAppliesToSpecs(t *testing.T) {
+	manager, minioClient := newValidatingStore()
+
+	ctx := WithKeyNamespace(context.TODO(), KeyNamespaceArtifact)
+	require.Nil(t, manager.AddFile(ctx, []byte{0xff, 0xfe}, "artifacts/1"))
+	assert.Equal(t, 1, minioClient.GetObjectCount())
+}
+
+func TestYamlValidation_DisabledByDefault(t *testing.T) {
+	minioClient := NewFakeMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+
+	require.Nil(t, manager.AddFile(context.TODO(), []byte{0xff, 0xfe}, manager.GetPipelineKey("1")))
+	assert.Equal(t, 1, minioClient.GetObjectCount())
+}
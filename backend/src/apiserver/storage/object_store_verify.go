@@ -0,0 +1,46 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// ErrSizeMismatch is the cause of errors returned for uploads stored with fewer or more
+// bytes than were written.
+var ErrSizeMismatch = errors.New("stored object size does not match the uploaded content")
+
+// SetUploadVerification enables checking the size of every object stored by AddFile
+// against the content written, to catch uploads truncated by the client. This costs a stat
+// per write, which is far cheaper than reading the object back.
+func (m *MinioObjectStore) SetUploadVerification(enabled bool) {
+	m.verifyUploads = enabled
+}
+
+// verifyUpload checks that the object stored at key holds size bytes.
+func (m *MinioObjectStore) verifyUpload(ctx context.Context, key string, filePath string, size int) error {
+	info, err := m.minioClient.StatObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+	if err != nil {
+		return newObjectStoreError(err, "Failed to verify file %v", filePath)
+	}
+	if info.Size != int64(size) {
+		return newObjectStoreError(
+			errors.Wrapf(ErrSizeMismatch, "stored %d bytes, uploaded %d bytes", info.Size, size),
+			"Failed to verify file %v", filePath)
+	}
+	return nil
+}
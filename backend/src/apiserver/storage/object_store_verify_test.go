@@ -0,0 +1,74 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+)
+
+func expectUpload(minioClient *MockMinioClient, storedSize int64) {
+	minioClient.EXPECT().
+		PutObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (int64, error) {
+			data, err := io.ReadAll(reader)
+			return int64(len(data)), err
+		})
+	minioClient.EXPECT().
+		StatObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any()).
+		Return(minio.ObjectInfo{Key: "pipelines/1", Size: storedSize}, nil)
+}
+
+func TestAddFile_VerifyUpload(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	manager.SetUploadVerification(true)
+	expectUpload(minioClient, int64(len("spec")))
+
+	assert.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), manager.GetPipelineKey("1")))
+}
+
+func TestAddFile_VerifyUpload_Truncated(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	manager.SetUploadVerification(true)
+	expectUpload(minioClient, int64(len("spec")-1))
+
+	err := manager.AddFile(context.TODO(), []byte("spec"), manager.GetPipelineKey("1"))
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrSizeMismatch))
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.Error(), "stored 3 bytes, uploaded 4 bytes")
+}
+
+func TestAddFile_VerifyUpload_Disabled(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStore(minioClient, "mlpipeline", "pipelines", false)
+	minioClient.EXPECT().
+		PutObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(int64(4), nil)
+
+	// No StatObject call is expected.
+	assert.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), manager.GetPipelineKey("1")))
+}
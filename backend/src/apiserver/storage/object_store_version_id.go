@@ -0,0 +1,70 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	minio "github.com/minio/minio-go/v7"
+)
+
+// versionIDMetadata is the user metadata recording the logical version ID of a file.
+const versionIDMetadata = "Kfp-Version-Id"
+
+type versionIDContextKey struct{}
+
+// WithVersionID records versionID as the logical version ID of the files written using ctx.
+func WithVersionID(ctx context.Context, versionID string) context.Context {
+	return context.WithValue(ctx, versionIDContextKey{}, versionID)
+}
+
+func versionIDFromContext(ctx context.Context) string {
+	versionID, _ := ctx.Value(versionIDContextKey{}).(string)
+	return versionID
+}
+
+// GetFileWithVersion returns the content of the file together with the logical version ID
+// it was written with, read from the response to the read rather than a separate stat.
+// Files written without a version ID return an empty one.
+func (m *MinioObjectStore) GetFileWithVersion(ctx context.Context, filePath string) ([]byte, string, error) {
+	if err := m.checkOpen("get file", filePath); err != nil {
+		return nil, "", err
+	}
+	if err := m.checkEnvironment(filePath, false); err != nil {
+		return nil, "", err
+	}
+	key := m.resolveKey(ctx, filePath)
+	reader, err := m.minioClient.GetObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+	if err != nil {
+		m.recordWarningEvent(ctx, eventReasonReadFailed, "Failed to get file %v: %v", filePath, err)
+		return nil, "", newObjectStoreError(err, "Failed to get file %v", filePath)
+	}
+	defer closeReader(reader)
+
+	var info minio.ObjectInfo
+	if object, ok := reader.(objectStater); ok {
+		info, err = object.Stat()
+	} else {
+		info, err = m.minioClient.StatObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+	}
+	if err != nil {
+		return nil, "", newObjectStoreError(err, "Failed to get file %v", filePath)
+	}
+	data, err := readObject(reader)
+	if err != nil {
+		return nil, "", newObjectStoreError(err, "Failed to read file %v", filePath)
+	}
+	return m.removeChunkSignatures(data), userMetadataValue(info, versionIDMetadata), nil
+}
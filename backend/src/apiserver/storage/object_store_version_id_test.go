@@ -0,0 +1,59 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestGetFileWithVersion(t *testing.T) {
+	minioClient := newCountingMinioClient()
+	manager := &MinioObjectStore{minioClient: minioClient, baseFolder: "pipeline"}
+	require.Nil(t, manager.AddFile(WithVersionID(context.TODO(), "v2"), []byte("spec"), manager.GetPipelineKey("1")))
+
+	data, versionID, err := manager.GetFileWithVersion(context.TODO(), manager.GetPipelineKey("1"))
+
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+	assert.Equal(t, "v2", versionID)
+	// The version ID comes with the read.
+	assert.Equal(t, 0, minioClient.statCount)
+}
+
+func TestGetFileWithVersion_NoVersionMetadata(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), manager.GetPipelineKey("1")))
+
+	data, versionID, err := manager.GetFileWithVersion(context.TODO(), manager.GetPipelineKey("1"))
+
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+	assert.Equal(t, "", versionID)
+}
+
+func TestGetFileWithVersion_NotFound(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipeline"}
+
+	_, _, err := manager.GetFileWithVersion(context.TODO(), manager.GetPipelineKey("1"))
+
+	require.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
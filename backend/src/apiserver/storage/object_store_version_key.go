@@ -0,0 +1,47 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"net/url"
+	"path"
+)
+
+// VersionKeyBuilder maps a pipeline version to the path of its spec, under the base folder.
+// Distinct versions must map to distinct paths.
+type VersionKeyBuilder func(pipelineID string, versionID string) string
+
+// DefaultVersionKeyBuilder stores the versions of a pipeline under a folder named after
+// the pipeline, e.g. "<pipeline>/versions/<version>". The IDs are escaped, so IDs
+// containing "/" cannot collide with other versions.
+func DefaultVersionKeyBuilder(pipelineID string, versionID string) string {
+	return path.Join(url.PathEscape(pipelineID), "versions", url.PathEscape(versionID))
+}
+
+// SetVersionKeyBuilder sets the layout of the keys of pipeline versions. A nil builder
+// restores the default layout. Keys built by GetPipelineKey are unaffected.
+func (m *MinioObjectStore) SetVersionKeyBuilder(builder VersionKeyBuilder) {
+	m.versionKeyBuilder = builder
+}
+
+// GetVersionKey returns the key of the spec of a pipeline version, under the configured
+// base folder.
+func (m *MinioObjectStore) GetVersionKey(pipelineID string, versionID string) string {
+	builder := m.versionKeyBuilder
+	if builder == nil {
+		builder = DefaultVersionKeyBuilder
+	}
+	return path.Join(m.baseFolder, builder(pipelineID, versionID))
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVersionKey_Default(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+
+	assert.Equal(t, "pipelines/p1/versions/v1", manager.GetVersionKey("p1", "v1"))
+	// Single ID keys are unchanged.
+	assert.Equal(t, "pipelines/p1", manager.GetPipelineKey("p1"))
+}
+
+func TestGetVersionKey_CollisionFree(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+	keys := map[string]bool{}
+	for _, ids := range [][2]string{
+		{"a/b", "c"},
+		{"a", "b/c"},
+		{"a", "c"},
+		{"b", "c"},
+		{"a/versions", "c"},
+		{"a", "versions/c"},
+	} {
+		key := manager.GetVersionKey(ids[0], ids[1])
+		assert.False(t, keys[key], "duplicate key %v", key)
+		keys[key] = true
+		assert.NotEqual(t, manager.GetPipelineKey(ids[0]), key)
+	}
+}
+
+func TestGetVersionKey_CustomBuilder(t *testing.T) {
+	manager := NewMinioObjectStoreWithOptions(NewFakeMinioClient(), "", "pipelines",
+		WithVersionKeyBuilder(func(pipelineID string, versionID string) string {
+			return path.Join("by-version", versionID, pipelineID)
+		}))
+
+	key := manager.GetVersionKey("p1", "v1")
+	assert.Equal(t, "pipelines/by-version/v1/p1", key)
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), key))
+	data, err := manager.GetFile(context.TODO(), manager.GetVersionKey("p1", "v1"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("spec"), data)
+
+	manager.SetVersionKeyBuilder(nil)
+	assert.Equal(t, "pipelines/p1/versions/v1", manager.GetVersionKey("p1", "v1"))
+}
+
+func TestGetVersionKey_Namespaced(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+	manager.SetKeyNamespacer(DefaultKeyNamespacer)
+
+	assert.Equal(t, "pipelines/p1/versions/v1", manager.GetVersionKey("p1", "v1"))
+	assert.Equal(t, "spec/pipelines/p1/versions/v1", manager.resolveKey(context.TODO(), manager.GetVersionKey("p1", "v1")))
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// initialVisibilityPollInterval is the wait before a written object is first looked up again.
+	initialVisibilityPollInterval = 10 * time.Millisecond
+	// maxVisibilityPollInterval bounds the wait between the lookups of a written object.
+	maxVisibilityPollInterval = 500 * time.Millisecond
+)
+
+// ErrWriteNotVisible is the cause of errors returned for writes that could not be read back in time.
+var ErrWriteNotVisible = errors.New("written object not visible")
+
+// SetReadAfterWriteTimeout makes AddFile wait, for up to timeout, until the file written
+// can be read back, for backends that are only eventually consistent for read-after-write.
+// Zero, the default, returns as soon as the write succeeds, which suits strongly
+// consistent backends.
+func (m *MinioObjectStore) SetReadAfterWriteTimeout(timeout time.Duration) {
+	m.readAfterWriteTimeout = timeout
+}
+
+// waitUntilVisible polls the object at key until the backend reports it, or the read after
+// write timeout expires.
+func (m *MinioObjectStore) waitUntilVisible(ctx context.Context, key string, filePath string) error {
+	timeout := time.NewTimer(m.readAfterWriteTimeout)
+	defer timeout.Stop()
+	interval := initialVisibilityPollInterval
+	for {
+		_, err := m.minioClient.StatObject(ctx, m.bucketName, key, m.getObjectOptions(ctx))
+		if err == nil {
+			return nil
+		}
+		if ClassifyError(err) != ErrNotFound {
+			return newObjectStoreError(err, "Failed to check file %v is visible", filePath)
+		}
+		select {
+		case <-ctx.Done():
+			return util.NewUnavailableServerError(ctx.Err(), "Failed to check file %v is visible", filePath)
+		case <-timeout.C:
+			return util.NewUnavailableServerError(ErrWriteNotVisible,
+				"File %v not visible %v after it was written", filePath, m.readAfterWriteTimeout)
+		case <-time.After(interval):
+		}
+		interval = min(2*interval, maxVisibilityPollInterval)
+	}
+}
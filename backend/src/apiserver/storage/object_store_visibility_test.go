@@ -0,0 +1,83 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+)
+
+func newVisibilityTestStore(t *testing.T, timeout time.Duration) (*MinioObjectStore, *MockMinioClient) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	manager := NewMinioObjectStoreWithOptions(minioClient, "mlpipeline", "pipelines", WithReadAfterWriteTimeout(timeout))
+	minioClient.EXPECT().
+		PutObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(int64(4), nil)
+	return manager, minioClient
+}
+
+func TestAddFile_WaitsUntilVisible(t *testing.T) {
+	manager, minioClient := newVisibilityTestStore(t, time.Minute)
+	gomock.InOrder(
+		minioClient.EXPECT().
+			StatObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any()).
+			Return(minio.ObjectInfo{}, newFakeNoSuchKeyError("pipelines/1")),
+		minioClient.EXPECT().
+			StatObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any()).
+			Return(minio.ObjectInfo{Key: "pipelines/1", Size: 4}, nil),
+	)
+
+	assert.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), manager.GetPipelineKey("1")))
+}
+
+func TestAddFile_NotVisibleInTime(t *testing.T) {
+	manager, minioClient := newVisibilityTestStore(t, 30*time.Millisecond)
+	minioClient.EXPECT().
+		StatObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any()).
+		Return(minio.ObjectInfo{}, newFakeNoSuchKeyError("pipelines/1")).
+		MinTimes(2)
+
+	err := manager.AddFile(context.TODO(), []byte("spec"), manager.GetPipelineKey("1"))
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrWriteNotVisible))
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestAddFile_VisibilityCheckError(t *testing.T) {
+	manager, minioClient := newVisibilityTestStore(t, time.Minute)
+	minioClient.EXPECT().
+		StatObject(gomock.Any(), "mlpipeline", "pipelines/1", gomock.Any()).
+		Return(minio.ObjectInfo{}, errAccessDenied)
+
+	err := manager.AddFile(context.TODO(), []byte("spec"), manager.GetPipelineKey("1"))
+	require.NotNil(t, err)
+	assert.Equal(t, codes.PermissionDenied, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestAddFile_NoVisibilityWaitByDefault(t *testing.T) {
+	// No stat is expected.
+	manager, _ := newVisibilityTestStore(t, 0)
+
+	assert.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), manager.GetPipelineKey("1")))
+}
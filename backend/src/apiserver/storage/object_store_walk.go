@@ -0,0 +1,76 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	minio "github.com/minio/minio-go/v7"
+)
+
+// WalkFiles calls fn with every file stored under prefix, in key order, as the listing is
+// streamed, so memory use does not grow with the number of files. The walk stops at the
+// first error returned by fn, which is returned as is, or when ctx is cancelled.
+func (m *MinioObjectStore) WalkFiles(ctx context.Context, prefix string, fn func(FileInfo) error) error {
+	if err := m.checkOpen("list files under", prefix); err != nil {
+		return err
+	}
+	listCtx, cancel := context.WithCancel(ctx)
+	// Stops the listing if the walk ends early.
+	defer cancel()
+
+	resolvedPrefix := m.resolvePrefix(ctx, prefix)
+	opts := minio.ListObjectsOptions{Prefix: m.transformPrefix(resolvedPrefix), Recursive: true}
+	for object := range m.minioClient.ListObjects(listCtx, m.bucketName, opts) {
+		if err := ctx.Err(); err != nil {
+			return util.NewUnavailableServerError(err, "Failed to list files under %v", prefix)
+		}
+		if object.Err != nil {
+			return newObjectStoreError(object.Err, "Failed to list files under %v", prefix)
+		}
+		key, ok := m.logicalKey(object.Key)
+		if !ok || m.isRecycleBinKey(key) {
+			continue
+		}
+		filePath := prefix + strings.TrimPrefix(key, resolvedPrefix)
+		if err := fn(*newFileInfo(filePath, object)); err != nil {
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return util.NewUnavailableServerError(err, "Failed to list files under %v", prefix)
+	}
+	return nil
+}
+
+// ListFilesModifiedSince returns the files under prefix, in key order, last modified after
+// since, e.g. so an indexer only pulls the files changed since its last run. The listing is
+// streamed and filtered as it goes, so only the matching files are held in memory.
+func (m *MinioObjectStore) ListFilesModifiedSince(ctx context.Context, prefix string, since time.Time) ([]FileInfo, error) {
+	var files []FileInfo
+	err := m.WalkFiles(ctx, prefix, func(file FileInfo) error {
+		if file.LastModified.After(since) {
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newWalkTestStore(t *testing.T) *MinioObjectStore {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+	for _, filePath := range []string{"pipelines/1", "pipelines/2", "pipelines/3/spec", "artifacts/1"} {
+		require.Nil(t, manager.AddFile(context.TODO(), []byte(filePath), filePath))
+	}
+	return manager
+}
+
+func TestWalkFiles(t *testing.T) {
+	manager := newWalkTestStore(t)
+
+	var walked []string
+	err := manager.WalkFiles(context.TODO(), "pipelines/", func(info FileInfo) error {
+		walked = append(walked, info.Key)
+		assert.Equal(t, int64(len(info.Key)), info.Size)
+		assert.NotEmpty(t, info.ETag)
+		return nil
+	})
+	require.Nil(t, err)
+	assert.Equal(t, []string{"pipelines/1", "pipelines/2", "pipelines/3/spec"}, walked)
+}
+
+func TestWalkFiles_StopsOnCallbackError(t *testing.T) {
+	manager := newWalkTestStore(t)
+	stop := errors.New("stop")
+
+	var walked []string
+	err := manager.WalkFiles(context.TODO(), "pipelines/", func(info FileInfo) error {
+		walked = append(walked, info.Key)
+		if len(walked) == 2 {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, []string{"pipelines/1", "pipelines/2"}, walked)
+}
+
+func TestWalkFiles_StopsOnCancel(t *testing.T) {
+	manager := newWalkTestStore(t)
+	ctx, cancel := context.WithCancel(context.TODO())
+
+	walked := 0
+	err := manager.WalkFiles(ctx, "pipelines/", func(info FileInfo) error {
+		walked++
+		cancel()
+		return nil
+	})
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 1, walked)
+}
+
+func TestWalkFiles_Namespaced(t *testing.T) {
+	manager := &MinioObjectStore{minioClient: NewFakeMinioClient(), baseFolder: "pipelines"}
+	manager.SetKeyNamespacer(DefaultKeyNamespacer)
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec"), "pipelines/1"))
+
+	var walked []string
+	err := manager.WalkFiles(context.TODO(), "pipelines/", func(info FileInfo) error {
+		walked = append(walked, info.Key)
+		return nil
+	})
+	require.Nil(t, err)
+	assert.Equal(t, []string{"pipelines/1"}, walked)
+}
+
+func TestListFilesModifiedSince(t *testing.T) {
+	since := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	minioClient.EXPECT().
+		ListObjects(gomock.Any(), "mlpipeline", minio.ListObjectsOptions{Prefix: "pipelines/", Recursive: true}).
+		DoAndReturn(func(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo {
+			objects := make(chan minio.ObjectInfo, 4)
+			objects <- minio.ObjectInfo{Key: "pipelines/1", LastModified: since.Add(-time.Hour)}
+			objects <- minio.ObjectInfo{Key: "pipelines/2", LastModified: since.Add(time.Second)}
+			objects <- minio.ObjectInfo{Key: "pipelines/3", LastModified: since}
+			objects <- minio.ObjectInfo{Key: "pipelines/4", LastModified: since.Add(24 * time.Hour)}
+			close(objects)
+			return objects
+		})
+	manager := &MinioObjectStore{minioClient: minioClient, bucketName: "mlpipeline", baseFolder: "pipelines"}
+
+	files, err := manager.ListFilesModifiedSince(context.TODO(), "pipelines/", since)
+
+	require.Nil(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "pipelines/2", files[0].Key)
+	assert.Equal(t, "pipelines/4", files[1].Key)
+}
+
+func TestListFilesModifiedSince_ListingError(t *testing.T) {
+	minioClient := NewMockMinioClient(gomock.NewController(t))
+	minioClient.EXPECT().
+		ListObjects(gomock.Any(), "mlpipeline", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo {
+			objects := make(chan minio.ObjectInfo, 1)
+			objects <- minio.ObjectInfo{Err: errors.New("connection reset")}
+			close(objects)
+			return objects
+		})
+	manager := &MinioObjectStore{minioClient: minioClient, bucketName: "mlpipeline", baseFolder: "pipelines"}
+
+	_, err := manager.ListFilesModifiedSince(context.TODO(), "pipelines/", time.Time{})
+
+	assert.NotNil(t, err)
+}
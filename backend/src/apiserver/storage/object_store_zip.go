@@ -0,0 +1,75 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+// zipErrorSuffix is appended to the names of the entries recording files that could not
+// be added to a zip.
+const zipErrorSuffix = ".error"
+
+// StreamZip writes a zip of the files at filePaths to w, one entry per file named after
+// its path. Files are streamed one at a time, decompressed like GetFileDecompressedReader
+// does, so memory use does not grow with their size. A missing file does not abort the
+// zip: it is recorded as an entry named after its path with the ".error" suffix, holding
+// the error. Other failures abort the zip, leaving w with a truncated archive.
+func (m *MinioObjectStore) StreamZip(ctx context.Context, filePaths []string, w io.Writer) error {
+	zipWriter := zip.NewWriter(w)
+	for _, filePath := range filePaths {
+		if err := m.addZipEntry(ctx, zipWriter, filePath); err != nil {
+			return err
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		return util.NewInternalServerError(err, "Failed to write zip")
+	}
+	return nil
+}
+
+func (m *MinioObjectStore) addZipEntry(ctx context.Context, zipWriter *zip.Writer, filePath string) error {
+	name := strings.TrimPrefix(filePath, "/")
+	reader, err := m.GetFileDecompressedReader(ctx, filePath)
+	if errors.Is(err, ErrNotFound) {
+		entry, err := zipWriter.Create(name + zipErrorSuffix)
+		if err == nil {
+			_, err = fmt.Fprintf(entry, "file %v not found\n", filePath)
+		}
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to write zip entry of file %v", filePath)
+		}
+		return nil
+	}
+	if err != nil {
+		return util.Wrap(err, "Failed to add file to zip")
+	}
+	defer reader.Close()
+	entry, err := zipWriter.Create(name)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to write zip entry of file %v", filePath)
+	}
+	if _, err := io.Copy(entry, reader); err != nil {
+		return util.NewInternalServerError(err, "Failed to write zip entry of file %v", filePath)
+	}
+	return nil
+}
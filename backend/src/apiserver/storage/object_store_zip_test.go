@@ -0,0 +1,91 @@
+// Copyright 2025 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readZip returns the content of the entries of a zip, by name.
+func readZip(t *testing.T, data []byte) map[string]string {
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.Nil(t, err)
+	entries := make(map[string]string)
+	for _, file := range zipReader.File {
+		reader, err := file.Open()
+		require.Nil(t, err)
+		content, err := io.ReadAll(reader)
+		require.Nil(t, err)
+		reader.Close()
+		entries[file.Name] = string(content)
+	}
+	return entries
+}
+
+func TestStreamZip(t *testing.T) {
+	manager := NewMinioObjectStore(NewFakeMinioClient(), "mlpipeline", "pipelines", false)
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec 1"), "pipelines/1"))
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec 2"), "pipelines/2"))
+	require.Nil(t, manager.AddFileFromReader(context.TODO(), strings.NewReader("compressed spec"), "pipelines/3", true))
+
+	var buf bytes.Buffer
+	err := manager.StreamZip(context.TODO(), []string{"pipelines/1", "pipelines/2", "pipelines/3"}, &buf)
+
+	require.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"pipelines/1": "spec 1",
+		"pipelines/2": "spec 2",
+		"pipelines/3": "compressed spec",
+	}, readZip(t, buf.Bytes()))
+}
+
+func TestStreamZip_MissingFile(t *testing.T) {
+	manager := NewMinioObjectStore(NewFakeMinioClient(), "mlpipeline", "pipelines", false)
+	require.Nil(t, manager.AddFile(context.TODO(), []byte("spec 1"), "pipelines/1"))
+
+	var buf bytes.Buffer
+	err := manager.StreamZip(context.TODO(), []string{"pipelines/missing", "pipelines/1"}, &buf)
+
+	require.Nil(t, err)
+	entries := readZip(t, buf.Bytes())
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "spec 1", entries["pipelines/1"])
+	assert.Contains(t, entries["pipelines/missing.error"], "not found")
+}
+
+func TestStreamZip_Error(t *testing.T) {
+	manager := NewMinioObjectStore(&FakeBadMinioClient{}, "mlpipeline", "pipelines", false)
+
+	err := manager.StreamZip(context.TODO(), []string{"pipelines/1"}, io.Discard)
+
+	assert.NotNil(t, err)
+}
+
+func TestStreamZip_Empty(t *testing.T) {
+	manager := NewMinioObjectStore(NewFakeMinioClient(), "mlpipeline", "pipelines", false)
+
+	var buf bytes.Buffer
+	require.Nil(t, manager.StreamZip(context.TODO(), nil, &buf))
+
+	assert.Empty(t, readZip(t, buf.Bytes()))
+}
@@ -238,6 +238,14 @@ func NewPermissionDeniedError(err error, externalFormat string, a ...interface{}
 		codes.PermissionDenied)
 }
 
+func NewResourceExhaustedError(err error, externalFormat string, a ...interface{}) *UserError {
+	externalMessage := fmt.Sprintf(externalFormat, a...)
+	return newUserError(
+		errors.Wrapf(err, "ResourceExhausted: %v", externalMessage),
+		externalMessage,
+		codes.ResourceExhausted)
+}
+
 func NewUnknownApiVersionError(a string, o interface{}) *UserError {
 	externalMessage := fmt.Sprintf("Error using %s with %T", a, o)
 	return newUserError(